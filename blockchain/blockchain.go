@@ -41,6 +41,8 @@ var (
 	ErrInvalidStateRoot     = errors.New("invalid block state root")
 	ErrInvalidGasUsed       = errors.New("invalid block gas used")
 	ErrInvalidReceiptsRoot  = errors.New("invalid block receipts root")
+	ErrInvalidReceipt       = errors.New("receipt doesn't match the transaction")
+	ErrTxTypeNotEnabled     = errors.New("transaction type not enabled for the block")
 )
 
 // Blockchain is a blockchain reference
@@ -620,6 +622,67 @@ func (b *Blockchain) VerifyFinalizedBlock(block *types.Block) (*types.FullBlock,
 	return &types.FullBlock{Block: block, Receipts: receipts}, nil
 }
 
+// VerifyFinalizedBlockWithReceipts verifies the finalized block along with its receipts
+// without executing the transactions, since the state of the parent block may not be known locally.
+// The receipts are proven by the receipts root of the header
+func (b *Blockchain) VerifyFinalizedBlockWithReceipts(
+	block *types.Block,
+	receipts []*types.Receipt,
+) (*types.FullBlock, error) {
+	if block == nil {
+		return nil, ErrNoBlock
+	}
+
+	if err := b.consensus.VerifyHeader(block.Header); err != nil {
+		return nil, fmt.Errorf("failed to verify the header: %w", err)
+	}
+
+	if err := b.verifyBlockParent(block); err != nil {
+		return nil, err
+	}
+
+	if err := b.verifyBlockRoots(block); err != nil {
+		return nil, err
+	}
+
+	if err := b.verifyBlockTxTypes(block); err != nil {
+		return nil, err
+	}
+
+	if err := verifyBlockReceipts(block, receipts); err != nil {
+		return nil, err
+	}
+
+	return &types.FullBlock{Block: block, Receipts: receipts}, nil
+}
+
+// verifyBlockReceipts makes sure the receipts match up with the block,
+// the fields which aren't part of the receipts root are checked against the transactions
+func verifyBlockReceipts(block *types.Block, receipts []*types.Receipt) error {
+	var gasUsed uint64
+
+	for i, receipt := range receipts {
+		if i >= len(block.Transactions) || receipt.TxHash != block.Transactions[i].Hash {
+			return ErrInvalidReceipt
+		}
+
+		if receipt.CumulativeGasUsed < gasUsed || receipt.GasUsed != receipt.CumulativeGasUsed-gasUsed {
+			return ErrInvalidReceipt
+		}
+
+		gasUsed = receipt.CumulativeGasUsed
+	}
+
+	// the state root can't be checked without the state
+	result := &BlockResult{
+		Root:     block.Header.StateRoot,
+		Receipts: receipts,
+		TotalGas: gasUsed,
+	}
+
+	return result.verifyBlockResult(block)
+}
+
 // verifyBlock does the base (common) block verification steps by
 // verifying the block body as well as the parent information
 func (b *Blockchain) verifyBlock(block *types.Block) ([]*types.Receipt, error) {
@@ -690,9 +753,34 @@ func (b *Blockchain) verifyBlockParent(childBlock *types.Block) error {
 
 // verifyBlockBody verifies that the block body is valid. This means checking:
 // - The trie roots match up (state, transactions, receipts, uncles)
+// - The transaction types are enabled for the block
 // - The receipts match up
 // - The execution result matches up
 func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, error) {
+	if err := b.verifyBlockRoots(block); err != nil {
+		return nil, err
+	}
+
+	if err := b.verifyBlockTxTypes(block); err != nil {
+		return nil, err
+	}
+
+	// Execute the transactions in the block and grab the result
+	blockResult, executeErr := b.executeBlockTransactions(block)
+	if executeErr != nil {
+		return nil, fmt.Errorf("unable to execute block transactions, %w", executeErr)
+	}
+
+	// Verify the local execution result with the proposed block data
+	if err := blockResult.verifyBlockResult(block); err != nil {
+		return nil, fmt.Errorf("unable to verify block execution result, %w", err)
+	}
+
+	return blockResult.Receipts, nil
+}
+
+// verifyBlockRoots makes sure the uncles and the transactions of the block match up with its header
+func (b *Blockchain) verifyBlockRoots(block *types.Block) error {
 	// Make sure the Uncles root matches up
 	if hash := buildroot.CalculateUncleRoot(block.Uncles); hash != block.Header.Sha3Uncles {
 		b.logger.Error(fmt.Sprintf(
@@ -701,7 +789,7 @@ func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, erro
 			block.Header.Sha3Uncles,
 		))
 
-		return nil, ErrInvalidSha3Uncles
+		return ErrInvalidSha3Uncles
 	}
 
 	// Make sure the transactions root matches up
@@ -712,21 +800,26 @@ func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, erro
 			block.Header.TxRoot,
 		))
 
-		return nil, ErrInvalidTxRoot
+		return ErrInvalidTxRoot
 	}
 
-	// Execute the transactions in the block and grab the result
-	blockResult, executeErr := b.executeBlockTransactions(block)
-	if executeErr != nil {
-		return nil, fmt.Errorf("unable to execute block transactions, %w", executeErr)
+	return nil
+}
+
+// verifyBlockTxTypes makes sure the block has no transactions
+// of the types which are introduced by the forks not enabled for it
+func (b *Blockchain) verifyBlockTxTypes(block *types.Block) error {
+	if b.config.Params.Forks.IsActive(chain.SponsoredTx, block.Number()) {
+		return nil
 	}
 
-	// Verify the local execution result with the proposed block data
-	if err := blockResult.verifyBlockResult(block); err != nil {
-		return nil, fmt.Errorf("unable to verify block execution result, %w", err)
+	for _, tx := range block.Transactions {
+		if tx.IsSponsored() {
+			return fmt.Errorf("%w: %s at block %d", ErrTxTypeNotEnabled, tx.Type, block.Number())
+		}
 	}
 
-	return blockResult.Receipts, nil
+	return nil
 }
 
 // verifyBlockResult verifies that the block transaction execution result
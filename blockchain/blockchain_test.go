@@ -20,6 +20,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/blockchain/storage"
 	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
 )
 
 func TestGenesis(t *testing.T) {
@@ -1519,3 +1520,111 @@ func TestBlockchain_WriteFullBlock(t *testing.T) {
 	require.NotNil(t, db[hex.EncodeToHex(getKey(storage.CANONICAL, common.EncodeUint64ToBytes(header.Number)))])
 	require.NotNil(t, db[hex.EncodeToHex(getKey(storage.RECEIPTS, header.Hash.Bytes()))])
 }
+
+func TestBlockchain_VerifyBlockTxTypes(t *testing.T) {
+	t.Parallel()
+
+	b := &Blockchain{
+		config: &chain.Chain{
+			Params: &chain.Params{
+				Forks: &chain.Forks{
+					chain.SponsoredTx: chain.NewFork(5),
+				},
+			},
+		},
+	}
+
+	newBlock := func(number uint64, txType types.TxType) *types.Block {
+		return &types.Block{
+			Header:       &types.Header{Number: number},
+			Transactions: []*types.Transaction{{Type: types.DynamicFeeTx}, {Type: txType}},
+		}
+	}
+
+	assert.NoError(t, b.verifyBlockTxTypes(newBlock(4, types.LegacyTx)))
+	assert.ErrorIs(t, b.verifyBlockTxTypes(newBlock(4, types.SponsoredTx)), ErrTxTypeNotEnabled)
+	assert.NoError(t, b.verifyBlockTxTypes(newBlock(5, types.SponsoredTx)))
+}
+
+func TestBlockchain_VerifyBlockReceipts(t *testing.T) {
+	t.Parallel()
+
+	success, failed := types.ReceiptSuccess, types.ReceiptFailed
+
+	txs := []*types.Transaction{
+		{Nonce: 1, Hash: types.StringToHash("1")},
+		{Nonce: 2, Hash: types.StringToHash("2")},
+	}
+
+	newReceipts := func() []*types.Receipt {
+		return []*types.Receipt{
+			{TxHash: txs[0].Hash, GasUsed: 21000, CumulativeGasUsed: 21000, Status: &success},
+			{TxHash: txs[1].Hash, GasUsed: 30000, CumulativeGasUsed: 51000, Status: &success},
+		}
+	}
+
+	header := &types.Header{
+		GasUsed:      51000,
+		ReceiptsRoot: buildroot.CalculateReceiptsRoot(newReceipts()),
+	}
+
+	testCases := []struct {
+		name     string
+		modify   func([]*types.Receipt) []*types.Receipt
+		expected error
+	}{
+		{
+			name:   "valid receipts",
+			modify: func(r []*types.Receipt) []*types.Receipt { return r },
+		},
+		{
+			name:     "missing receipt",
+			modify:   func(r []*types.Receipt) []*types.Receipt { return r[:1] },
+			expected: ErrInvalidReceiptsSize,
+		},
+		{
+			name: "transaction hash mismatch",
+			modify: func(r []*types.Receipt) []*types.Receipt {
+				r[1].TxHash = types.StringToHash("3")
+
+				return r
+			},
+			expected: ErrInvalidReceipt,
+		},
+		{
+			name: "gas used mismatch",
+			modify: func(r []*types.Receipt) []*types.Receipt {
+				r[1].GasUsed = 1
+
+				return r
+			},
+			expected: ErrInvalidReceipt,
+		},
+		{
+			name: "receipts root mismatch",
+			modify: func(r []*types.Receipt) []*types.Receipt {
+				r[1].Status = &failed
+
+				return r
+			},
+			expected: ErrInvalidReceiptsRoot,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			block := &types.Block{Header: header, Transactions: txs}
+
+			err := verifyBlockReceipts(block, tc.modify(newReceipts()))
+			if tc.expected == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.expected)
+			}
+		})
+	}
+}
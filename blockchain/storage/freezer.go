@@ -0,0 +1,328 @@
+package storage
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// Tables of the freezer, the items of each table are indexed by the block number
+const (
+	FreezerHeaders  = "headers"
+	FreezerBodies   = "bodies"
+	FreezerReceipts = "receipts"
+)
+
+// freezerIndexEntrySize is the size of the end offset of an item in the index file
+const freezerIndexEntrySize = 8
+
+var freezerTables = []string{FreezerHeaders, FreezerBodies, FreezerReceipts}
+
+var (
+	ErrFreezerOutOfOrder = errors.New("the freezer items have to be appended in the order of the block numbers")
+)
+
+// Freezer is the append-only store of the old canonical blocks. Each table keeps the items
+// in a flat data file along with the index file of their end offsets, so the item of the block
+// is read at the offset found by its number without any lookups in the database
+type Freezer struct {
+	logger hclog.Logger
+
+	lock   sync.RWMutex
+	tables map[string]*freezerTable
+	// count is the number of the blocks in the freezer, the blocks from the genesis are frozen
+	count uint64
+}
+
+// freezerTable is the data file of the table along with its index file
+type freezerTable struct {
+	data  *os.File
+	index *os.File
+	// items is the number of the items in the table
+	items uint64
+	// size is the size of the data file
+	size uint64
+}
+
+// NewFreezer opens the freezer at the path, it's created if it doesn't exist.
+// The items appended partially before a crash are truncated
+func NewFreezer(path string, logger hclog.Logger) (*Freezer, error) {
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return nil, err
+	}
+
+	f := &Freezer{
+		logger: logger.Named("freezer"),
+		tables: make(map[string]*freezerTable, len(freezerTables)),
+	}
+
+	for i, name := range freezerTables {
+		table, err := openFreezerTable(path, name)
+		if err != nil {
+			_ = f.Close()
+
+			return nil, fmt.Errorf("failed to open the freezer table %s: %w", name, err)
+		}
+
+		f.tables[name] = table
+
+		if i == 0 || table.items < f.count {
+			f.count = table.items
+		}
+	}
+
+	// a crash may leave the tables with the different number of the items
+	for name, table := range f.tables {
+		if err := table.truncate(f.count); err != nil {
+			_ = f.Close()
+
+			return nil, fmt.Errorf("failed to truncate the freezer table %s: %w", name, err)
+		}
+	}
+
+	return f, nil
+}
+
+// Count returns the number of the blocks in the freezer
+func (f *Freezer) Count() uint64 {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return f.count
+}
+
+// Get returns the item of the table for the block with the given number,
+// false if the block isn't frozen or its item is empty
+func (f *Freezer) Get(table string, number uint64) ([]byte, bool, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	t, ok := f.tables[table]
+	if !ok {
+		return nil, false, fmt.Errorf("unknown freezer table %s", table)
+	}
+
+	if number >= f.count {
+		return nil, false, nil
+	}
+
+	data, err := t.get(number)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return data, len(data) > 0, nil
+}
+
+// Append appends the items of the block with the given number, which has to be the number of the frozen blocks.
+// The missing items of the tables are appended empty
+func (f *Freezer) Append(number uint64, items map[string][]byte) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if number != f.count {
+		return fmt.Errorf("%w, expected %d, got %d", ErrFreezerOutOfOrder, f.count, number)
+	}
+
+	for _, name := range freezerTables {
+		if err := f.tables[name].append(items[name]); err != nil {
+			return err
+		}
+	}
+
+	f.count++
+
+	return nil
+}
+
+// Sync flushes the appended items to the disk
+func (f *Freezer) Sync() error {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	for _, table := range f.tables {
+		// the data is synced first, so the index never points beyond it
+		if err := table.data.Sync(); err != nil {
+			return err
+		}
+
+		if err := table.index.Sync(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close closes the files of the freezer
+func (f *Freezer) Close() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var errs []error
+
+	for _, table := range f.tables {
+		if err := table.data.Close(); err != nil {
+			errs = append(errs, err)
+		}
+
+		if err := table.index.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func openFreezerTable(path, name string) (*freezerTable, error) {
+	data, err := os.OpenFile(filepath.Join(path, name+".dat"), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := os.OpenFile(filepath.Join(path, name+".idx"), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		_ = data.Close()
+
+		return nil, err
+	}
+
+	t := &freezerTable{data: data, index: index}
+
+	if err := t.repair(); err != nil {
+		_ = data.Close()
+		_ = index.Close()
+
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// repair drops the items whose index entry or data wasn't fully written
+func (t *freezerTable) repair() error {
+	indexStat, err := t.index.Stat()
+	if err != nil {
+		return err
+	}
+
+	dataStat, err := t.data.Stat()
+	if err != nil {
+		return err
+	}
+
+	t.items = uint64(indexStat.Size()) / freezerIndexEntrySize
+	t.size = uint64(dataStat.Size())
+
+	for t.items > 0 {
+		end, err := t.readOffset(t.items - 1)
+		if err != nil {
+			return err
+		}
+
+		if end <= t.size {
+			break
+		}
+
+		t.items--
+	}
+
+	return t.truncate(t.items)
+}
+
+// truncate drops the items starting from the given one
+func (t *freezerTable) truncate(items uint64) error {
+	size := uint64(0)
+
+	if items > 0 {
+		end, err := t.readOffset(items - 1)
+		if err != nil {
+			return err
+		}
+
+		size = end
+	}
+
+	if err := t.index.Truncate(int64(items * freezerIndexEntrySize)); err != nil {
+		return err
+	}
+
+	if err := t.data.Truncate(int64(size)); err != nil {
+		return err
+	}
+
+	t.items, t.size = items, size
+
+	return nil
+}
+
+// get reads the item with the given number
+func (t *freezerTable) get(number uint64) ([]byte, error) {
+	start := uint64(0)
+
+	if number > 0 {
+		offset, err := t.readOffset(number - 1)
+		if err != nil {
+			return nil, err
+		}
+
+		start = offset
+	}
+
+	end, err := t.readOffset(number)
+	if err != nil {
+		return nil, err
+	}
+
+	if end < start {
+		return nil, fmt.Errorf("corrupted freezer index at item %d", number)
+	}
+
+	data := make([]byte, end-start)
+	if _, err := t.data.ReadAt(data, int64(start)); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// append writes the item to the end of the data file, then its end offset to the index file
+func (t *freezerTable) append(item []byte) error {
+	if _, err := t.data.WriteAt(item, int64(t.size)); err != nil {
+		return err
+	}
+
+	var entry [freezerIndexEntrySize]byte
+
+	binary.BigEndian.PutUint64(entry[:], t.size+uint64(len(item)))
+
+	if _, err := t.index.WriteAt(entry[:], int64(t.items*freezerIndexEntrySize)); err != nil {
+		return err
+	}
+
+	t.size += uint64(len(item))
+	t.items++
+
+	return nil
+}
+
+// readOffset reads the end offset of the item with the given number
+func (t *freezerTable) readOffset(number uint64) (uint64, error) {
+	var entry [freezerIndexEntrySize]byte
+
+	if _, err := t.index.ReadAt(entry[:], int64(number*freezerIndexEntrySize)); err != nil {
+		if errors.Is(err, io.EOF) {
+			return 0, fmt.Errorf("freezer index entry %d not found", number)
+		}
+
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(entry[:]), nil
+}
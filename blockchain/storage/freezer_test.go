@@ -0,0 +1,162 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFreezer(t *testing.T, path string) *Freezer {
+	t.Helper()
+
+	f, err := NewFreezer(path, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	return f
+}
+
+func freezerItems(number uint64) map[string][]byte {
+	items := map[string][]byte{
+		FreezerHeaders: []byte{byte(number), 0x1},
+		FreezerBodies:  make([]byte, number*10),
+	}
+
+	if number%2 == 0 {
+		items[FreezerReceipts] = []byte{byte(number), 0x2, 0x3}
+	}
+
+	return items
+}
+
+func TestFreezer_AppendGet(t *testing.T) {
+	t.Parallel()
+
+	path := t.TempDir()
+	f := newTestFreezer(t, path)
+
+	for i := uint64(0); i < 20; i++ {
+		require.NoError(t, f.Append(i, freezerItems(i)))
+	}
+
+	require.ErrorIs(t, f.Append(30, freezerItems(30)), ErrFreezerOutOfOrder)
+	require.NoError(t, f.Sync())
+	require.NoError(t, f.Close())
+
+	// the items are read after reopening the freezer
+	f = newTestFreezer(t, path)
+	defer f.Close()
+
+	require.Equal(t, uint64(20), f.Count())
+
+	for i := uint64(0); i < 20; i++ {
+		for name, expected := range freezerItems(i) {
+			data, ok, err := f.Get(name, i)
+			require.NoError(t, err)
+			assert.Equal(t, len(expected) > 0, ok)
+			assert.Equal(t, expected, data)
+		}
+
+		_, ok, err := f.Get(FreezerReceipts, i)
+		require.NoError(t, err)
+		assert.Equal(t, i%2 == 0, ok)
+	}
+
+	_, ok, err := f.Get(FreezerHeaders, 20)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = f.Get("unknown", 0)
+	require.Error(t, err)
+}
+
+func TestFreezer_Repair(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		damage   func(t *testing.T, path string)
+		expected uint64
+	}{
+		{
+			name: "partially written index entry",
+			damage: func(t *testing.T, path string) {
+				t.Helper()
+
+				truncateFile(t, filepath.Join(path, FreezerHeaders+".idx"), 9*freezerIndexEntrySize+3)
+			},
+			expected: 9,
+		},
+		{
+			name: "index entry beyond the data",
+			damage: func(t *testing.T, path string) {
+				t.Helper()
+
+				// the body of the block 9 is 90 bytes long
+				stat, err := os.Stat(filepath.Join(path, FreezerBodies+".dat"))
+				require.NoError(t, err)
+
+				truncateFile(t, filepath.Join(path, FreezerBodies+".dat"), stat.Size()-50)
+			},
+			expected: 9,
+		},
+		{
+			name: "missing table",
+			damage: func(t *testing.T, path string) {
+				t.Helper()
+
+				require.NoError(t, os.Remove(filepath.Join(path, FreezerReceipts+".idx")))
+			},
+			expected: 0,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := t.TempDir()
+			f := newTestFreezer(t, path)
+
+			for i := uint64(0); i < 10; i++ {
+				require.NoError(t, f.Append(i, freezerItems(i)))
+			}
+
+			require.NoError(t, f.Close())
+
+			c.damage(t, path)
+
+			f = newTestFreezer(t, path)
+			defer f.Close()
+
+			require.Equal(t, c.expected, f.Count())
+
+			for i := uint64(0); i < c.expected; i++ {
+				data, _, err := f.Get(FreezerBodies, i)
+				require.NoError(t, err)
+				assert.Equal(t, freezerItems(i)[FreezerBodies], data)
+			}
+
+			// the damaged items are appended again
+			for i := c.expected; i < 10; i++ {
+				require.NoError(t, f.Append(i, freezerItems(i)))
+			}
+
+			data, ok, err := f.Get(FreezerHeaders, 9)
+			require.NoError(t, err)
+			require.True(t, ok)
+			assert.Equal(t, freezerItems(9)[FreezerHeaders], data)
+		})
+	}
+}
+
+func truncateFile(t *testing.T, path string, size int64) {
+	t.Helper()
+
+	require.NoError(t, os.Truncate(path, size))
+}
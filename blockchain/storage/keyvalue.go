@@ -2,8 +2,11 @@
 package storage
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
+	"sync"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/types"
@@ -39,6 +42,12 @@ var (
 
 	// TX_LOOKUP_PREFIX is the prefix for transaction lookups
 	TX_LOOKUP_PREFIX = []byte("l")
+
+	// FROZEN_NUMBER is the prefix for the numbers of the frozen blocks
+	FROZEN_NUMBER = []byte("n")
+
+	// FROZEN is the entry of the number of the blocks removed from the database once they were frozen
+	FROZEN = []byte("z")
 )
 
 // Sub-prefixes
@@ -62,6 +71,12 @@ type KeyValueStorage struct {
 	logger hclog.Logger
 	db     KV
 	Db     KV
+
+	// freezer keeps the old canonical blocks, nil if it's disabled
+	freezer        *Freezer
+	freezerDepth   uint64
+	freezerCloseCh chan struct{}
+	freezerWg      sync.WaitGroup
 }
 
 func NewKeyValueStorage(logger hclog.Logger, db KV) Storage {
@@ -148,8 +163,8 @@ func (s *KeyValueStorage) ReadBody(hash types.Hash) (*types.Body, error) {
 	}
 
 	// must read header because block number is needed in order to calculate each tx hash
-	header := &types.Header{}
-	if err := s.readRLP(HEADER, hash.Bytes(), header); err != nil {
+	header, err := s.ReadHeader(hash)
+	if err != nil {
 		return nil, err
 	}
 
@@ -191,16 +206,192 @@ func (s *KeyValueStorage) ReadTxLookup(hash types.Hash) (types.Hash, bool) {
 	return types.BytesToHash(blockHash), true
 }
 
+// FREEZER //
+
+const (
+	// freezeInterval is how often the blocks deeper than the freezer depth are moved to the freezer
+	freezeInterval = time.Minute
+
+	// freezeBatchSize is the max number of the blocks moved to the freezer at once
+	freezeBatchSize = 10000
+)
+
+// frozenTables are the freezer tables of the block data by its prefix
+var frozenTables = map[string]string{
+	string(HEADER):   FreezerHeaders,
+	string(BODY):     FreezerBodies,
+	string(RECEIPTS): FreezerReceipts,
+}
+
+var errFreezerBehind = errors.New("the database has more frozen blocks than the freezer")
+
+// StartFreezer starts moving the canonical blocks deeper than the given depth below the head
+// from the database to the freezer, their headers, bodies and receipts are read from the freezer afterwards.
+// It has to be called before the storage is used
+func (s *KeyValueStorage) StartFreezer(freezer *Freezer, depth uint64) {
+	s.freezer = freezer
+	s.freezerDepth = depth
+	s.freezerCloseCh = make(chan struct{})
+
+	s.freezerWg.Add(1)
+
+	go func() {
+		defer s.freezerWg.Done()
+
+		for {
+			frozen, err := s.freeze()
+			if err != nil {
+				s.logger.Error("failed to freeze the blocks", "err", err)
+			}
+
+			wait := freezeInterval
+			if err == nil && frozen == freezeBatchSize {
+				// there are more blocks to freeze
+				wait = 0
+			}
+
+			select {
+			case <-s.freezerCloseCh:
+				return
+			case <-time.After(wait):
+			}
+		}
+	}()
+}
+
+// readFrozen reads the data of the frozen block with the given hash from the freezer table of the prefix
+func (s *KeyValueStorage) readFrozen(p, hash []byte) ([]byte, bool, error) {
+	if s.freezer == nil {
+		return nil, false, nil
+	}
+
+	table, ok := frozenTables[string(p)]
+	if !ok {
+		return nil, false, nil
+	}
+
+	data, ok := s.get(FROZEN_NUMBER, hash)
+	if !ok || len(data) != 8 {
+		return nil, false, nil
+	}
+
+	return s.freezer.Get(table, common.EncodeBytesToUint64(data))
+}
+
+// freeze appends the next canonical blocks deeper than the freezer depth to the freezer
+// and removes them from the database, it returns the number of the appended blocks
+func (s *KeyValueStorage) freeze() (int, error) {
+	frozen := 0
+
+	head, ok := s.ReadHeadNumber()
+	if !ok || head < s.freezerDepth {
+		return frozen, nil
+	}
+
+	// the blocks below the limit are frozen
+	limit := head - s.freezerDepth + 1
+
+	for number := s.freezer.Count(); number < limit && frozen < freezeBatchSize; number++ {
+		hash, ok := s.ReadCanonicalHash(number)
+		if !ok {
+			return frozen, fmt.Errorf("canonical hash of the block %d not found", number)
+		}
+
+		items := make(map[string][]byte, len(frozenTables))
+
+		for prefix, table := range frozenTables {
+			data, _, err := s.db.Get(prefixedKey([]byte(prefix), hash.Bytes()))
+			if err != nil {
+				return frozen, err
+			}
+
+			items[table] = data
+		}
+
+		if len(items[FreezerHeaders]) == 0 {
+			return frozen, fmt.Errorf("header of the block %d not found", number)
+		}
+
+		if err := s.freezer.Append(number, items); err != nil {
+			return frozen, err
+		}
+
+		frozen++
+	}
+
+	if frozen > 0 {
+		if err := s.freezer.Sync(); err != nil {
+			return frozen, err
+		}
+	}
+
+	return frozen, s.removeFrozen()
+}
+
+// removeFrozen removes the blocks which are in the freezer from the database,
+// the blocks appended by the run interrupted before their removal are removed as well
+func (s *KeyValueStorage) removeFrozen() error {
+	removed := uint64(0)
+	if data, ok := s.get(FROZEN, NUMBER); ok && len(data) == 8 {
+		removed = common.EncodeBytesToUint64(data)
+	}
+
+	count := s.freezer.Count()
+	if removed > count {
+		return fmt.Errorf("%w, %d blocks were removed, %d are frozen", errFreezerBehind, removed, count)
+	}
+
+	if removed == count {
+		return nil
+	}
+
+	batch := s.db.NewBatch()
+
+	for number := removed; number < count; number++ {
+		hash, ok := s.ReadCanonicalHash(number)
+		if !ok {
+			return fmt.Errorf("canonical hash of the block %d not found", number)
+		}
+
+		// the number of the block is needed to find its items in the freezer
+		batch.Put(prefixedKey(FROZEN_NUMBER, hash.Bytes()), common.EncodeUint64ToBytes(number))
+
+		for prefix := range frozenTables {
+			batch.Delete(prefixedKey([]byte(prefix), hash.Bytes()))
+		}
+	}
+
+	batch.Put(prefixedKey(FROZEN, NUMBER), common.EncodeUint64ToBytes(count))
+
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	s.logger.Debug("blocks moved to the freezer", "from", removed, "to", count-1)
+
+	return nil
+}
+
+func prefixedKey(p, k []byte) []byte {
+	return append(append(make([]byte, 0, len(p)+len(k)), p...), k...)
+}
+
 var ErrNotFound = fmt.Errorf("not found")
 
 func (s *KeyValueStorage) readRLP(p, k []byte, raw types.RLPUnmarshaler) error {
-	p = append(p, k...)
-	data, ok, err := s.db.Get(p)
+	data, ok, err := s.db.Get(prefixedKey(p, k))
 
 	if err != nil {
 		return err
 	}
 
+	if !ok {
+		// the block may be moved to the freezer
+		if data, ok, err = s.readFrozen(p, k); err != nil {
+			return err
+		}
+	}
+
 	if !ok {
 		return ErrNotFound
 	}
@@ -247,6 +438,15 @@ func (s *KeyValueStorage) get(p []byte, k []byte) ([]byte, bool) {
 
 // Close closes the connection with the db
 func (s *KeyValueStorage) Close() error {
+	if s.freezer != nil {
+		close(s.freezerCloseCh)
+		s.freezerWg.Wait()
+
+		if err := s.freezer.Close(); err != nil {
+			s.logger.Error("failed to close the freezer", "err", err)
+		}
+	}
+
 	return s.db.Close()
 }
 
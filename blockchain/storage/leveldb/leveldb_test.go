@@ -254,3 +254,86 @@ insertloop:
 		}
 	}
 }
+
+func TestStorage_Freezer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		path    = t.TempDir()
+		dbPath  = filepath.Join(path, "blockchain")
+		success = types.ReceiptSuccess
+		headers = make([]*types.Header, 10)
+	)
+
+	open := func(depth uint64) storage.Storage {
+		t.Helper()
+
+		s, err := NewLevelDBStorage(dbPath, hclog.NewNullLogger())
+		require.NoError(t, err)
+
+		if depth > 0 {
+			freezer, err := storage.NewFreezer(filepath.Join(path, "ancient"), hclog.NewNullLogger())
+			require.NoError(t, err)
+
+			s.(*storage.KeyValueStorage).StartFreezer(freezer, depth)
+		}
+
+		return s
+	}
+
+	s := open(0)
+	batchWriter := storage.NewBatchWriter(s)
+
+	for i := range headers {
+		headers[i] = &types.Header{Number: uint64(i), ExtraData: []byte{byte(i)}}
+		headers[i].ComputeHash()
+
+		batchWriter.PutHeader(headers[i])
+		batchWriter.PutBody(headers[i].Hash, &types.Body{})
+		batchWriter.PutCanonicalHash(uint64(i), headers[i].Hash)
+		batchWriter.PutReceipts(headers[i].Hash, []*types.Receipt{
+			{Status: &success, CumulativeGasUsed: uint64(i), TxHash: types.StringToHash("1")},
+		})
+	}
+
+	batchWriter.PutHeadNumber(9)
+	batchWriter.PutHeadHash(headers[9].Hash)
+	require.NoError(t, batchWriter.WriteBatch())
+	require.NoError(t, s.Close())
+
+	// the blocks up to 6 are frozen as soon as the freezer starts, which happens before it's closed
+	s = open(3)
+	require.NoError(t, s.Close())
+
+	// the frozen blocks are removed from the database
+	s = open(0)
+
+	for i, header := range headers {
+		_, err := s.ReadHeader(header.Hash)
+		if i <= 6 {
+			require.ErrorIs(t, err, storage.ErrNotFound)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+
+	require.NoError(t, s.Close())
+
+	// the frozen blocks are read from the freezer
+	s = open(3)
+	defer s.Close()
+
+	for i, header := range headers {
+		h, err := s.ReadHeader(header.Hash)
+		require.NoError(t, err)
+		require.Equal(t, header.Hash, h.Hash)
+
+		_, err = s.ReadBody(header.Hash)
+		require.NoError(t, err)
+
+		receipts, err := s.ReadReceipts(header.Hash)
+		require.NoError(t, err)
+		require.Len(t, receipts, 1)
+		require.Equal(t, uint64(i), receipts[0].CumulativeGasUsed)
+	}
+}
@@ -0,0 +1,32 @@
+package pebble
+
+import (
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/cockroachdb/pebble"
+)
+
+var _ storage.Batch = (*batchPebble)(nil)
+
+type batchPebble struct {
+	db *pebble.DB
+	b  *pebble.Batch
+}
+
+func NewBatchPebble(db *pebble.DB) *batchPebble {
+	return &batchPebble{
+		db: db,
+		b:  db.NewBatch(),
+	}
+}
+
+func (b *batchPebble) Delete(key []byte) {
+	_ = b.b.Delete(key, nil)
+}
+
+func (b *batchPebble) Put(k []byte, v []byte) {
+	_ = b.b.Set(k, v, nil)
+}
+
+func (b *batchPebble) Write() error {
+	return b.b.Commit(pebble.NoSync)
+}
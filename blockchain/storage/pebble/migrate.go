@@ -0,0 +1,74 @@
+package pebble
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/hashicorp/go-hclog"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// migrateBatchSize is the max size of the entries written to pebble at once
+const migrateBatchSize = 64 * mib
+
+// MigrateLevelDB copies the entries of the leveldb database at src to the new pebble database at dst.
+// It returns the number of the copied entries
+func MigrateLevelDB(src, dst string, logger hclog.Logger) (uint64, error) {
+	if _, err := os.Stat(dst); err == nil {
+		return 0, fmt.Errorf("the pebble database %s already exists", dst)
+	}
+
+	ldb, err := leveldb.OpenFile(src, &opt.Options{ReadOnly: true, ErrorIfMissing: true})
+	if err != nil {
+		return 0, fmt.Errorf("failed to open the leveldb database %s: %w", src, err)
+	}
+
+	defer ldb.Close()
+
+	pdb, err := Open(dst, logger)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open the pebble database %s: %w", dst, err)
+	}
+
+	defer pdb.Close()
+
+	iter := ldb.NewIterator(nil, nil)
+	defer iter.Release()
+
+	var (
+		batch  = pdb.NewBatch()
+		copied = uint64(0)
+	)
+
+	for iter.Next() {
+		// the batch copies the key and the value, the iterator reuses their buffers
+		if err := batch.Set(iter.Key(), iter.Value(), nil); err != nil {
+			return copied, err
+		}
+
+		copied++
+
+		if batch.Len() >= migrateBatchSize {
+			if err := batch.Commit(pebble.NoSync); err != nil {
+				return copied, err
+			}
+
+			batch = pdb.NewBatch()
+
+			logger.Info("migrating the database", "path", src, "entries", copied)
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return copied, err
+	}
+
+	// the database is synced once the copy is complete
+	if err := batch.Commit(pebble.Sync); err != nil {
+		return copied, err
+	}
+
+	return copied, nil
+}
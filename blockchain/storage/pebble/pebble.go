@@ -0,0 +1,115 @@
+package pebble
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/cockroachdb/pebble"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	DefaultCache   = int(256)
+	DefaultHandles = int(256)
+
+	mib = 1024 * 1024
+)
+
+// Factory creates a pebble storage
+func Factory(config map[string]interface{}, logger hclog.Logger) (storage.Storage, error) {
+	path, ok := config["path"]
+	if !ok {
+		return nil, fmt.Errorf("path not found")
+	}
+
+	pathStr, ok := path.(string)
+	if !ok {
+		return nil, fmt.Errorf("path is not a string")
+	}
+
+	return NewPebbleStorage(pathStr, logger)
+}
+
+// NewPebbleStorage creates the new storage reference with pebble default options
+func NewPebbleStorage(path string, logger hclog.Logger) (storage.Storage, error) {
+	db, err := Open(path, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return storage.NewKeyValueStorage(logger.Named("pebble"), &pebbleKV{db}), nil
+}
+
+// Open opens the pebble database at the path with the default options,
+// the database is created if it doesn't exist
+func Open(path string, logger hclog.Logger) (*pebble.DB, error) {
+	cache := pebble.NewCache(int64(DefaultCache * mib))
+	// the database holds its own reference
+	defer cache.Unref()
+
+	options := &pebble.Options{
+		Cache:        cache,
+		MaxOpenFiles: DefaultHandles,
+		// the flushes are spread over two memtables so the writes aren't stalled by a single flush
+		MemTableSize:                uint64(DefaultCache / 4 * mib),
+		MemTableStopWritesThreshold: 2,
+		MaxConcurrentCompactions:    func() int { return runtime.NumCPU() },
+		Logger:                      &pebbleLogger{logger.Named("pebble")},
+	}
+
+	return pebble.Open(path, options)
+}
+
+// pebbleKV is the pebble implementation of the kv storage
+type pebbleKV struct {
+	db *pebble.DB
+}
+
+// Set sets the key-value pair in pebble storage
+func (p *pebbleKV) Set(k []byte, v []byte) error {
+	return p.db.Set(k, v, pebble.NoSync)
+}
+
+// Get retrieves the key-value pair in pebble storage
+func (p *pebbleKV) Get(k []byte) ([]byte, bool, error) {
+	data, closer, err := p.db.Get(k)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	// the returned slice is valid until the closer is closed
+	res := append([]byte{}, data...)
+
+	return res, true, closer.Close()
+}
+
+// Close closes the pebble storage instance
+func (p *pebbleKV) Close() error {
+	return p.db.Close()
+}
+
+func (p *pebbleKV) NewBatch() storage.Batch {
+	return NewBatchPebble(p.db)
+}
+
+// pebbleLogger forwards the pebble logs to the node logger
+type pebbleLogger struct {
+	logger hclog.Logger
+}
+
+// Infof logs the flushes and the compactions, they're too frequent for the info level
+func (l *pebbleLogger) Infof(format string, args ...interface{}) {
+	l.logger.Debug(fmt.Sprintf(format, args...))
+}
+
+func (l *pebbleLogger) Fatalf(format string, args ...interface{}) {
+	l.logger.Error(fmt.Sprintf(format, args...))
+	os.Exit(1)
+}
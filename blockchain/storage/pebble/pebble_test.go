@@ -0,0 +1,74 @@
+package pebble
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func newStorage(t *testing.T) (storage.Storage, func()) {
+	t.Helper()
+
+	s, err := NewPebbleStorage(t.TempDir(), hclog.NewNullLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	closeFn := func() {
+		if err := s.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return s, closeFn
+}
+
+func TestStorage(t *testing.T) {
+	storage.TestStorage(t, newStorage)
+}
+
+func TestMigrateLevelDB(t *testing.T) {
+	t.Parallel()
+
+	var (
+		src = filepath.Join(t.TempDir(), "leveldb")
+		dst = filepath.Join(t.TempDir(), "pebble")
+	)
+
+	ldb, err := leveldb.OpenFile(src, nil)
+	require.NoError(t, err)
+
+	entries := map[string]string{"a": "1", "b": "2", "c": ""}
+	for k, v := range entries {
+		require.NoError(t, ldb.Put([]byte(k), []byte(v), nil))
+	}
+
+	require.NoError(t, ldb.Close())
+
+	copied, err := MigrateLevelDB(src, dst, hclog.NewNullLogger())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(len(entries)), copied)
+
+	db, err := Open(dst, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	kv := &pebbleKV{db}
+
+	for k, v := range entries {
+		value, ok, err := kv.Get([]byte(k))
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, v, string(value))
+	}
+
+	require.NoError(t, kv.Close())
+
+	// the existing pebble database isn't overwritten
+	_, err = MigrateLevelDB(src, dst, hclog.NewNullLogger())
+	assert.ErrorContains(t, err, "already exists")
+}
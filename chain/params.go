@@ -32,6 +32,18 @@ type Params struct {
 	BurnContract map[uint64]types.Address `json:"burnContract"`
 	// Destination address to initialize default burn contract with
 	BurnContractDestinationAddress types.Address `json:"burnContractDestinationAddress,omitempty"`
+
+	// Transaction ordering of the block builders, the transactions are ordered by price if it's not set
+	TxOrdering *TxOrderingConfig `json:"txOrdering,omitempty"`
+}
+
+// TxOrderingConfig is the policy the block builders pick the pool transactions by
+type TxOrderingConfig struct {
+	// Policy is the name of the ordering policy (price or fifo)
+	Policy string `json:"policy,omitempty"`
+
+	// MaxSenderTxs is the max number of transactions of a single account in a block, 0 means no limit
+	MaxSenderTxs uint64 `json:"maxSenderTxs,omitempty"`
 }
 
 type AddressListConfig struct {
@@ -83,12 +95,16 @@ const (
 	Constantinople      = "constantinople"
 	Petersburg          = "petersburg"
 	Istanbul            = "istanbul"
+	Berlin              = "berlin"
 	London              = "london"
+	Shanghai            = "shanghai"
+	Cancun              = "cancun"
 	EIP150              = "EIP150"
 	EIP158              = "EIP158"
 	EIP155              = "EIP155"
 	QuorumCalcAlignment = "quorumcalcalignment"
 	TxHashWithType      = "txHashWithType"
+	SponsoredTx         = "sponsoredTx"
 )
 
 // Forks is map which contains all forks and their starting blocks from genesis
@@ -118,12 +134,16 @@ func (f *Forks) At(block uint64) ForksInTime {
 		Constantinople:      f.IsActive(Constantinople, block),
 		Petersburg:          f.IsActive(Petersburg, block),
 		Istanbul:            f.IsActive(Istanbul, block),
+		Berlin:              f.IsActive(Berlin, block),
 		London:              f.IsActive(London, block),
+		Shanghai:            f.IsActive(Shanghai, block),
+		Cancun:              f.IsActive(Cancun, block),
 		EIP150:              f.IsActive(EIP150, block),
 		EIP158:              f.IsActive(EIP158, block),
 		EIP155:              f.IsActive(EIP155, block),
 		QuorumCalcAlignment: f.IsActive(QuorumCalcAlignment, block),
 		TxHashWithType:      f.IsActive(TxHashWithType, block),
+		SponsoredTx:         f.IsActive(SponsoredTx, block),
 	}
 }
 
@@ -147,12 +167,16 @@ type ForksInTime struct {
 	Constantinople,
 	Petersburg,
 	Istanbul,
+	Berlin,
 	London,
+	Shanghai,
+	Cancun,
 	EIP150,
 	EIP158,
 	EIP155,
 	QuorumCalcAlignment,
-	TxHashWithType bool
+	TxHashWithType,
+	SponsoredTx bool
 }
 
 // AllForksEnabled should contain all supported forks by current edge version
@@ -165,7 +189,11 @@ var AllForksEnabled = &Forks{
 	Constantinople:      NewFork(0),
 	Petersburg:          NewFork(0),
 	Istanbul:            NewFork(0),
+	Berlin:              NewFork(0),
 	London:              NewFork(0),
+	Shanghai:            NewFork(0),
+	Cancun:              NewFork(0),
 	QuorumCalcAlignment: NewFork(0),
 	TxHashWithType:      NewFork(0),
+	SponsoredTx:         NewFork(0),
 }
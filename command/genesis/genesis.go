@@ -77,6 +77,20 @@ func setFlags(cmd *cobra.Command) {
 		"the burn contract block and address (format: <block>:<address>[:<burn destination>])",
 	)
 
+	cmd.Flags().StringVar(
+		&params.txOrdering,
+		txOrderingFlag,
+		"",
+		"the policy the block builders order the pool transactions by (price or fifo), price if not set",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.maxSenderTxs,
+		maxSenderTxsFlag,
+		0,
+		"the max number of transactions of a single account included in a block (0 disables the limit)",
+	)
+
 	cmd.Flags().StringArrayVar(
 		&params.bootnodes,
 		command.BootnodeFlag,
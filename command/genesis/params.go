@@ -20,6 +20,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/contracts/staking"
 	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
 	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validators"
 )
@@ -33,6 +34,8 @@ const (
 	epochRewardFlag       = "epoch-reward"
 	blockGasLimitFlag     = "block-gas-limit"
 	burnContractFlag      = "burn-contract"
+	txOrderingFlag        = "tx-ordering"
+	maxSenderTxsFlag      = "max-sender-txs"
 	posFlag               = "pos"
 	minValidatorCount     = "min-validator-count"
 	maxValidatorCount     = "max-validator-count"
@@ -84,6 +87,9 @@ type genesisParams struct {
 
 	burnContract string
 
+	txOrdering   string
+	maxSenderTxs uint64
+
 	minNumValidators uint64
 	maxNumValidators uint64
 
@@ -149,6 +155,12 @@ func (p *genesisParams) validateFlags() error {
 		return err
 	}
 
+	if p.txOrdering != "" {
+		if err := txpool.ValidateOrdering(txpool.OrderingType(p.txOrdering)); err != nil {
+			return err
+		}
+	}
+
 	if p.isPolyBFTConsensus() {
 		if err := p.extractNativeTokenMetadata(); err != nil {
 			return err
@@ -397,9 +409,10 @@ func (p *genesisParams) initGenesisConfig() error {
 			GasUsed:    command.DefaultGenesisGasUsed,
 		},
 		Params: &chain.Params{
-			ChainID: int64(p.chainID),
-			Forks:   enabledForks,
-			Engine:  p.consensusEngineConfig,
+			ChainID:    int64(p.chainID),
+			Forks:      enabledForks,
+			Engine:     p.consensusEngineConfig,
+			TxOrdering: p.txOrderingConfig(),
 		},
 		Bootnodes: p.bootnodes,
 	}
@@ -609,3 +622,16 @@ func (p *genesisParams) getResult() command.CommandResult {
 		Message: fmt.Sprintf("\nGenesis written to %s\n", p.genesisPath),
 	}
 }
+
+// txOrderingConfig returns the transaction ordering of the chain,
+// or nil if the default ordering by price is used
+func (p *genesisParams) txOrderingConfig() *chain.TxOrderingConfig {
+	if p.txOrdering == "" && p.maxSenderTxs == 0 {
+		return nil
+	}
+
+	return &chain.TxOrderingConfig{
+		Policy:       p.txOrdering,
+		MaxSenderTxs: p.maxSenderTxs,
+	}
+}
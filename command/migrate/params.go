@@ -0,0 +1,142 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/pebble"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/server"
+)
+
+const (
+	dataDirFlag = "data-dir"
+)
+
+const (
+	// pebbleSuffix is the suffix of the database directory the pebble database is written to
+	pebbleSuffix = ".pebble"
+)
+
+var (
+	params = &migrateParams{}
+)
+
+var (
+	errAlreadyMigrated      = errors.New("data directory is already migrated to pebble")
+	errMigratedDatabaseLost = errors.New("neither the database nor its pebble copy is found")
+)
+
+type migrateParams struct {
+	dataDir string
+
+	migrated []migratedDatabase
+}
+
+type migratedDatabase struct {
+	path    string
+	backup  string
+	entries uint64
+}
+
+// migrate copies each database to pebble and replaces the leveldb one with it,
+// the leveldb database is kept as a backup next to it.
+// Pebble is recorded as the storage backend of the data directory once all the databases are in place
+func (p *migrateParams) migrate() error {
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "migrate",
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	recorded, err := server.ReadStorageBackend(p.dataDir)
+	if err != nil {
+		return err
+	}
+
+	if recorded == server.PebbleBackend {
+		return errAlreadyMigrated
+	}
+
+	for _, name := range server.StorageDatabases {
+		var (
+			path   = filepath.Join(p.dataDir, name)
+			tmp    = path + pebbleSuffix
+			backup = path + server.LevelDBBackupSuffix
+		)
+
+		// the database was migrated by an interrupted run, which may have stopped
+		// before moving the pebble database in place
+		if _, err := os.Stat(backup); err == nil {
+			if err := restoreMigrated(path, tmp); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// the leftover of an interrupted migration
+		if err := os.RemoveAll(tmp); err != nil {
+			return err
+		}
+
+		entries, err := pebble.MigrateLevelDB(path, tmp, logger)
+		if err != nil {
+			return fmt.Errorf("failed to migrate the database %s: %w", path, err)
+		}
+
+		if err := os.Rename(path, backup); err != nil {
+			return err
+		}
+
+		if err := os.Rename(tmp, path); err != nil {
+			return err
+		}
+
+		p.migrated = append(p.migrated, migratedDatabase{path: path, backup: backup, entries: entries})
+	}
+
+	return server.WriteStorageBackend(p.dataDir, server.PebbleBackend)
+}
+
+// restoreMigrated moves the pebble database of an interrupted run in place if it isn't yet,
+// an empty directory in its place is the one created by a server started in the meantime
+func restoreMigrated(path, tmp string) error {
+	entries, err := os.ReadDir(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if len(entries) > 0 {
+		return nil
+	}
+
+	if err := os.RemoveAll(path); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(tmp); err != nil {
+		return fmt.Errorf("%w: %s", errMigratedDatabaseLost, path)
+	}
+
+	return os.Rename(tmp, path)
+}
+
+func (p *migrateParams) getResult() command.CommandResult {
+	result := &MigrateResult{
+		Databases: make([]MigratedDatabase, len(p.migrated)),
+	}
+
+	for i, db := range p.migrated {
+		result.Databases[i] = MigratedDatabase{
+			Path:    db.path,
+			Backup:  db.backup,
+			Entries: db.entries,
+		}
+	}
+
+	return result
+}
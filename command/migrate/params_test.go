@@ -0,0 +1,83 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/server"
+)
+
+func Test_restoreMigrated(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pebble database not moved in place", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		path := filepath.Join(dir, "blockchain")
+		tmp := path + pebbleSuffix
+
+		require.NoError(t, os.Mkdir(tmp, 0770))
+		require.NoError(t, os.WriteFile(filepath.Join(tmp, "MANIFEST"), []byte{1}, 0660))
+
+		require.NoError(t, restoreMigrated(path, tmp))
+
+		assert.FileExists(t, filepath.Join(path, "MANIFEST"))
+		assert.NoDirExists(t, tmp)
+	})
+
+	t.Run("empty directory created in the meantime", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		path := filepath.Join(dir, "blockchain")
+		tmp := path + pebbleSuffix
+
+		require.NoError(t, os.Mkdir(path, 0770))
+		require.NoError(t, os.Mkdir(tmp, 0770))
+		require.NoError(t, os.WriteFile(filepath.Join(tmp, "MANIFEST"), []byte{1}, 0660))
+
+		require.NoError(t, restoreMigrated(path, tmp))
+
+		assert.FileExists(t, filepath.Join(path, "MANIFEST"))
+	})
+
+	t.Run("pebble database in place", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		path := filepath.Join(dir, "blockchain")
+
+		require.NoError(t, os.Mkdir(path, 0770))
+		require.NoError(t, os.WriteFile(filepath.Join(path, "MANIFEST"), []byte{1}, 0660))
+
+		require.NoError(t, restoreMigrated(path, path+pebbleSuffix))
+
+		assert.FileExists(t, filepath.Join(path, "MANIFEST"))
+	})
+
+	t.Run("database lost", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		path := filepath.Join(dir, "blockchain")
+
+		require.ErrorIs(t, restoreMigrated(path, path+pebbleSuffix), errMigratedDatabaseLost)
+	})
+}
+
+func Test_migrate_AlreadyMigrated(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	require.NoError(t, server.WriteStorageBackend(dir, server.PebbleBackend))
+
+	p := &migrateParams{dataDir: dir}
+
+	require.ErrorIs(t, p.migrate(), errAlreadyMigrated)
+}
@@ -0,0 +1,37 @@
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type MigratedDatabase struct {
+	Path    string `json:"path"`
+	Backup  string `json:"backup"`
+	Entries uint64 `json:"entries"`
+}
+
+type MigrateResult struct {
+	Databases []MigratedDatabase `json:"databases"`
+}
+
+func (r *MigrateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[MIGRATE]\n")
+	buffer.WriteString("Migrated the databases to pebble, start the server with --storage-backend pebble\n")
+
+	for _, db := range r.Databases {
+		buffer.WriteString("\n")
+		buffer.WriteString(helper.FormatKV([]string{
+			fmt.Sprintf("Database|%s", db.Path),
+			fmt.Sprintf("LevelDB backup|%s", db.Backup),
+			fmt.Sprintf("Entries|%d", db.Entries),
+		}))
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
@@ -29,11 +29,27 @@ type Config struct {
 	LogFilePath              string     `json:"log_to" yaml:"log_to"`
 	JSONRPCBatchRequestLimit uint64     `json:"json_rpc_batch_request_limit" yaml:"json_rpc_batch_request_limit"`
 	JSONRPCBlockRangeLimit   uint64     `json:"json_rpc_block_range_limit" yaml:"json_rpc_block_range_limit"`
+	JSONRPCRateLimit         *RateLimit `json:"json_rpc_rate_limit" yaml:"json_rpc_rate_limit"`
+	JSONRPCNamespaces        []string   `json:"json_rpc_namespaces" yaml:"json_rpc_namespaces"`
+	JSONRPCAllowedMethods    []string   `json:"json_rpc_allowed_methods" yaml:"json_rpc_allowed_methods"`
+	JSONRPCDeniedMethods     []string   `json:"json_rpc_denied_methods" yaml:"json_rpc_denied_methods"`
+	JSONRPCAdminAddr         string     `json:"json_rpc_admin_addr" yaml:"json_rpc_admin_addr"`
+	JSONRPCAdminNamespaces   []string   `json:"json_rpc_admin_namespaces" yaml:"json_rpc_admin_namespaces"`
 	JSONLogFormat            bool       `json:"json_log_format" yaml:"json_log_format"`
 	CorsAllowedOrigins       []string   `json:"cors_allowed_origins" yaml:"cors_allowed_origins"`
 
 	Relayer               bool   `json:"relayer" yaml:"relayer"`
 	NumBlockConfirmations uint64 `json:"num_block_confirmations" yaml:"num_block_confirmations"`
+
+	Prune                   uint64 `json:"prune" yaml:"prune"`
+	PruneCheckpointInterval uint64 `json:"prune_checkpoint_interval" yaml:"prune_checkpoint_interval"`
+
+	StateSnapshot  bool   `json:"state_snapshot" yaml:"state_snapshot"`
+	StorageBackend string `json:"storage_backend" yaml:"storage_backend"`
+
+	StateSync bool `json:"state_sync" yaml:"state_sync"`
+
+	FreezerDepth uint64 `json:"freezer_depth" yaml:"freezer_depth"`
 }
 
 // Telemetry holds the config details for metric services.
@@ -54,9 +70,28 @@ type Network struct {
 
 // TxPool defines the TxPool configuration params
 type TxPool struct {
-	PriceLimit         uint64 `json:"price_limit" yaml:"price_limit"`
-	MaxSlots           uint64 `json:"max_slots" yaml:"max_slots"`
-	MaxAccountEnqueued uint64 `json:"max_account_enqueued" yaml:"max_account_enqueued"`
+	PriceLimit         uint64   `json:"price_limit" yaml:"price_limit"`
+	MaxSlots           uint64   `json:"max_slots" yaml:"max_slots"`
+	MaxAccountEnqueued uint64   `json:"max_account_enqueued" yaml:"max_account_enqueued"`
+	Journal            string   `json:"journal" yaml:"journal"`
+	JournalRotation    uint64   `json:"journal_rotation" yaml:"journal_rotation"`
+	Lifetime           uint64   `json:"lifetime" yaml:"lifetime"`
+	EvictPromoted      bool     `json:"evict_promoted" yaml:"evict_promoted"`
+	PriorityAccounts   []string `json:"priority_accounts" yaml:"priority_accounts"`
+	Ordering           string   `json:"ordering" yaml:"ordering"`
+	MaxSenderTxs       uint64   `json:"max_sender_txs" yaml:"max_sender_txs"`
+}
+
+// RateLimit defines the limits applied to the JSON-RPC clients
+type RateLimit struct {
+	RequestsPerSecond       float64           `json:"requests_per_second" yaml:"requests_per_second"`
+	Burst                   uint64            `json:"burst" yaml:"burst"`
+	MethodCosts             map[string]uint64 `json:"method_costs" yaml:"method_costs"`
+	MaxConcurrentExpensive  uint64            `json:"max_concurrent_expensive" yaml:"max_concurrent_expensive"`
+	MaxWSConnections        uint64            `json:"max_ws_connections" yaml:"max_ws_connections"`
+	MaxSubscriptionsPerConn uint64            `json:"max_ws_subscriptions" yaml:"max_ws_subscriptions"`
+	APIKeyHeader            string            `json:"api_key_header" yaml:"api_key_header"`
+	APIKeys                 []string          `json:"api_keys" yaml:"api_keys"`
 }
 
 // Headers defines the HTTP response headers required to enable CORS.
@@ -76,11 +111,49 @@ const (
 	// requests with fromBlock/toBlock values (e.g. eth_getLogs)
 	DefaultJSONRPCBlockRangeLimit uint64 = 1000
 
+	// DefaultTxPoolJournalRotation is the interval in seconds between the txpool journal rotations
+	DefaultTxPoolJournalRotation uint64 = 3600
+
+	// DefaultTxPoolLifetime is the time in seconds after which the enqueued transactions are evicted
+	DefaultTxPoolLifetime uint64 = 3 * 3600
+
 	// DefaultNumBlockConfirmations minimal number of child blocks required for the parent block to be considered final
 	// on ethereum epoch lasts for 32 blocks. more details: https://www.alchemy.com/overviews/ethereum-commitment-levels
 	DefaultNumBlockConfirmations uint64 = 64
+
+	// DefaultPruneCheckpointInterval is the interval of the blocks whose state is kept by the pruning
+	DefaultPruneCheckpointInterval uint64 = 10000
+
+	// DefaultStorageBackend is the database engine of the blockchain and the trie stores
+	DefaultStorageBackend = "leveldb"
 )
 
+// DefaultJSONRPCAdminNamespaces returns the privileged json_rpc namespaces served by the admin listener
+func DefaultJSONRPCAdminNamespaces() []string {
+	return []string{"debug", "trace", "txpool", "bridge"}
+}
+
+// DefaultJSONRPCMethodCosts returns the default cost weights of the expensive json_rpc methods
+func DefaultJSONRPCMethodCosts() map[string]uint64 {
+	return map[string]uint64{
+		"eth_call":                      5,
+		"eth_estimateGas":               5,
+		"eth_createAccessList":          5,
+		"eth_getProof":                  5,
+		"eth_getBlockReceipts":          5,
+		"eth_getLogs":                   10,
+		"debug_traceCall":               20,
+		"debug_traceTransaction":        20,
+		"debug_traceBlock":              50,
+		"debug_traceBlockByHash":        50,
+		"debug_traceBlockByNumber":      50,
+		"trace_transaction":             20,
+		"trace_block":                   50,
+		"trace_replayBlockTransactions": 50,
+		"trace_filter":                  100,
+	}
+}
+
 // DefaultConfig returns the default server configuration
 func DefaultConfig() *Config {
 	defaultNetworkConfig := network.DefaultConfig()
@@ -105,6 +178,8 @@ func DefaultConfig() *Config {
 			PriceLimit:         0,
 			MaxSlots:           4096,
 			MaxAccountEnqueued: 128,
+			JournalRotation:    DefaultTxPoolJournalRotation,
+			Lifetime:           DefaultTxPoolLifetime,
 		},
 		LogLevel:    "INFO",
 		RestoreFile: "",
@@ -114,8 +189,17 @@ func DefaultConfig() *Config {
 		LogFilePath:              "",
 		JSONRPCBatchRequestLimit: DefaultJSONRPCBatchRequestLimit,
 		JSONRPCBlockRangeLimit:   DefaultJSONRPCBlockRangeLimit,
-		Relayer:                  false,
-		NumBlockConfirmations:    DefaultNumBlockConfirmations,
+		JSONRPCRateLimit: &RateLimit{
+			MethodCosts: DefaultJSONRPCMethodCosts(),
+		},
+		JSONRPCAdminNamespaces: DefaultJSONRPCAdminNamespaces(),
+		Relayer:                false,
+		NumBlockConfirmations:  DefaultNumBlockConfirmations,
+
+		PruneCheckpointInterval: DefaultPruneCheckpointInterval,
+
+		StateSnapshot:  false,
+		StorageBackend: DefaultStorageBackend,
 	}
 }
 
@@ -15,6 +15,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -58,6 +59,20 @@ func (p *serverParams) initRawParams() error {
 
 	p.relayer = p.rawConfig.Relayer
 
+	if err := p.initPriorityAccounts(); err != nil {
+		return err
+	}
+
+	if err := server.ValidateStorageBackend(server.StorageBackend(p.rawConfig.StorageBackend)); err != nil {
+		return err
+	}
+
+	if ordering := p.rawConfig.TxPool.Ordering; ordering != "" {
+		if err := txpool.ValidateOrdering(txpool.OrderingType(ordering)); err != nil {
+			return err
+		}
+	}
+
 	return p.initAddresses()
 }
 
@@ -199,6 +214,20 @@ func (p *serverParams) initUsingMaxPeers() {
 	}
 }
 
+func (p *serverParams) initPriorityAccounts() error {
+	p.priorityAccounts = make([]types.Address, 0, len(p.rawConfig.TxPool.PriorityAccounts))
+
+	for _, addr := range p.rawConfig.TxPool.PriorityAccounts {
+		if err := types.IsValidAddress(addr); err != nil {
+			return fmt.Errorf("invalid priority account: %w", err)
+		}
+
+		p.priorityAccounts = append(p.priorityAccounts, types.StringToAddress(addr))
+	}
+
+	return nil
+}
+
 func (p *serverParams) initAddresses() error {
 	if err := p.initPrometheusAddress(); err != nil {
 		return err
@@ -220,6 +249,10 @@ func (p *serverParams) initAddresses() error {
 		return err
 	}
 
+	if err := p.initJSONRPCAdminAddress(); err != nil {
+		return err
+	}
+
 	return p.initGRPCAddress()
 }
 
@@ -296,6 +329,23 @@ func (p *serverParams) initJSONRPCAddress() error {
 	return nil
 }
 
+func (p *serverParams) initJSONRPCAdminAddress() error {
+	if !p.isJSONRPCAdminAddressSet() {
+		return nil
+	}
+
+	var parseErr error
+
+	if p.jsonRPCAdminAddr, parseErr = helper.ResolveAddr(
+		p.rawConfig.JSONRPCAdminAddr,
+		helper.LocalHostBinding,
+	); parseErr != nil {
+		return parseErr
+	}
+
+	return nil
+}
+
 func (p *serverParams) initGRPCAddress() error {
 	var parseErr error
 
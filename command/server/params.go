@@ -3,12 +3,15 @@ package server
 import (
 	"errors"
 	"net"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command/server/config"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/multiformats/go-multiaddr"
 )
@@ -28,8 +31,27 @@ const (
 	priceLimitFlag               = "price-limit"
 	jsonRPCBatchRequestLimitFlag = "json-rpc-batch-request-limit"
 	jsonRPCBlockRangeLimitFlag   = "json-rpc-block-range-limit"
+	jsonRPCRateLimitFlag         = "json-rpc-rate-limit"
+	jsonRPCRateLimitBurstFlag    = "json-rpc-rate-limit-burst"
+	jsonRPCMaxExpensiveFlag      = "json-rpc-max-concurrent-expensive"
+	jsonRPCMaxWSConnsFlag        = "json-rpc-max-ws-connections"
+	jsonRPCMaxWSSubsFlag         = "json-rpc-max-ws-subscriptions"
+	jsonRPCAPIKeyHeaderFlag      = "json-rpc-api-key-header"
+	jsonRPCAPIKeysFlag           = "json-rpc-api-keys"
+	jsonRPCNamespacesFlag        = "json-rpc-namespaces"
+	jsonRPCAllowedMethodsFlag    = "json-rpc-allowed-methods"
+	jsonRPCDeniedMethodsFlag     = "json-rpc-denied-methods"
+	jsonRPCAdminFlag             = "json-rpc-admin"
+	jsonRPCAdminNamespacesFlag   = "json-rpc-admin-namespaces"
 	maxSlotsFlag                 = "max-slots"
 	maxEnqueuedFlag              = "max-enqueued"
+	txPoolJournalFlag            = "journal"
+	txPoolJournalRotationFlag    = "journal-rotation"
+	txPoolLifetimeFlag           = "lifetime"
+	txPoolEvictPromotedFlag      = "evict-promoted"
+	txPoolPriorityAccountsFlag   = "priority-accounts"
+	txPoolOrderingFlag           = "tx-ordering"
+	txPoolMaxSenderTxsFlag       = "max-sender-txs"
 	blockGasTargetFlag           = "block-gas-target"
 	secretsConfigFlag            = "secrets-config"
 	restoreFlag                  = "restore"
@@ -40,6 +62,13 @@ const (
 
 	relayerFlag               = "relayer"
 	numBlockConfirmationsFlag = "num-block-confirmations"
+
+	pruneFlag                   = "prune"
+	pruneCheckpointIntervalFlag = "prune-checkpoint-interval"
+	stateSnapshotFlag           = "state-snapshot"
+	storageBackendFlag          = "storage-backend"
+	stateSyncFlag               = "state-sync"
+	freezerDepthFlag            = "freezer-depth"
 )
 
 // Flags that are deprecated, but need to be preserved for
@@ -58,6 +87,8 @@ var (
 			Telemetry: &config.Telemetry{},
 			Network:   &config.Network{},
 			TxPool:    &config.TxPool{},
+
+			JSONRPCRateLimit: &config.RateLimit{},
 		},
 	}
 )
@@ -76,6 +107,7 @@ type serverParams struct {
 	dnsAddress        multiaddr.Multiaddr
 	grpcAddress       *net.TCPAddr
 	jsonRPCAddress    *net.TCPAddr
+	jsonRPCAdminAddr  *net.TCPAddr
 
 	blockGasTarget uint64
 	devInterval    uint64
@@ -89,6 +121,8 @@ type serverParams struct {
 	logFileLocation string
 
 	relayer bool
+
+	priorityAccounts []types.Address
 }
 
 func (p *serverParams) isMaxPeersSet() bool {
@@ -108,6 +142,10 @@ func (p *serverParams) isPrometheusAddressSet() bool {
 	return p.rawConfig.Telemetry.PrometheusAddr != ""
 }
 
+func (p *serverParams) isJSONRPCAdminAddressSet() bool {
+	return p.rawConfig.JSONRPCAdminAddr != ""
+}
+
 func (p *serverParams) isNATAddressSet() bool {
 	return p.rawConfig.Network.NatAddr != ""
 }
@@ -152,6 +190,12 @@ func (p *serverParams) generateConfig() *server.Config {
 			AccessControlAllowOrigin: p.rawConfig.CorsAllowedOrigins,
 			BatchLengthLimit:         p.rawConfig.JSONRPCBatchRequestLimit,
 			BlockRangeLimit:          p.rawConfig.JSONRPCBlockRangeLimit,
+			RateLimit:                p.generateRateLimitConfig(),
+			Namespaces:               p.rawConfig.JSONRPCNamespaces,
+			AllowedMethods:           p.rawConfig.JSONRPCAllowedMethods,
+			DeniedMethods:            p.rawConfig.JSONRPCDeniedMethods,
+			AdminAddr:                p.jsonRPCAdminAddr,
+			AdminNamespaces:          p.rawConfig.JSONRPCAdminNamespaces,
 		},
 		GRPCAddr:   p.grpcAddress,
 		LibP2PAddr: p.libp2pAddress,
@@ -169,18 +213,53 @@ func (p *serverParams) generateConfig() *server.Config {
 			MaxOutboundPeers: p.rawConfig.Network.MaxOutboundPeers,
 			Chain:            p.genesisConfig,
 		},
-		DataDir:            p.rawConfig.DataDir,
-		Seal:               p.rawConfig.ShouldSeal,
-		PriceLimit:         p.rawConfig.TxPool.PriceLimit,
-		MaxSlots:           p.rawConfig.TxPool.MaxSlots,
-		MaxAccountEnqueued: p.rawConfig.TxPool.MaxAccountEnqueued,
-		SecretsManager:     p.secretsConfig,
-		RestoreFile:        p.getRestoreFilePath(),
-		LogLevel:           hclog.LevelFromString(p.rawConfig.LogLevel),
-		JSONLogFormat:      p.rawConfig.JSONLogFormat,
-		LogFilePath:        p.logFileLocation,
+		DataDir:               p.rawConfig.DataDir,
+		Seal:                  p.rawConfig.ShouldSeal,
+		PriceLimit:            p.rawConfig.TxPool.PriceLimit,
+		MaxSlots:              p.rawConfig.TxPool.MaxSlots,
+		MaxAccountEnqueued:    p.rawConfig.TxPool.MaxAccountEnqueued,
+		TxPoolJournal:         p.rawConfig.TxPool.Journal,
+		TxPoolJournalRotation: time.Duration(p.rawConfig.TxPool.JournalRotation) * time.Second,
+		TxPoolLifetime:        time.Duration(p.rawConfig.TxPool.Lifetime) * time.Second,
+		TxPoolEvictPromoted:   p.rawConfig.TxPool.EvictPromoted,
+		PriorityAccounts:      p.priorityAccounts,
+		TxOrdering:            p.rawConfig.TxPool.Ordering,
+		MaxSenderTxs:          p.rawConfig.TxPool.MaxSenderTxs,
+		SecretsManager:        p.secretsConfig,
+		RestoreFile:           p.getRestoreFilePath(),
+		LogLevel:              hclog.LevelFromString(p.rawConfig.LogLevel),
+		JSONLogFormat:         p.rawConfig.JSONLogFormat,
+		LogFilePath:           p.logFileLocation,
 
 		Relayer:               p.relayer,
 		NumBlockConfirmations: p.rawConfig.NumBlockConfirmations,
+
+		PruneRetain:             p.rawConfig.Prune,
+		PruneCheckpointInterval: p.rawConfig.PruneCheckpointInterval,
+
+		StateSnapshot:  p.rawConfig.StateSnapshot,
+		StorageBackend: server.StorageBackend(p.rawConfig.StorageBackend),
+
+		StateSync: p.rawConfig.StateSync,
+
+		FreezerDepth: p.rawConfig.FreezerDepth,
+	}
+}
+
+func (p *serverParams) generateRateLimitConfig() *jsonrpc.RateLimitConfig {
+	rateLimit := p.rawConfig.JSONRPCRateLimit
+	if rateLimit == nil {
+		return nil
+	}
+
+	return &jsonrpc.RateLimitConfig{
+		RequestsPerSecond:       rateLimit.RequestsPerSecond,
+		Burst:                   rateLimit.Burst,
+		MethodCosts:             rateLimit.MethodCosts,
+		MaxConcurrentExpensive:  rateLimit.MaxConcurrentExpensive,
+		MaxWSConnections:        rateLimit.MaxWSConnections,
+		MaxSubscriptionsPerConn: rateLimit.MaxSubscriptionsPerConn,
+		APIKeyHeader:            rateLimit.APIKeyHeader,
+		APIKeys:                 rateLimit.APIKeys,
 	}
 }
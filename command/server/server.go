@@ -185,6 +185,59 @@ func setFlags(cmd *cobra.Command) {
 		"maximum number of enqueued transactions per account",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.TxPool.Journal,
+		txPoolJournalFlag,
+		defaultConfig.TxPool.Journal,
+		"the file of the journal which persists the local transactions of the pool across restarts, "+
+			"relative to the data directory if not absolute, the journal is disabled if not set",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.JournalRotation,
+		txPoolJournalRotationFlag,
+		defaultConfig.TxPool.JournalRotation,
+		"the interval in seconds between the rotations of the transaction pool journal",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.Lifetime,
+		txPoolLifetimeFlag,
+		defaultConfig.TxPool.Lifetime,
+		"the time in seconds after which the enqueued transactions are evicted from the pool (0 disables the eviction)",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.TxPool.EvictPromoted,
+		txPoolEvictPromotedFlag,
+		defaultConfig.TxPool.EvictPromoted,
+		"evict the promoted transactions after the lifetime as well",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.TxPool.PriorityAccounts,
+		txPoolPriorityAccountsFlag,
+		defaultConfig.TxPool.PriorityAccounts,
+		"the accounts whose transactions bypass the price limit, are never pruned from the pool "+
+			"and are executed first",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.TxPool.Ordering,
+		txPoolOrderingFlag,
+		defaultConfig.TxPool.Ordering,
+		"the policy the block builders order the pool transactions by (price or fifo), "+
+			"the one of the genesis is used if not set",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.MaxSenderTxs,
+		txPoolMaxSenderTxsFlag,
+		defaultConfig.TxPool.MaxSenderTxs,
+		"the max number of transactions of a single account included in a block, "+
+			"the limit of the genesis is used if 0",
+	)
+
 	cmd.Flags().StringArrayVar(
 		&params.rawConfig.CorsAllowedOrigins,
 		corsOriginFlag,
@@ -207,6 +260,97 @@ func setFlags(cmd *cobra.Command) {
 			"that consider fromBlock/toBlock values (e.g. eth_getLogs), value of 0 disables it",
 	)
 
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.JSONRPCNamespaces,
+		jsonRPCNamespacesFlag,
+		defaultConfig.JSONRPCNamespaces,
+		"the json-rpc namespaces exposed on the json-rpc address (e.g. eth,net,web3), all of them if not set",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.JSONRPCAllowedMethods,
+		jsonRPCAllowedMethodsFlag,
+		defaultConfig.JSONRPCAllowedMethods,
+		"the json-rpc methods exposed even if their namespace is not (e.g. debug_traceTransaction)",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.JSONRPCDeniedMethods,
+		jsonRPCDeniedMethodsFlag,
+		defaultConfig.JSONRPCDeniedMethods,
+		"the json-rpc methods not exposed even if their namespace is (e.g. eth_sendRawTransaction)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCAdminAddr,
+		jsonRPCAdminFlag,
+		defaultConfig.JSONRPCAdminAddr,
+		"the address and port of the admin json-rpc listener, which serves the admin namespaces "+
+			"without rate limits (default address: 127.0.0.1), it's disabled if not set",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.JSONRPCAdminNamespaces,
+		jsonRPCAdminNamespacesFlag,
+		defaultConfig.JSONRPCAdminNamespaces,
+		"the json-rpc namespaces exposed on the admin json-rpc address",
+	)
+
+	// the method costs can only be set in the config file
+	params.rawConfig.JSONRPCRateLimit.MethodCosts = defaultConfig.JSONRPCRateLimit.MethodCosts
+
+	cmd.Flags().Float64Var(
+		&params.rawConfig.JSONRPCRateLimit.RequestsPerSecond,
+		jsonRPCRateLimitFlag,
+		defaultConfig.JSONRPCRateLimit.RequestsPerSecond,
+		"cost units allowed per second to each json-rpc client, identified by IP address or API key, "+
+			"value of 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCRateLimit.Burst,
+		jsonRPCRateLimitBurstFlag,
+		defaultConfig.JSONRPCRateLimit.Burst,
+		"max cost units a json-rpc client can spend at once, it's at least the budget of one second",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCRateLimit.MaxConcurrentExpensive,
+		jsonRPCMaxExpensiveFlag,
+		defaultConfig.JSONRPCRateLimit.MaxConcurrentExpensive,
+		"max number of json-rpc requests with expensive calls (e.g. eth_getLogs, debug_traceBlock) "+
+			"executed at the same time, value of 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCRateLimit.MaxWSConnections,
+		jsonRPCMaxWSConnsFlag,
+		defaultConfig.JSONRPCRateLimit.MaxWSConnections,
+		"max number of open json-rpc websocket connections, value of 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.JSONRPCRateLimit.MaxSubscriptionsPerConn,
+		jsonRPCMaxWSSubsFlag,
+		defaultConfig.JSONRPCRateLimit.MaxSubscriptionsPerConn,
+		"max number of subscriptions of a json-rpc websocket connection, value of 0 disables it",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCRateLimit.APIKeyHeader,
+		jsonRPCAPIKeyHeaderFlag,
+		defaultConfig.JSONRPCRateLimit.APIKeyHeader,
+		"HTTP header with the API key identifying the json-rpc clients, "+
+			"the clients without an accepted key are identified by IP address",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rawConfig.JSONRPCRateLimit.APIKeys,
+		jsonRPCAPIKeysFlag,
+		defaultConfig.JSONRPCRateLimit.APIKeys,
+		"the API keys accepted in the json-rpc API key header, the other keys are ignored",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.LogFilePath,
 		logFileLocationFlag,
@@ -228,6 +372,53 @@ func setFlags(cmd *cobra.Command) {
 		"minimal number of child blocks required for the parent block to be considered final",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.Prune,
+		pruneFlag,
+		defaultConfig.Prune,
+		"the number of the most recent blocks whose state is kept, the older states are pruned "+
+			"and can't be queried (0 disables the pruning)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.PruneCheckpointInterval,
+		pruneCheckpointIntervalFlag,
+		defaultConfig.PruneCheckpointInterval,
+		"the interval of the blocks whose state is never pruned (0 keeps only the recent states)",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.StateSnapshot,
+		stateSnapshotFlag,
+		defaultConfig.StateSnapshot,
+		"serve the state reads from the flat snapshot of the recent states, it's rebuilt from the trie "+
+			"in the background if it doesn't match the head state",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.StorageBackend,
+		storageBackendFlag,
+		defaultConfig.StorageBackend,
+		"the database engine of the blockchain and the state stores (leveldb or pebble), "+
+			"the existing leveldb data directory is converted by the migrate command",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.StateSync,
+		stateSyncFlag,
+		defaultConfig.StateSync,
+		"download the state of a recent block from the peers instead of executing the blocks from genesis, "+
+			"when the node starts far behind them (not supported by the PoS IBFT forks)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.FreezerDepth,
+		freezerDepthFlag,
+		defaultConfig.FreezerDepth,
+		"how deep below the head the headers, bodies and receipts of the blocks are moved from the database "+
+			"to the append-only files of the freezer, it's disabled if it's 0",
+	)
+
 	setLegacyFlags(cmd)
 
 	setDevFlags(cmd)
@@ -10,6 +10,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -78,6 +79,11 @@ type Params struct {
 	BlockTime      uint64
 
 	NumBlockConfirmations uint64
+
+	// StateStorage is the storage of the state tries, served to the syncing peers
+	StateStorage itrie.Storage
+	// StateSync enables downloading the state of a recent block instead of executing the blocks from genesis
+	StateSync bool
 }
 
 // Factory is the factory function to create a discovery consensus
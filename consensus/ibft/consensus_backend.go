@@ -12,6 +12,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/consensus/ibft/signer"
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -208,7 +209,7 @@ func (i *backendIBFT) buildBlock(parent *types.Header) (*types.Block, error) {
 	txs := i.writeTransactions(
 		writeCtx,
 		gasLimit,
-		header.Number,
+		header,
 		transition,
 	)
 
@@ -290,17 +291,18 @@ type txExeResult struct {
 
 type transitionInterface interface {
 	Write(txn *types.Transaction) error
+	WriteBundle(txns []*types.Transaction) error
 }
 
 func (i *backendIBFT) writeTransactions(
 	writeCtx context.Context,
-	gasLimit,
-	blockNumber uint64,
+	gasLimit uint64,
+	header *types.Header,
 	transition transitionInterface,
 ) (executed []*types.Transaction) {
 	executed = make([]*types.Transaction, 0)
 
-	if !i.currentHooks.ShouldWriteTransactions(blockNumber) {
+	if !i.currentHooks.ShouldWriteTransactions(header.Number) {
 		return
 	}
 
@@ -322,6 +324,19 @@ func (i *backendIBFT) writeTransactions(
 
 	i.txpool.Prepare()
 
+	// the bundles are applied before the rest of the transactions
+	for _, bundle := range i.txpool.PeekBundles(header.Number, header.Timestamp) {
+		switch i.writeBundle(bundle, transition, gasLimit) {
+		case success:
+			executed = append(executed, bundle.Txs...)
+			successful += len(bundle.Txs)
+		case fail:
+			failed += len(bundle.Txs)
+		case skip:
+			skipped += len(bundle.Txs)
+		}
+	}
+
 write:
 	for {
 		select {
@@ -395,6 +410,35 @@ func (i *backendIBFT) writeTransaction(
 	return &txExeResult{tx, success}, true
 }
 
+// writeBundle applies the bundle as a unit. The skipped bundle
+// is kept in the pool, as it may fit in one of the following blocks
+func (i *backendIBFT) writeBundle(
+	bundle *txpool.Bundle,
+	transition transitionInterface,
+	gasLimit uint64,
+) status {
+	if bundle.Gas() > gasLimit {
+		i.txpool.RemoveBundle(bundle)
+
+		return fail
+	}
+
+	if err := transition.WriteBundle(bundle.Txs); err != nil {
+		if _, ok := err.(*state.GasLimitReachedTransitionApplicationError); ok { //nolint:errorlint
+			return skip
+		}
+
+		i.logger.Debug("failed to apply bundle", "hash", bundle.Hash, "err", err)
+		i.txpool.RemoveBundle(bundle)
+
+		return fail
+	}
+
+	i.txpool.RemoveBundle(bundle)
+
+	return success
+}
+
 // extractCommittedSeals extracts CommittedSeals from header
 func (i *backendIBFT) extractCommittedSeals(
 	header *types.Header,
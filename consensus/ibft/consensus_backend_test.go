@@ -1,9 +1,16 @@
 package ibft
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/consensus/ibft/hook"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/txpool"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -111,3 +118,113 @@ func TestIBFTBackend_RoundUpTime(t *testing.T) {
 		})
 	}
 }
+
+// TestIBFTBackend_WriteBundles verifies the bundles are applied as a unit
+// before the rest of the transactions
+func TestIBFTBackend_WriteBundles(t *testing.T) {
+	t.Parallel()
+
+	newBundle := func(hash byte, gas ...uint64) *txpool.Bundle {
+		bundle := &txpool.Bundle{Hash: types.Hash{hash}}
+
+		for i, g := range gas {
+			bundle.Txs = append(bundle.Txs, &types.Transaction{
+				Nonce: uint64(i),
+				Gas:   g,
+				Hash:  types.Hash{hash, byte(i + 1)},
+			})
+		}
+
+		return bundle
+	}
+
+	var (
+		applied   = newBundle(0x1, 100, 100)
+		failing   = newBundle(0x2, 100, 100)
+		tooLarge  = newBundle(0x3, 1000, 1000)
+		postponed = newBundle(0x4, 100)
+	)
+
+	pool := &mockTxPool{bundles: []*txpool.Bundle{applied, failing, tooLarge, postponed}}
+	transition := &mockTransition{
+		failing:         map[types.Hash]bool{failing.Txs[1].Hash: true},
+		gasLimitReached: map[types.Hash]bool{postponed.Txs[0].Hash: true},
+	}
+
+	i := &backendIBFT{
+		logger:       hclog.NewNullLogger(),
+		txpool:       pool,
+		currentHooks: &hook.Hooks{},
+	}
+
+	writeCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	executed := i.writeTransactions(writeCtx, 1000, &types.Header{Number: 1}, transition)
+
+	// the failing bundle is reverted as a whole
+	assert.Equal(t, applied.Txs, executed)
+	assert.Equal(t, applied.Txs, transition.applied)
+
+	// the bundle which may fit in one of the following blocks is kept
+	assert.Equal(t, []*txpool.Bundle{applied, failing, tooLarge}, pool.removed)
+}
+
+type mockTxPool struct {
+	txPoolInterface
+
+	bundles []*txpool.Bundle
+	removed []*txpool.Bundle
+}
+
+func (p *mockTxPool) Prepare() {}
+
+func (p *mockTxPool) Length() uint64 {
+	return 0
+}
+
+func (p *mockTxPool) Peek() *types.Transaction {
+	return nil
+}
+
+func (p *mockTxPool) PeekBundles(uint64, uint64) []*txpool.Bundle {
+	return p.bundles
+}
+
+func (p *mockTxPool) RemoveBundle(bundle *txpool.Bundle) {
+	p.removed = append(p.removed, bundle)
+}
+
+type mockTransition struct {
+	applied         []*types.Transaction
+	failing         map[types.Hash]bool
+	gasLimitReached map[types.Hash]bool
+}
+
+func (m *mockTransition) Write(txn *types.Transaction) error {
+	if m.gasLimitReached[txn.Hash] {
+		return state.NewGasLimitReachedTransitionApplicationError(errors.New("gas limit reached"))
+	}
+
+	if m.failing[txn.Hash] {
+		return errors.New("execution failed")
+	}
+
+	m.applied = append(m.applied, txn)
+
+	return nil
+}
+
+func (m *mockTransition) WriteBundle(txns []*types.Transaction) error {
+	snapshot := len(m.applied)
+
+	for _, txn := range txns {
+		if err := m.Write(txn); err != nil {
+			m.applied = m.applied[:snapshot]
+
+			return err
+		}
+	}
+
+	return nil
+}
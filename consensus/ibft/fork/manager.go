@@ -187,6 +187,18 @@ func (m *ForkManager) GetValidators(height uint64) (validators.Validators, error
 	)
 }
 
+// ReadsValidatorsFromState returns whether any fork reads the validators from the state,
+// which is needed to verify the headers of the blocks in that fork
+func (m *ForkManager) ReadsValidatorsFromState() bool {
+	for _, fork := range m.forks {
+		if ibftTypesToSourceType[fork.Type] == store.Contract {
+			return true
+		}
+	}
+
+	return false
+}
+
 // GetHooks returns a hooks at specified height
 func (m *ForkManager) GetHooks(height uint64) HooksInterface {
 	hooks := &hook.Hooks{}
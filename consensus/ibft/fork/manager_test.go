@@ -882,6 +882,46 @@ func TestForkManager_initializeValidatorStores(t *testing.T) {
 	)
 }
 
+func TestForkManagerReadsValidatorsFromState(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		forks    IBFTForks
+		expected bool
+	}{
+		{
+			name: "PoA only",
+			forks: IBFTForks{
+				{Type: PoA, From: common.JSONNumber{Value: 0}},
+			},
+			expected: false,
+		},
+		{
+			name: "PoA to PoS",
+			forks: IBFTForks{
+				{Type: PoA, From: common.JSONNumber{Value: 0}, To: &common.JSONNumber{Value: 49}},
+				{Type: PoS, From: common.JSONNumber{Value: 50}},
+			},
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fm := &ForkManager{
+				forks: test.forks,
+			}
+
+			assert.Equal(t, test.expected, fm.ReadsValidatorsFromState())
+		})
+	}
+}
+
 func TestForkManager_initializeHooksRegisters(t *testing.T) {
 	t.Parallel()
 
@@ -15,6 +15,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/syncer"
+	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validators"
 	"github.com/armon/go-metrics"
@@ -39,6 +40,8 @@ var (
 	ErrInvalidMixHash             = errors.New("invalid mixhash")
 	ErrInvalidSha3Uncles          = errors.New("invalid sha3 uncles")
 	ErrWrongDifficulty            = errors.New("wrong difficulty")
+	ErrStateSyncNotSupported      = errors.New("state sync isn't supported by the PoS forks, " +
+		"their validators are read from the state to verify the headers")
 )
 
 type txPoolInterface interface {
@@ -50,6 +53,8 @@ type txPoolInterface interface {
 	Demote(tx *types.Transaction)
 	ResetWithHeaders(headers ...*types.Header)
 	SetSealing(bool)
+	PeekBundles(blockNumber, timestamp uint64) []*txpool.Bundle
+	RemoveBundle(bundle *txpool.Bundle)
 }
 
 type forkManagerInterface interface {
@@ -137,6 +142,11 @@ func Factory(params *consensus.Params) (consensus.Consensus, error) {
 		return nil, err
 	}
 
+	// the headers of the blocks imported by the state sync are verified before their state is downloaded
+	if params.StateSync && forkManager.ReadsValidatorsFromState() {
+		return nil, ErrStateSyncNotSupported
+	}
+
 	p := &backendIBFT{
 		// References
 		logger:     logger,
@@ -148,6 +158,8 @@ func Factory(params *consensus.Params) (consensus.Consensus, error) {
 			params.Logger,
 			params.Network,
 			params.Blockchain,
+			params.StateStorage,
+			params.StateSync,
 			time.Duration(params.BlockTime)*3*time.Second,
 		),
 		secretsManager: params.SecretsManager,
@@ -160,6 +160,9 @@ func (b *BlockBuilder) Fill() {
 	blockTimer := time.NewTimer(b.params.BlockTime)
 
 	b.params.TxPool.Prepare()
+
+	// the bundles are applied before the rest of the transactions
+	b.writeBundles()
 write:
 	for {
 		select {
@@ -215,6 +218,28 @@ func (b *BlockBuilder) writeTxPoolTransaction(tx *types.Transaction) (bool, erro
 	return false, nil
 }
 
+// writeBundles applies each of the executable bundles from the txpool as a unit
+func (b *BlockBuilder) writeBundles() {
+	for _, bundle := range b.params.TxPool.PeekBundles(b.header.Number, b.header.Timestamp) {
+		if bundle.Gas() > b.params.GasLimit {
+			b.params.Logger.Info("Bundle gas limit exceeds block gas limit", "hash", bundle.Hash,
+				"bundle gas limit", bundle.Gas(), "block gas limit", b.params.GasLimit)
+		} else if err := b.state.WriteBundle(bundle.Txs); err != nil {
+			b.params.Logger.Debug("Fill bundle error", "hash", bundle.Hash, "err", err)
+
+			if _, ok := err.(*state.GasLimitReachedTransitionApplicationError); ok { //nolint:errorlint
+				// the bundle may fit in one of the following blocks
+				continue
+			}
+		} else {
+			b.txns = append(b.txns, bundle.Txs...)
+		}
+
+		// the bundle is either included or failed
+		b.params.TxPool.RemoveBundle(bundle)
+	}
+}
+
 // GetState returns Transition reference
 func (b *BlockBuilder) GetState() *state.Transition {
 	return b.state
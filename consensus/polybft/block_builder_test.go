@@ -11,9 +11,11 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/state"
 	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/umbracle/ethgo"
 )
@@ -76,6 +78,7 @@ func TestBlockBuilder_BuildBlockTxOneFailedTxAndOneTakesTooMuchGas(t *testing.T)
 
 	txPool := &txPoolMock{}
 	txPool.On("Prepare").Once()
+	txPool.On("PeekBundles", uint64(1), mock.Anything).Return([]*txpool.Bundle(nil)).Once()
 
 	for i, acc := range accounts {
 		receiver := types.Address(acc.Ecdsa.Address())
@@ -158,3 +161,85 @@ func TestBlockBuilder_BuildBlockTxOneFailedTxAndOneTakesTooMuchGas(t *testing.T)
 	assert.False(t, fb.Block.Header.LogsBloom.IsLogInBloom(
 		&types.Log{Address: types.StringToAddress("111177779999")}))
 }
+
+func TestBlockBuilder_WriteBundles(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chainID       = 100
+		blockGasLimit = 21000 * 10
+	)
+
+	funded, unfunded := generateTestAccount(t), generateTestAccount(t)
+
+	forks := &chain.Forks{}
+	logger := hclog.NewNullLogger()
+	signer := crypto.NewSigner(forks.At(0), chainID)
+
+	executor := state.NewExecutor(&chain.Params{ChainID: chainID, Forks: forks},
+		itrie.NewState(itrie.NewMemoryStorage()), logger)
+
+	executor.GetHash = func(header *types.Header) func(i uint64) types.Hash {
+		return func(i uint64) (res types.Hash) {
+			return types.BytesToHash(common.EncodeUint64ToBytes(i))
+		}
+	}
+
+	hash, err := executor.WriteGenesis(map[types.Address]*chain.GenesisAccount{
+		types.Address(funded.Ecdsa.Address()): {Balance: ethgo.Ether(1)},
+	}, types.ZeroHash)
+	require.NoError(t, err)
+
+	newTx := func(acc *wallet.Account, nonce uint64) *types.Transaction {
+		t.Helper()
+
+		privateKey, err := acc.GetEcdsaPrivateKey()
+		require.NoError(t, err)
+
+		tx, err := signer.SignTx(&types.Transaction{
+			Value:    big.NewInt(1),
+			GasPrice: big.NewInt(1),
+			Gas:      21000,
+			Nonce:    nonce,
+			To:       &types.ZeroAddress,
+		}, privateKey)
+		require.NoError(t, err)
+
+		return tx
+	}
+
+	included := &txpool.Bundle{Txs: []*types.Transaction{newTx(funded, 0), newTx(funded, 1)}}
+	// the second transaction can't pay for the gas
+	failed := &txpool.Bundle{Txs: []*types.Transaction{newTx(funded, 2), newTx(unfunded, 0)}}
+	// the bundle doesn't fit in the block
+	tooLarge := &txpool.Bundle{Txs: []*types.Transaction{newTx(funded, 2)}}
+	tooLarge.Txs[0].Gas = blockGasLimit + 1
+
+	txPool := &txPoolMock{}
+	txPool.On("Prepare").Once()
+	txPool.On("PeekBundles", uint64(1), mock.Anything).
+		Return([]*txpool.Bundle{included, failed, tooLarge}).Once()
+	txPool.On("RemoveBundle", included).Once()
+	txPool.On("RemoveBundle", failed).Once()
+	txPool.On("RemoveBundle", tooLarge).Once()
+	txPool.On("Peek").Return((*types.Transaction)(nil)).Once()
+
+	bb := NewBlockBuilder(&BlockBuilderParams{
+		BlockTime: time.Millisecond * 100,
+		Parent:    &types.Header{StateRoot: hash, GasLimit: blockGasLimit},
+		Coinbase:  types.ZeroAddress,
+		Executor:  executor,
+		GasLimit:  blockGasLimit,
+		TxPool:    txPool,
+		Logger:    logger,
+	})
+
+	require.NoError(t, bb.Reset())
+
+	bb.Fill()
+
+	txPool.AssertExpectations(t)
+	require.Equal(t, included.Txs, bb.txns)
+	require.Len(t, bb.Receipts(), 2)
+	require.Equal(t, uint64(2), bb.GetState().GetNonce(types.Address(funded.Ecdsa.Address())))
+}
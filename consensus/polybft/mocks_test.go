@@ -8,6 +8,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/syncer"
+	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/mock"
@@ -339,6 +340,16 @@ func (tp *txPoolMock) ResetWithHeaders(values ...*types.Header) {
 	tp.Called(values)
 }
 
+func (tp *txPoolMock) PeekBundles(blockNumber, timestamp uint64) []*txpool.Bundle {
+	args := tp.Called(blockNumber, timestamp)
+
+	return args[0].([]*txpool.Bundle) //nolint
+}
+
+func (tp *txPoolMock) RemoveBundle(bundle *txpool.Bundle) {
+	tp.Called(bundle)
+}
+
 var _ syncer.Syncer = (*syncerMock)(nil)
 
 type syncerMock struct {
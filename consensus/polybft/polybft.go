@@ -428,6 +428,8 @@ func (p *Polybft) Initialize() error {
 		p.config.Logger.Named("syncer"),
 		p.config.Network,
 		p.config.Blockchain,
+		p.config.StateStorage,
+		p.config.StateSync,
 		time.Duration(p.config.BlockTime)*3*time.Second,
 	)
 
@@ -2,12 +2,14 @@ package crypto
 
 import (
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/helper/keccak"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/fastrlp"
 )
 
 // Magic numbers from Ethereum, used in v calculation
@@ -16,6 +18,10 @@ var (
 	big35 = big.NewInt(35)
 )
 
+// ErrSponsoredTxNotSupported is returned when a signer which predates the typed transactions
+// or the sponsored transactions fork is asked for the sponsor of a transaction
+var ErrSponsoredTxNotSupported = errors.New("sponsored transactions are not supported by the signer")
+
 // TxSigner is a utility interface used to recover data from a transaction
 type TxSigner interface {
 	// Hash returns the hash of the transaction
@@ -24,6 +30,9 @@ type TxSigner interface {
 	// Sender returns the sender of the transaction
 	Sender(tx *types.Transaction) (types.Address, error)
 
+	// Sponsor returns the account which signed the sponsored transaction as its gas payer
+	Sponsor(tx *types.Transaction) (types.Address, error)
+
 	// SignTx signs a transaction
 	SignTx(tx *types.Transaction, priv *ecdsa.PrivateKey) (*types.Transaction, error)
 }
@@ -40,8 +49,12 @@ func NewSigner(forks chain.ForksInTime, chainID uint64) TxSigner {
 
 	// London signer requires a fallback signer that is defined above.
 	// This is the reason why the london signer check is separated.
-	if forks.London {
-		return NewLondonSigner(chainID, forks.Homestead, signer)
+	// It also signs access list transactions which are introduced by the berlin fork.
+	if forks.London || forks.Berlin {
+		londonSigner := NewLondonSigner(chainID, forks.Homestead, signer)
+		londonSigner.noSponsoredTx = !forks.SponsoredTx
+
+		return londonSigner
 	}
 
 	return signer
@@ -64,11 +77,46 @@ func encodeSignature(R, S, V *big.Int, isHomestead bool) ([]byte, error) {
 // calcTxHash calculates the transaction hash (keccak256 hash of the RLP value)
 func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
 	a := signerPool.Get()
+
+	v := txSigningPayload(a, tx, chainID)
+
+	var hash []byte
+	if tx.IsTyped() {
+		hash = keccak.PrefixedKeccak256Rlp([]byte{byte(tx.Type)}, nil, v)
+	} else {
+		hash = keccak.Keccak256Rlp(nil, v)
+	}
+
+	signerPool.Put(a)
+
+	return types.BytesToHash(hash)
+}
+
+// calcSponsorHash calculates the hash signed by the sponsor of the transaction,
+// the payload signed by the sender followed by the sender's signature
+func calcSponsorHash(tx *types.Transaction, chainID uint64) types.Hash {
+	a := signerPool.Get()
+
+	v := txSigningPayload(a, tx, chainID)
+	v.Set(a.NewBigInt(tx.V))
+	v.Set(a.NewBigInt(tx.R))
+	v.Set(a.NewBigInt(tx.S))
+
+	hash := keccak.PrefixedKeccak256Rlp([]byte{byte(tx.Type)}, nil, v)
+
+	signerPool.Put(a)
+
+	return types.BytesToHash(hash)
+}
+
+// txSigningPayload returns the RLP value of the transaction fields signed by the sender
+func txSigningPayload(a *fastrlp.Arena, tx *types.Transaction, chainID uint64) *fastrlp.Value {
 	isDynamicFeeTx := tx.Type == types.DynamicFeeTx
+	isTypedTx := tx.IsTyped()
 
 	v := a.NewArray()
 
-	if isDynamicFeeTx {
+	if isTypedTx {
 		v.Set(a.NewUint(chainID))
 	}
 
@@ -93,8 +141,8 @@ func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
 
 	v.Set(a.NewCopyBytes(tx.Input))
 
-	if isDynamicFeeTx {
-		v.Set(a.NewArray())
+	if isTypedTx {
+		v.Set(tx.AccessList.MarshalRLPWith(a))
 	} else {
 		// EIP155
 		if chainID != 0 {
@@ -104,14 +152,10 @@ func calcTxHash(tx *types.Transaction, chainID uint64) types.Hash {
 		}
 	}
 
-	var hash []byte
-	if isDynamicFeeTx {
-		hash = keccak.PrefixedKeccak256Rlp([]byte{byte(tx.Type)}, nil, v)
-	} else {
-		hash = keccak.Keccak256Rlp(nil, v)
+	// the sender agrees on the account paying the gas
+	if tx.IsSponsored() {
+		v.Set(a.NewCopyBytes(tx.Sponsor.Bytes()))
 	}
 
-	signerPool.Put(a)
-
-	return types.BytesToHash(hash)
+	return v
 }
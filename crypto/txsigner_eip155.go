@@ -65,6 +65,11 @@ func (e *EIP155Signer) Sender(tx *types.Transaction) (types.Address, error) {
 	return types.BytesToAddress(buf), nil
 }
 
+// Sponsor returns an error, the sponsored transactions are signed by the london signer
+func (e *EIP155Signer) Sponsor(_ *types.Transaction) (types.Address, error) {
+	return types.ZeroAddress, ErrSponsoredTxNotSupported
+}
+
 // SignTx signs the transaction using the passed in private key
 func (e *EIP155Signer) SignTx(
 	tx *types.Transaction,
@@ -52,6 +52,11 @@ func (f *FrontierSigner) Sender(tx *types.Transaction) (types.Address, error) {
 	return types.BytesToAddress(buf), nil
 }
 
+// Sponsor returns an error, the sponsored transactions are signed by the london signer
+func (f *FrontierSigner) Sponsor(_ *types.Transaction) (types.Address, error) {
+	return types.ZeroAddress, ErrSponsoredTxNotSupported
+}
+
 // SignTx signs the transaction using the passed in private key
 func (f *FrontierSigner) SignTx(
 	tx *types.Transaction,
@@ -2,19 +2,24 @@ package crypto
 
 import (
 	"crypto/ecdsa"
+	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
-// LondonSigner implements signer for EIP-1559
+// LondonSigner implements signer for EIP-1559, EIP-2930 and sponsored transactions
 type LondonSigner struct {
 	chainID        uint64
 	isHomestead    bool
 	fallbackSigner TxSigner
+
+	// noSponsoredTx rejects the sponsored transactions, before the sponsored transactions fork
+	noSponsoredTx bool
 }
 
-// NewLondonSigner returns a new LondonSigner object
+// NewLondonSigner returns a new LondonSigner object, supporting the sponsored transactions
 func NewLondonSigner(chainID uint64, isHomestead bool, fallbackSigner TxSigner) *LondonSigner {
 	return &LondonSigner{
 		chainID:        chainID,
@@ -30,17 +35,70 @@ func (e *LondonSigner) Hash(tx *types.Transaction) types.Hash {
 
 // Sender returns the transaction sender
 func (e *LondonSigner) Sender(tx *types.Transaction) (types.Address, error) {
-	// Apply fallback signer for non-dynamic-fee-txs
-	if tx.Type != types.DynamicFeeTx {
+	// Apply fallback signer for non-typed txs
+	if !tx.IsTyped() {
 		return e.fallbackSigner.Sender(tx)
 	}
 
-	sig, err := encodeSignature(tx.R, tx.S, tx.V, e.isHomestead)
+	if tx.IsSponsored() && e.noSponsoredTx {
+		return types.ZeroAddress, ErrSponsoredTxNotSupported
+	}
+
+	return e.recover(e.Hash(tx), tx.R, tx.S, tx.V)
+}
+
+// SponsorHash returns the hash signed by the sponsor of the transaction
+func (e *LondonSigner) SponsorHash(tx *types.Transaction) types.Hash {
+	return calcSponsorHash(tx, e.chainID)
+}
+
+// Sponsor returns the account which signed the sponsored transaction as its gas payer
+func (e *LondonSigner) Sponsor(tx *types.Transaction) (types.Address, error) {
+	if !tx.IsSponsored() {
+		return types.ZeroAddress, fmt.Errorf("%s has no sponsor", tx.Type)
+	}
+
+	if e.noSponsoredTx {
+		return types.ZeroAddress, ErrSponsoredTxNotSupported
+	}
+
+	if tx.SponsorV == nil {
+		return types.ZeroAddress, errors.New("missing sponsor signature")
+	}
+
+	return e.recover(e.SponsorHash(tx), tx.SponsorR, tx.SponsorS, tx.SponsorV)
+}
+
+// SignSponsorTx signs the transaction signed by the sender as its sponsor
+func (e *LondonSigner) SignSponsorTx(tx *types.Transaction, pk *ecdsa.PrivateKey) (*types.Transaction, error) {
+	if !tx.IsSponsored() {
+		return nil, fmt.Errorf("%s has no sponsor", tx.Type)
+	}
+
+	tx = tx.Copy()
+
+	h := e.SponsorHash(tx)
+
+	sig, err := Sign(pk, h[:])
+	if err != nil {
+		return nil, err
+	}
+
+	tx.SponsorR = new(big.Int).SetBytes(sig[:32])
+	tx.SponsorS = new(big.Int).SetBytes(sig[32:64])
+	tx.SponsorV = new(big.Int).SetBytes(e.calculateV(sig[64]))
+
+	return tx, nil
+}
+
+// recover returns the address which signed the hash
+func (e *LondonSigner) recover(hash types.Hash, r, s, v *big.Int) (types.Address, error) {
+	sig, err := encodeSignature(r, s, v, e.isHomestead)
 	if err != nil {
 		return types.Address{}, err
 	}
 
-	pub, err := Ecrecover(e.Hash(tx).Bytes(), sig)
+	pub, err := Ecrecover(hash.Bytes(), sig)
 	if err != nil {
 		return types.Address{}, err
 	}
@@ -52,8 +110,8 @@ func (e *LondonSigner) Sender(tx *types.Transaction) (types.Address, error) {
 
 // SignTx signs the transaction using the passed in private key
 func (e *LondonSigner) SignTx(tx *types.Transaction, pk *ecdsa.PrivateKey) (*types.Transaction, error) {
-	// Apply fallback signer for non-dynamic-fee-txs
-	if tx.Type != types.DynamicFeeTx {
+	// Apply fallback signer for non-typed txs
+	if !tx.IsTyped() {
 		return e.fallbackSigner.SignTx(tx, pk)
 	}
 
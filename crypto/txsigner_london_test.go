@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -143,3 +144,127 @@ func Test_LondonSigner_Sender(t *testing.T) {
 		})
 	}
 }
+
+func TestLondonSigner_AccessList(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateECDSAKey()
+	require.NoError(t, err)
+
+	to := types.StringToAddress("1")
+	signer := NewLondonSigner(100, true, NewEIP155Signer(100, true))
+
+	for _, txType := range []types.TxType{types.AccessListTx, types.DynamicFeeTx} {
+		txType := txType
+
+		t.Run(txType.String(), func(t *testing.T) {
+			t.Parallel()
+
+			txn := &types.Transaction{
+				Type:      txType,
+				To:        &to,
+				Value:     big.NewInt(1),
+				GasPrice:  big.NewInt(10),
+				GasTipCap: big.NewInt(10),
+				GasFeeCap: big.NewInt(10),
+				Gas:       30000,
+				AccessList: types.TxAccessList{
+					{
+						Address:     to,
+						StorageKeys: []types.Hash{types.StringToHash("1")},
+					},
+				},
+			}
+
+			signedTx, err := signer.SignTx(txn, key)
+			require.NoError(t, err)
+
+			sender, err := signer.Sender(signedTx)
+			require.NoError(t, err)
+			assert.Equal(t, PubKeyToAddress(&key.PublicKey), sender)
+
+			// the access list is the part of the signed payload
+			signedTx.AccessList[0].StorageKeys[0] = types.StringToHash("2")
+
+			sender, err = signer.Sender(signedTx)
+			require.NoError(t, err)
+			assert.NotEqual(t, PubKeyToAddress(&key.PublicKey), sender)
+		})
+	}
+}
+
+func TestLondonSigner_Sponsored(t *testing.T) {
+	t.Parallel()
+
+	senderKey, err := GenerateECDSAKey()
+	require.NoError(t, err)
+
+	sponsorKey, err := GenerateECDSAKey()
+	require.NoError(t, err)
+
+	to := types.StringToAddress("1")
+	signer := NewLondonSigner(100, true, NewEIP155Signer(100, true))
+
+	txn := &types.Transaction{
+		Type:     types.SponsoredTx,
+		To:       &to,
+		Value:    big.NewInt(1),
+		GasPrice: big.NewInt(10),
+		Gas:      30000,
+		Sponsor:  PubKeyToAddress(&sponsorKey.PublicKey),
+	}
+
+	// the transaction is not signed by the sponsor yet
+	_, err = signer.Sponsor(txn)
+	require.Error(t, err)
+
+	signedTx, err := signer.SignTx(txn, senderKey)
+	require.NoError(t, err)
+
+	signedTx, err = signer.SignSponsorTx(signedTx, sponsorKey)
+	require.NoError(t, err)
+
+	sender, err := signer.Sender(signedTx)
+	require.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(&senderKey.PublicKey), sender)
+
+	sponsor, err := signer.Sponsor(signedTx)
+	require.NoError(t, err)
+	assert.Equal(t, signedTx.Sponsor, sponsor)
+
+	// the sponsor signs the signature of the sender
+	tamperedTx := signedTx.Copy()
+	tamperedTx.S = new(big.Int).Add(tamperedTx.S, big.NewInt(1))
+
+	sponsor, err = signer.Sponsor(tamperedTx)
+	if err == nil {
+		assert.NotEqual(t, signedTx.Sponsor, sponsor)
+	}
+
+	// the sender signs the address of the sponsor
+	tamperedTx = signedTx.Copy()
+	tamperedTx.Sponsor = to
+
+	sender, err = signer.Sender(tamperedTx)
+	require.NoError(t, err)
+	assert.NotEqual(t, PubKeyToAddress(&senderKey.PublicKey), sender)
+
+	// the signers predating the typed transactions don't support the sponsors
+	_, err = NewEIP155Signer(100, true).Sponsor(signedTx)
+	assert.ErrorIs(t, err, ErrSponsoredTxNotSupported)
+
+	// the sponsored transactions are rejected before their fork
+	forks := chain.ForksInTime{Homestead: true, EIP155: true, London: true}
+
+	_, err = NewSigner(forks, 100).Sender(signedTx)
+	assert.ErrorIs(t, err, ErrSponsoredTxNotSupported)
+
+	_, err = NewSigner(forks, 100).Sponsor(signedTx)
+	assert.ErrorIs(t, err, ErrSponsoredTxNotSupported)
+
+	forks.SponsoredTx = true
+
+	sponsor, err = NewSigner(forks, 100).Sponsor(signedTx)
+	require.NoError(t, err)
+	assert.Equal(t, signedTx.Sponsor, sponsor)
+}
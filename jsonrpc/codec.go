@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -140,6 +141,7 @@ type BlockNumberOrHash struct {
 // 2 - "0x2"								- block number #2 (EIP-1898 backward compatible)
 // 3 - {blockNumber:	"0x2"}				- EIP-1898 compliant block number #2
 // 4 - {blockHash:		"0xe0e..."}			- EIP-1898 compliant block hash 0xe0e...
+// 5 - "0xe0e..."							- block hash 0xe0e...
 func (bnh *BlockNumberOrHash) UnmarshalJSON(data []byte) error {
 	type bnhCopy BlockNumberOrHash
 
@@ -147,12 +149,16 @@ func (bnh *BlockNumberOrHash) UnmarshalJSON(data []byte) error {
 
 	err := json.Unmarshal(data, &placeholder)
 	if err != nil {
-		number, err := stringToBlockNumber(string(data))
-		if err != nil {
-			return err
+		if hash, ok := stringToBlockHash(string(data)); ok {
+			placeholder.BlockHash = &hash
+		} else {
+			number, err := stringToBlockNumber(string(data))
+			if err != nil {
+				return err
+			}
+
+			placeholder.BlockNumber = &number
 		}
-
-		placeholder.BlockNumber = &number
 	}
 
 	// Try to extract object
@@ -189,6 +195,21 @@ func stringToBlockNumber(str string) (BlockNumber, error) {
 	return BlockNumber(n), nil
 }
 
+// stringToBlockHash parses the string as a block hash, which is expected to have the full hash length
+func stringToBlockHash(str string) (types.Hash, bool) {
+	str = strings.Trim(str, "\"")
+	if len(str) != 2+2*types.HashLength || !strings.HasPrefix(str, "0x") {
+		return types.ZeroHash, false
+	}
+
+	buf, err := hex.DecodeHex(str)
+	if err != nil {
+		return types.ZeroHash, false
+	}
+
+	return types.BytesToHash(buf), true
+}
+
 func createBlockNumberPointer(str string) (*BlockNumber, error) {
 	blockNumber, err := stringToBlockNumber(str)
 	if err != nil {
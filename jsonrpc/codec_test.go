@@ -82,6 +82,20 @@ func TestBlockNumberOrHash_UnmarshalJSON(t *testing.T) {
 				BlockHash: &blockHash,
 			},
 		},
+		{
+			"should unmarshal block hash string properly",
+			`"0xe0ee62fd4a39a6988e24df0b406b90af71932e1b01d5561400a8eab943a33d68"`,
+			false,
+			BlockNumberOrHash{
+				BlockHash: &blockHash,
+			},
+		},
+		{
+			"should return an error for invalid block hash string",
+			`"0xz0ee62fd4a39a6988e24df0b406b90af71932e1b01d5561400a8eab943a33d68"`,
+			true,
+			BlockNumberOrHash{},
+		},
 	}
 
 	for _, tt := range tests {
@@ -2,16 +2,27 @@ package jsonrpc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/jstracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/prestatetracer"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/structtracer"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
+const (
+	// callTracerName is the name of the built-in tracer collecting the call tree
+	callTracerName = "callTracer"
+	// prestateTracerName is the name of the built-in tracer collecting the touched accounts
+	prestateTracerName = "prestateTracer"
+)
+
 var (
 	defaultTraceTimeout = 5 * time.Second
 
@@ -21,6 +32,8 @@ var (
 	ErrTraceGenesisBlock = errors.New("genesis is not traceable")
 	// ErrNoConfig is an error returns when config is empty
 	ErrNoConfig = errors.New("missing config object")
+	// ErrUnknownTracer is an error returned when the requested tracer doesn't exist
+	ErrUnknownTracer = errors.New("unknown tracer")
 )
 
 type debugBlockchainStore interface {
@@ -69,11 +82,13 @@ type Debug struct {
 }
 
 type TraceConfig struct {
-	EnableMemory     bool    `json:"enableMemory"`
-	DisableStack     bool    `json:"disableStack"`
-	DisableStorage   bool    `json:"disableStorage"`
-	EnableReturnData bool    `json:"enableReturnData"`
-	Timeout          *string `json:"timeout"`
+	EnableMemory     bool            `json:"enableMemory"`
+	DisableStack     bool            `json:"disableStack"`
+	DisableStorage   bool            `json:"disableStorage"`
+	EnableReturnData bool            `json:"enableReturnData"`
+	Timeout          *string         `json:"timeout"`
+	Tracer           string          `json:"tracer"`
+	TracerConfig     json.RawMessage `json:"tracerConfig"`
 }
 
 func (d *Debug) TraceBlockByNumber(
@@ -166,12 +181,12 @@ func (d *Debug) TraceCall(
 	}
 
 	tracer, cancel, err := newTracer(config)
-	defer cancel()
-
 	if err != nil {
 		return nil, err
 	}
 
+	defer cancel()
+
 	return d.store.TraceCall(tx, header, tracer)
 }
 
@@ -184,12 +199,12 @@ func (d *Debug) traceBlock(
 	}
 
 	tracer, cancel, err := newTracer(config)
-	defer cancel()
-
 	if err != nil {
 		return nil, err
 	}
 
+	defer cancel()
+
 	return d.store.TraceBlock(block, tracer)
 }
 
@@ -214,13 +229,32 @@ func newTracer(config *TraceConfig) (
 		}
 	}
 
-	tracer := structtracer.NewStructTracer(structtracer.Config{
-		EnableMemory:     config.EnableMemory,
-		EnableStack:      !config.DisableStack,
-		EnableStorage:    !config.DisableStorage,
-		EnableReturnData: config.EnableReturnData,
-	})
+	tracer, err := newTracerByName(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// cancellation of context is done by caller
+	cancel := startTraceTimeout(tracer, timeout)
+
+	// the script of the JavaScript tracer runs within the timeout
+	if jsTracer, ok := tracer.(*jstracer.JSTracer); ok {
+		if err := jsTracer.Setup(); err != nil {
+			cancel()
+
+			if errors.Is(err, ErrExecutionTimeout) {
+				return nil, nil, err
+			}
+
+			return nil, nil, fmt.Errorf("%w: %s", ErrUnknownTracer, err.Error())
+		}
+	}
+
+	return tracer, cancel, nil
+}
 
+// startTraceTimeout cancels the tracer once the given timeout is exceeded
+func startTraceTimeout(tracer tracer.Tracer, timeout time.Duration) context.CancelFunc {
 	timeoutCtx, cancel := context.WithTimeout(context.Background(), timeout)
 
 	go func() {
@@ -231,6 +265,58 @@ func newTracer(config *TraceConfig) (
 		}
 	}()
 
-	// cancellation of context is done by caller
-	return tracer, cancel, nil
+	return cancel
+}
+
+// newTracerByName creates the tracer selected in config,
+// the struct logger is used if no tracer is specified
+func newTracerByName(config *TraceConfig) (tracer.Tracer, error) {
+	switch config.Tracer {
+	case "":
+		return structtracer.NewStructTracer(structtracer.Config{
+			EnableMemory:     config.EnableMemory,
+			EnableStack:      !config.DisableStack,
+			EnableStorage:    !config.DisableStorage,
+			EnableReturnData: config.EnableReturnData,
+		}), nil
+
+	case callTracerName:
+		callConfig := calltracer.Config{}
+
+		if err := decodeTracerConfig(config.TracerConfig, &callConfig); err != nil {
+			return nil, err
+		}
+
+		return calltracer.NewCallTracer(callConfig), nil
+
+	case prestateTracerName:
+		prestateConfig := prestatetracer.Config{}
+
+		if err := decodeTracerConfig(config.TracerConfig, &prestateConfig); err != nil {
+			return nil, err
+		}
+
+		return prestatetracer.NewPrestateTracer(prestateConfig), nil
+	}
+
+	// any other value is evaluated as the code of the JavaScript tracer
+	jsTracer, err := jstracer.NewJSTracer(config.Tracer, config.TracerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTracer, err.Error())
+	}
+
+	return jsTracer, nil
+}
+
+// decodeTracerConfig decodes the tracer specific config if it's given
+func decodeTracerConfig(raw json.RawMessage, config interface{}) error {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(raw, config); err != nil {
+		return fmt.Errorf("invalid tracer config: %w", err)
+	}
+
+	return nil
 }
@@ -8,6 +8,9 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/calltracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/jstracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/prestatetracer"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -138,6 +141,16 @@ func TestDebugTraceConfigDecode(t *testing.T) {
 				Timeout:          &timeout15s,
 			},
 		},
+		{
+			input: `{
+				"tracer": "callTracer",
+				"tracerConfig": {"withLog": true}
+			}`,
+			expected: TraceConfig{
+				Tracer:       "callTracer",
+				TracerConfig: json.RawMessage(`{"withLog": true}`),
+			},
+		},
 		{
 			input: `{
 				"enableMemory": true,
@@ -715,6 +728,85 @@ func Test_newTracer(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("should create built-in tracers", func(t *testing.T) {
+		t.Parallel()
+
+		callTracer, cancel, err := newTracer(&TraceConfig{
+			Tracer:       "callTracer",
+			TracerConfig: json.RawMessage(`{"onlyTopCall": true}`),
+		})
+
+		assert.NoError(t, err)
+		cancel()
+
+		assert.IsType(t, &calltracer.CallTracer{}, callTracer)
+		assert.True(t, callTracer.(*calltracer.CallTracer).Config.OnlyTopCall) //nolint:forcetypeassert
+
+		prestateTracer, cancel, err := newTracer(&TraceConfig{
+			Tracer:       "prestateTracer",
+			TracerConfig: json.RawMessage(`{"diffMode": true}`),
+		})
+
+		assert.NoError(t, err)
+		cancel()
+
+		assert.IsType(t, &prestatetracer.PrestateTracer{}, prestateTracer)
+		assert.True(t, prestateTracer.(*prestatetracer.PrestateTracer).Config.DiffMode) //nolint:forcetypeassert
+	})
+
+	t.Run("should create js tracer", func(t *testing.T) {
+		t.Parallel()
+
+		jsTracer, cancel, err := newTracer(&TraceConfig{
+			Tracer: `{count: 0, step: function() { this.count++; }, result: function() { return this.count; }}`,
+		})
+
+		assert.NoError(t, err)
+		cancel()
+
+		assert.IsType(t, &jstracer.JSTracer{}, jsTracer)
+	})
+
+	t.Run("should stop js tracer setup on timeout", func(t *testing.T) {
+		t.Parallel()
+
+		timeout := "100ms"
+
+		tracer, cancel, err := newTracer(&TraceConfig{
+			Tracer:  `{setup: function() { for (;;) {} }, result: function() { return {}; }}`,
+			Timeout: &timeout,
+		})
+
+		assert.Nil(t, tracer)
+		assert.Nil(t, cancel)
+		assert.ErrorIs(t, err, ErrExecutionTimeout)
+	})
+
+	t.Run("should return error if tracer is unknown", func(t *testing.T) {
+		t.Parallel()
+
+		tracer, cancel, err := newTracer(&TraceConfig{
+			Tracer: "unknownTracer",
+		})
+
+		assert.Nil(t, tracer)
+		assert.Nil(t, cancel)
+		assert.ErrorIs(t, err, ErrUnknownTracer)
+	})
+
+	t.Run("should return error if tracer config is invalid", func(t *testing.T) {
+		t.Parallel()
+
+		tracer, cancel, err := newTracer(&TraceConfig{
+			Tracer:       "callTracer",
+			TracerConfig: json.RawMessage(`{"onlyTopCall": "yes"}`),
+		})
+
+		assert.Nil(t, tracer)
+		assert.Nil(t, cancel)
+		assert.Error(t, err)
+	})
+
 	t.Run("should return error if arg is nil", func(t *testing.T) {
 		t.Parallel()
 
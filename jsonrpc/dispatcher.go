@@ -14,6 +14,14 @@ import (
 
 	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/state"
+)
+
+const (
+	openSquareBracket  byte = '['
+	closeSquareBracket byte = ']'
+	comma              byte = ','
 )
 
 type serviceData struct {
@@ -39,6 +47,7 @@ type endpoints struct {
 	TxPool *TxPool
 	Bridge *Bridge
 	Debug  *Debug
+	Trace  *Trace
 }
 
 // Dispatcher handles all json rpc requests by delegating
@@ -48,6 +57,7 @@ type Dispatcher struct {
 	serviceMap    map[string]*serviceData
 	filterManager *FilterManager
 	endpoints     endpoints
+	access        *methodAccess
 
 	params *dispatcherParams
 }
@@ -59,6 +69,11 @@ type dispatcherParams struct {
 	priceLimit              uint64
 	jsonRPCBatchLengthLimit uint64
 	blockRangeLimit         uint64
+	maxSubscriptionsPerConn uint64
+
+	namespaces     []string
+	allowedMethods []string
+	deniedMethods  []string
 }
 
 func (dp dispatcherParams) isExceedingBatchLengthLimit(value uint64) bool {
@@ -84,6 +99,13 @@ func newDispatcher(
 		return nil, err
 	}
 
+	access, err := newMethodAccess(d.serviceMap, params.namespaces, params.allowedMethods, params.deniedMethods)
+	if err != nil {
+		return nil, err
+	}
+
+	d.access = access
+
 	return d, nil
 }
 
@@ -112,6 +134,10 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) error {
 	d.endpoints.Debug = &Debug{
 		store,
 	}
+	d.endpoints.Trace = &Trace{
+		store,
+		d.params.blockRangeLimit,
+	}
 
 	var err error
 
@@ -135,10 +161,18 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) error {
 		return err
 	}
 
-	return d.registerService("debug", d.endpoints.Debug)
+	if err = d.registerService("debug", d.endpoints.Debug); err != nil {
+		return err
+	}
+
+	return d.registerService("trace", d.endpoints.Trace)
 }
 
 func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error) {
+	if !d.access.isAvailable(req.Method) {
+		return nil, nil, NewMethodNotFoundError(req.Method)
+	}
+
 	callName := strings.SplitN(req.Method, "_", 2)
 	if len(callName) != 2 {
 		return nil, nil, NewMethodNotFoundError(req.Method)
@@ -200,19 +234,44 @@ func (d *Dispatcher) handleSubscribe(req Request, conn wsConn) (string, Error) {
 		return "", NewSubscriptionNotFoundError(subscribeMethod)
 	}
 
-	var filterID string
-	if subscribeMethod == "newHeads" {
-		filterID = d.filterManager.NewBlockFilter(conn)
-	} else if subscribeMethod == "logs" {
+	var filter filter
+
+	switch subscribeMethod {
+	case "newHeads":
+		filter = d.filterManager.newBlockFilter(conn)
+	case "logs":
 		logQuery, err := decodeLogQueryFromInterface(params[1])
 		if err != nil {
 			return "", NewInternalError(err.Error())
 		}
-		filterID = d.filterManager.NewLogFilter(logQuery, conn)
-	} else {
+
+		filter = newLogFilter(logQuery, conn)
+	case "newPendingTransactions":
+		// the transaction objects are returned instead of the hashes if the second param is true
+		fullTx := false
+
+		if len(params) > 1 {
+			if fullTx, ok = params[1].(bool); !ok {
+				return "", NewInvalidParamsError("Invalid params")
+			}
+		}
+
+		filter = d.filterManager.newPendingTxFilter(fullTx, conn)
+	case "syncing":
+		filter = newSyncingFilter(conn)
+	default:
 		return "", NewSubscriptionNotFoundError(subscribeMethod)
 	}
 
+	// if not disabled, avoid creating too many filters for the connection,
+	// the filters are counted and added at once so the concurrent subscriptions can't exceed the limit
+	filterID, added := d.filterManager.addWSFilter(filter, d.params.maxSubscriptionsPerConn)
+	if !added {
+		metrics.IncrCounter([]string{jsonRPCMetric, "subscriptions_limit_exceeded"}, 1)
+
+		return "", NewLimitExceededError("too many subscriptions for the connection")
+	}
+
 	return filterID, nil
 }
 
@@ -239,12 +298,6 @@ func (d *Dispatcher) RemoveFilterByWs(conn wsConn) {
 }
 
 func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
-	const (
-		openSquareBracket  byte = '['
-		closeSquareBracket byte = ']'
-		comma              byte = ','
-	)
-
 	reqBody = bytes.TrimLeft(reqBody, " \t\r\n")
 
 	// if body begins with [ consider it as a batch request
@@ -303,6 +356,10 @@ func (d *Dispatcher) handleSingleWs(req Request, conn wsConn) Response {
 
 	var response []byte
 
+	if (req.Method == "eth_subscribe" || req.Method == "eth_unsubscribe") && !d.access.isAvailable(req.Method) {
+		return NewRPCResponse(id, "2.0", nil, NewMethodNotFoundError(req.Method))
+	}
+
 	switch req.Method {
 	case "eth_subscribe":
 		var filterID string
@@ -431,6 +488,10 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 		metrics.IncrCounter([]string{jsonRPCMetric, req.Method + "_errors"}, 1)
 		d.logInternalError(req.Method, err)
 
+		if errors.Is(err, state.ErrStatePruned) {
+			return nil, NewStatePrunedError(err.Error())
+		}
+
 		if res := output[0].Interface(); res != nil {
 			data, ok = res.([]byte)
 
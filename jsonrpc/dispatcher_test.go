@@ -5,9 +5,14 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
@@ -100,6 +105,247 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 			t.Fatal("\"newHeads\" event not received in 2 seconds")
 		}
 	})
+
+	t.Run("clients should be able to receive \"newPendingTransactions\" event thru eth_subscribe", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockStore()
+		dispatcher := newTestDispatcher(t,
+			hclog.NewNullLogger(),
+			store,
+			&dispatcherParams{
+				chainID:                 0,
+				priceLimit:              0,
+				jsonRPCBatchLengthLimit: 20,
+				blockRangeLimit:         1000,
+			},
+		)
+		mockConnection, msgCh := newMockWsConnWithMsgCh()
+
+		req := []byte(`{
+		"method": "eth_subscribe",
+		"params": ["newPendingTransactions"]
+	}`)
+		if _, err := dispatcher.HandleWs(req, mockConnection); err != nil {
+			t.Fatal(err)
+		}
+
+		store.txEvents <- &proto.TxPoolEvent{
+			Type:   proto.EventType_PROMOTED,
+			TxHash: types.StringToHash("1").String(),
+		}
+
+		select {
+		case <-msgCh:
+		case <-time.After(2 * time.Second):
+			t.Fatal("\"newPendingTransactions\" event not received in 2 seconds")
+		}
+	})
+
+	t.Run("\"newPendingTransactions\" should reject non boolean params", func(t *testing.T) {
+		t.Parallel()
+
+		dispatcher := newTestDispatcher(t,
+			hclog.NewNullLogger(),
+			newMockStore(),
+			&dispatcherParams{
+				chainID:                 0,
+				priceLimit:              0,
+				jsonRPCBatchLengthLimit: 20,
+				blockRangeLimit:         1000,
+			},
+		)
+		mockConnection, _ := newMockWsConnWithMsgCh()
+
+		req := []byte(`{
+		"method": "eth_subscribe",
+		"params": ["newPendingTransactions", "true"]
+	}`)
+
+		resp, err := dispatcher.HandleWs(req, mockConnection)
+		require.NoError(t, err)
+
+		var res ErrorResponse
+
+		require.NoError(t, json.Unmarshal(resp, &res))
+		require.NotNil(t, res.Error)
+		assert.Equal(t, -32602, res.Error.Code)
+	})
+
+	t.Run("clients should be able to subscribe to \"syncing\" thru eth_subscribe", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockStore()
+		store.setSyncProgression(&progress.Progression{
+			SyncType:     progress.ChainSyncBulk,
+			CurrentBlock: 1,
+			HighestBlock: 10,
+		})
+
+		dispatcher := newTestDispatcher(t,
+			hclog.NewNullLogger(),
+			store,
+			&dispatcherParams{
+				chainID:                 0,
+				priceLimit:              0,
+				jsonRPCBatchLengthLimit: 20,
+				blockRangeLimit:         1000,
+			},
+		)
+		mockConnection, msgCh := newMockWsConnWithMsgCh()
+
+		req := []byte(`{
+		"method": "eth_subscribe",
+		"params": ["syncing"]
+	}`)
+		if _, err := dispatcher.HandleWs(req, mockConnection); err != nil {
+			t.Fatal(err)
+		}
+
+		store.emitEvent(&mockEvent{
+			NewChain: []*mockHeader{
+				{
+					header: &types.Header{
+						Number: 1,
+						Hash:   types.StringToHash("1"),
+					},
+				},
+			},
+		})
+
+		select {
+		case <-msgCh:
+		case <-time.After(2 * time.Second):
+			t.Fatal("\"syncing\" event not received in 2 seconds")
+		}
+	})
+}
+
+func TestDispatcher_HandleWebsocketConnection_SubscriptionsLimit(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+			maxSubscriptionsPerConn: 1,
+		},
+	)
+	mockConnection, _ := newMockWsConnWithMsgCh()
+
+	req := []byte(`{
+		"method": "eth_subscribe",
+		"params": ["newHeads"]
+	}`)
+
+	resp, err := dispatcher.HandleWs(req, mockConnection)
+	require.NoError(t, err)
+
+	var filterID string
+
+	require.NoError(t, expectJSONResult(resp, &filterID))
+
+	// the limit is per connection
+	otherConnection, _ := newMockWsConnWithMsgCh()
+
+	resp, err = dispatcher.HandleWs(req, otherConnection)
+	require.NoError(t, err)
+	require.NoError(t, expectJSONResult(resp, &filterID))
+
+	resp, err = dispatcher.HandleWs(req, mockConnection)
+	require.NoError(t, err)
+
+	var res ErrorResponse
+
+	require.NoError(t, json.Unmarshal(resp, &res))
+	require.NotNil(t, res.Error)
+	assert.Equal(t, -32005, res.Error.Code)
+}
+
+func TestDispatcher_HandleWebsocketConnection_SubscriptionsLimitConcurrently(t *testing.T) {
+	t.Parallel()
+
+	const (
+		limit       = 3
+		subscribers = 20
+	)
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+			maxSubscriptionsPerConn: limit,
+		},
+	)
+	mockConnection, _ := newMockWsConnWithMsgCh()
+
+	req := []byte(`{
+		"method": "eth_subscribe",
+		"params": ["newHeads"]
+	}`)
+
+	var (
+		wg         sync.WaitGroup
+		subscribed atomic.Int32
+	)
+
+	for i := 0; i < subscribers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			resp, err := dispatcher.HandleWs(req, mockConnection)
+			assert.NoError(t, err)
+
+			var filterID string
+			if expectJSONResult(resp, &filterID) == nil {
+				subscribed.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int32(limit), subscribed.Load())
+}
+
+func TestDispatcher_MethodAccess(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			namespaces:    []string{"eth", "web3"},
+			deniedMethods: []string{"eth_subscribe"},
+		},
+	)
+
+	resp, err := dispatcher.Handle([]byte(`{"method": "web3_clientVersion"}`))
+	require.NoError(t, err)
+
+	var version string
+
+	require.NoError(t, expectJSONResult(resp, &version))
+
+	for _, req := range []string{
+		`{"method": "txpool_status"}`,
+		`{"method": "eth_subscribe", "params": ["newHeads"]}`,
+	} {
+		resp, err := dispatcher.HandleWs([]byte(req), &mockWsConn{})
+		require.NoError(t, err)
+
+		var res ErrorResponse
+
+		require.NoError(t, json.Unmarshal(resp, &res))
+		require.NotNil(t, res.Error, req)
+		assert.Equal(t, -32601, res.Error.Code, req)
+	}
 }
 
 func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
@@ -214,6 +460,31 @@ func (m *mockService) Filter(f LogQuery) (interface{}, error) {
 	return nil, nil
 }
 
+// Pruned returns the error of the query at the pruned state
+func (m *mockService) Pruned() (interface{}, error) {
+	return nil, fmt.Errorf("unable to get snapshot: %w", state.ErrStatePruned)
+}
+
+func TestDispatcher_StatePruned(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{},
+	)
+
+	require.NoError(t, dispatcher.registerService("mock", &mockService{}))
+
+	_, err := dispatcher.handleReq(Request{Method: "mock_pruned"})
+
+	var rpcErr Error
+
+	require.ErrorAs(t, err, &rpcErr)
+	assert.Equal(t, -32000, rpcErr.ErrorCode())
+	assert.Contains(t, rpcErr.Error(), state.ErrStatePruned.Error())
+}
+
 func TestDispatcherFuncDecode(t *testing.T) {
 	t.Parallel()
 
@@ -9,6 +9,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -99,6 +100,162 @@ func TestEth_Block_GetBlockTransactionCountByNumber(t *testing.T) {
 	assert.Equal(t, "0xa", res)
 }
 
+func TestEth_Block_GetBlockTransactionCountByHash(t *testing.T) {
+	t.Parallel()
+
+	store := &mockBlockStore{}
+	block := newTestBlock(1, hash1)
+
+	for i := 0; i < 10; i++ {
+		block.Transactions = append(block.Transactions, newTestTransaction(uint64(i), addr0))
+	}
+
+	store.add(block)
+
+	eth := newTestEthEndpoint(store)
+
+	res, err := eth.GetBlockTransactionCountByHash(hash1)
+	assert.NoError(t, err)
+	assert.Equal(t, "0xa", res)
+
+	res, err = eth.GetBlockTransactionCountByHash(hash2)
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestEth_GetTransactionByBlockAndIndex(t *testing.T) {
+	t.Parallel()
+
+	store := &mockBlockStore{}
+	block := newTestBlock(1, hash1)
+
+	for i := 0; i < 10; i++ {
+		block.Transactions = append(block.Transactions, newTestTransaction(uint64(i), addr0))
+	}
+
+	store.add(block)
+
+	eth := newTestEthEndpoint(store)
+
+	cases := []struct {
+		name  string
+		get   func(index argUint64) (interface{}, error)
+		index argUint64
+		found bool
+	}{
+		{
+			name: "by block number",
+			get: func(index argUint64) (interface{}, error) {
+				return eth.GetTransactionByBlockNumberAndIndex(BlockNumber(1), index)
+			},
+			index: 5,
+			found: true,
+		},
+		{
+			name: "by latest block number",
+			get: func(index argUint64) (interface{}, error) {
+				return eth.GetTransactionByBlockNumberAndIndex(LatestBlockNumber, index)
+			},
+			index: 0,
+			found: true,
+		},
+		{
+			name: "by block hash",
+			get: func(index argUint64) (interface{}, error) {
+				return eth.GetTransactionByBlockHashAndIndex(hash1, index)
+			},
+			index: 9,
+			found: true,
+		},
+		{
+			name: "index out of range",
+			get: func(index argUint64) (interface{}, error) {
+				return eth.GetTransactionByBlockHashAndIndex(hash1, index)
+			},
+			index: 10,
+		},
+		{
+			name: "block number not found",
+			get: func(index argUint64) (interface{}, error) {
+				return eth.GetTransactionByBlockNumberAndIndex(BlockNumber(2), index)
+			},
+			index: 0,
+		},
+		{
+			name: "block hash not found",
+			get: func(index argUint64) (interface{}, error) {
+				return eth.GetTransactionByBlockHashAndIndex(hash2, index)
+			},
+			index: 0,
+		},
+	}
+
+	for _, c := range cases {
+		res, err := c.get(c.index)
+		assert.NoError(t, err, c.name)
+
+		if !c.found {
+			assert.Nil(t, res, c.name)
+
+			continue
+		}
+
+		//nolint:forcetypeassert
+		txn := res.(*transaction)
+		assert.Equal(t, block.Transactions[c.index].Hash, txn.Hash, c.name)
+		assert.Equal(t, argUint64(block.Number()), *txn.BlockNumber, c.name)
+		assert.Equal(t, block.Hash(), *txn.BlockHash, c.name)
+		assert.Equal(t, c.index, *txn.TxIndex, c.name)
+	}
+}
+
+func TestEth_Uncles(t *testing.T) {
+	t.Parallel()
+
+	store := &mockBlockStore{}
+	store.add(newTestBlock(1, hash1))
+
+	eth := newTestEthEndpoint(store)
+
+	res, err := eth.GetUncleCountByBlockNumber(BlockNumber(1))
+	assert.NoError(t, err)
+	assert.Equal(t, argUint64(0), res)
+
+	res, err = eth.GetUncleCountByBlockNumber(BlockNumber(2))
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	res, err = eth.GetUncleCountByBlockHash(hash1)
+	assert.NoError(t, err)
+	assert.Equal(t, argUint64(0), res)
+
+	res, err = eth.GetUncleCountByBlockHash(hash2)
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	res, err = eth.GetUncleByBlockNumberAndIndex(BlockNumber(1), 0)
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	res, err = eth.GetUncleByBlockHashAndIndex(hash1, 0)
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestEth_AccountsAndProtocolVersion(t *testing.T) {
+	t.Parallel()
+
+	eth := newTestEthEndpoint(&mockBlockStore{})
+
+	res, err := eth.Accounts()
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Address{}, res)
+
+	res, err = eth.ProtocolVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, argUint64(ethProtocolVersion), res)
+}
+
 func TestEth_GetTransactionByHash(t *testing.T) {
 	t.Parallel()
 
@@ -244,6 +401,82 @@ func TestEth_GetTransactionReceipt(t *testing.T) {
 	})
 }
 
+func TestEth_GetBlockReceipts(t *testing.T) {
+	t.Parallel()
+
+	store := newMockBlockStore()
+	eth := newTestEthEndpoint(store)
+
+	block := newTestBlock(1, hash4)
+	block.Transactions = []*types.Transaction{
+		newTestTransaction(uint64(0), addr0),
+		newTestTransaction(uint64(1), addr1),
+	}
+
+	emptyHash := types.StringToHash("5")
+	emptyBlock := newTestBlock(2, emptyHash)
+
+	store.add(block, emptyBlock)
+
+	receipt1 := &types.Receipt{
+		GasUsed: 100,
+		Logs: []*types.Log{
+			{Topics: []types.Hash{hash1}},
+			{Topics: []types.Hash{hash2}},
+		},
+	}
+	receipt1.SetStatus(types.ReceiptSuccess)
+
+	receipt2 := &types.Receipt{
+		GasUsed: 200,
+		Logs: []*types.Log{
+			{Topics: []types.Hash{hash3}},
+		},
+	}
+	receipt2.SetStatus(types.ReceiptFailed)
+
+	store.receipts[hash4] = []*types.Receipt{receipt1, receipt2}
+
+	blockNumber := BlockNumber(1)
+
+	for _, filter := range []BlockNumberOrHash{{BlockHash: &hash4}, {BlockNumber: &blockNumber}} {
+		res, err := eth.GetBlockReceipts(filter)
+		assert.NoError(t, err)
+
+		//nolint:forcetypeassert
+		receipts := res.([]*receipt)
+		assert.Len(t, receipts, 2)
+
+		for i, r := range receipts {
+			assert.Equal(t, block.Transactions[i].Hash, r.TxHash)
+			assert.Equal(t, argUint64(i), r.TxIndex)
+			assert.Equal(t, block.Hash(), r.BlockHash)
+			assert.Equal(t, argUint64(block.Number()), r.BlockNumber)
+			assert.Equal(t, block.Transactions[i].From, r.FromAddr)
+		}
+
+		assert.Equal(t, argUint64(100), receipts[0].GasUsed)
+		assert.Equal(t, argUint64(types.ReceiptSuccess), receipts[0].Status)
+		assert.Equal(t, argUint64(types.ReceiptFailed), receipts[1].Status)
+
+		// the log indexes are the positions of the logs in the block
+		assert.Len(t, receipts[0].Logs, 2)
+		assert.Equal(t, argUint64(0), receipts[0].Logs[0].LogIndex)
+		assert.Equal(t, argUint64(1), receipts[0].Logs[1].LogIndex)
+		assert.Len(t, receipts[1].Logs, 1)
+		assert.Equal(t, argUint64(2), receipts[1].Logs[0].LogIndex)
+		assert.Equal(t, argUint64(1), receipts[1].Logs[0].TxIndex)
+	}
+
+	res, err := eth.GetBlockReceipts(BlockNumberOrHash{BlockHash: &emptyHash})
+	assert.NoError(t, err)
+	assert.Equal(t, []*receipt{}, res)
+
+	// the block doesn't exist
+	_, err = eth.GetBlockReceipts(BlockNumberOrHash{BlockHash: &hash1})
+	assert.Error(t, err)
+}
+
 func TestEth_Syncing(t *testing.T) {
 	store := newMockBlockStore()
 	eth := newTestEthEndpoint(store)
@@ -531,6 +764,15 @@ func (m *mockBlockStore) GetBlockByHash(hash types.Hash, full bool) (*types.Bloc
 	return nil, false
 }
 
+func (m *mockBlockStore) GetHeaderByNumber(blockNumber uint64) (*types.Header, bool) {
+	block, ok := m.GetBlockByNumber(blockNumber, false)
+	if !ok {
+		return nil, false
+	}
+
+	return block.Header, true
+}
+
 func (m *mockBlockStore) Header() *types.Header {
 	return m.blocks[len(m.blocks)-1].Header
 }
@@ -585,6 +827,10 @@ func (m *mockBlockStore) SubscribeEvents() blockchain.Subscription {
 	return nil
 }
 
+func (m *mockBlockStore) SubscribeTxEvents(eventTypes ...proto.EventType) (<-chan *proto.TxPoolEvent, func()) {
+	return nil, func() {}
+}
+
 func (m *mockBlockStore) FilterExtra(extra []byte) ([]byte, error) {
 	return extra, nil
 }
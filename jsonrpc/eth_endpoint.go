@@ -14,6 +14,9 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/precompiled"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/accesslisttracer"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -26,6 +29,9 @@ type ethTxPoolStore interface {
 
 	// GetNonce returns the next nonce for this address
 	GetNonce(addr types.Address) uint64
+
+	// AddBundle adds the transactions to the tx pool as an atomic bundle
+	AddBundle(txs []*types.Transaction, blockNumber, maxTimestamp uint64) (types.Hash, error)
 }
 
 type Account struct {
@@ -33,11 +39,31 @@ type Account struct {
 	Nonce   uint64
 }
 
+// AccountProof is the merkle proof of an account and of some of its storage slots
+type AccountProof struct {
+	Balance      *big.Int
+	Nonce        uint64
+	CodeHash     types.Hash
+	StorageHash  types.Hash
+	Proof        [][]byte
+	StorageProof []*StorageProof
+}
+
+// StorageProof is the merkle proof of a storage slot
+type StorageProof struct {
+	Key   types.Hash
+	Value types.Hash
+	Proof [][]byte
+}
+
 type ethStateStore interface {
 	GetAccount(root types.Hash, addr types.Address) (*Account, error)
 	GetStorage(root types.Hash, addr types.Address, slot types.Hash) ([]byte, error)
 	GetForksInTime(blockNumber uint64) chain.ForksInTime
 	GetCode(root types.Hash, addr types.Address) ([]byte, error)
+
+	// GetProof returns the merkle proofs of the account and of the storage slots
+	GetProof(root types.Hash, addr types.Address, slots []types.Hash) (*AccountProof, error)
 }
 
 type ethBlockchainStore interface {
@@ -67,6 +93,9 @@ type ethBlockchainStore interface {
 
 	// GetSyncProgression retrieves the current sync progression, if any
 	GetSyncProgression() *progress.Progression
+
+	// TraceCall traces a single call at the point when the given header is mined
+	TraceCall(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
 }
 
 type ethFilter interface {
@@ -96,6 +125,9 @@ var (
 	ErrInsufficientFunds = errors.New("insufficient funds for execution")
 )
 
+// ethProtocolVersion is the version of the ethereum wire protocol (eth/65) reported to the clients
+const ethProtocolVersion = 65
+
 // ChainId returns the chain id of the client
 //
 //nolint:stylecheck
@@ -106,12 +138,7 @@ func (e *Eth) ChainId() (interface{}, error) {
 func (e *Eth) Syncing() (interface{}, error) {
 	if syncProgression := e.store.GetSyncProgression(); syncProgression != nil {
 		// Node is bulk syncing, return the status
-		return progression{
-			Type:          string(syncProgression.SyncType),
-			StartingBlock: argUint64(syncProgression.StartingBlock),
-			CurrentBlock:  argUint64(syncProgression.CurrentBlock),
-			HighestBlock:  argUint64(syncProgression.HighestBlock),
-		}, nil
+		return toProgression(syncProgression), nil
 	}
 
 	// Node is not bulk syncing
@@ -183,6 +210,92 @@ func (e *Eth) GetBlockTransactionCountByNumber(number BlockNumber) (interface{},
 	return *types.EncodeUint64(uint64(len(block.Transactions))), nil
 }
 
+// GetBlockTransactionCountByHash returns the number of transactions in the block with the given hash
+func (e *Eth) GetBlockTransactionCountByHash(hash types.Hash) (interface{}, error) {
+	block, ok := e.store.GetBlockByHash(hash, true)
+	if !ok {
+		return nil, nil
+	}
+
+	return *types.EncodeUint64(uint64(len(block.Transactions))), nil
+}
+
+// GetTransactionByBlockNumberAndIndex returns the transaction at the index of the block with the given number
+func (e *Eth) GetTransactionByBlockNumberAndIndex(number BlockNumber, index argUint64) (interface{}, error) {
+	num, err := GetNumericBlockNumber(number, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := e.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, nil
+	}
+
+	if uint64(index) >= uint64(len(block.Transactions)) {
+		return nil, nil
+	}
+
+	return toBlockTransaction(block, int(index)), nil
+}
+
+// GetTransactionByBlockHashAndIndex returns the transaction at the index of the block with the given hash
+func (e *Eth) GetTransactionByBlockHashAndIndex(hash types.Hash, index argUint64) (interface{}, error) {
+	block, ok := e.store.GetBlockByHash(hash, true)
+	if !ok {
+		return nil, nil
+	}
+
+	if uint64(index) >= uint64(len(block.Transactions)) {
+		return nil, nil
+	}
+
+	return toBlockTransaction(block, int(index)), nil
+}
+
+// GetUncleByBlockNumberAndIndex returns the uncle of the block, which is always empty since there are no uncles
+func (e *Eth) GetUncleByBlockNumberAndIndex(number BlockNumber, index argUint64) (interface{}, error) {
+	return nil, nil
+}
+
+// GetUncleByBlockHashAndIndex returns the uncle of the block, which is always empty since there are no uncles
+func (e *Eth) GetUncleByBlockHashAndIndex(hash types.Hash, index argUint64) (interface{}, error) {
+	return nil, nil
+}
+
+// GetUncleCountByBlockNumber returns the number of uncles in the block with the given number, which is always 0
+func (e *Eth) GetUncleCountByBlockNumber(number BlockNumber) (interface{}, error) {
+	num, err := GetNumericBlockNumber(number, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := e.store.GetHeaderByNumber(num); !ok {
+		return nil, nil
+	}
+
+	return argUint64(0), nil
+}
+
+// GetUncleCountByBlockHash returns the number of uncles in the block with the given hash, which is always 0
+func (e *Eth) GetUncleCountByBlockHash(hash types.Hash) (interface{}, error) {
+	if _, ok := e.store.GetBlockByHash(hash, false); !ok {
+		return nil, nil
+	}
+
+	return argUint64(0), nil
+}
+
+// Accounts returns the accounts owned by the client, which is always empty since the node doesn't manage keys
+func (e *Eth) Accounts() (interface{}, error) {
+	return []types.Address{}, nil
+}
+
+// ProtocolVersion returns the version of the ethereum wire protocol implemented by the client
+func (e *Eth) ProtocolVersion() (interface{}, error) {
+	return argUint64(ethProtocolVersion), nil
+}
+
 // BlockNumber returns current block number
 func (e *Eth) BlockNumber() (interface{}, error) {
 	h := e.store.Header()
@@ -208,6 +321,42 @@ func (e *Eth) SendRawTransaction(buf argBytes) (interface{}, error) {
 	return tx.Hash.String(), nil
 }
 
+// bundleArgs are the arguments of eth_sendBundle
+type bundleArgs struct {
+	// Txs are the signed raw transactions, executed in the given order
+	Txs []argBytes `json:"txs"`
+	// BlockNumber is the only block the bundle can be included in, any of the next blocks if omitted
+	BlockNumber argUint64 `json:"blockNumber"`
+	// MaxTimestamp is the max timestamp of the block the bundle can be included in
+	MaxTimestamp argUint64 `json:"maxTimestamp"`
+}
+
+type sendBundleResponse struct {
+	BundleHash types.Hash `json:"bundleHash"`
+}
+
+// SendBundle sends signed transactions which are included contiguously in a block, or not at all.
+// The bundles are not gossiped, so only the validators accept them
+func (e *Eth) SendBundle(args *bundleArgs) (interface{}, error) {
+	txs := make([]*types.Transaction, len(args.Txs))
+
+	for i, buf := range args.Txs {
+		tx := &types.Transaction{}
+		if err := tx.UnmarshalRLP(buf); err != nil {
+			return nil, fmt.Errorf("invalid transaction %d: %w", i, err)
+		}
+
+		txs[i] = tx
+	}
+
+	hash, err := e.store.AddBundle(txs, uint64(args.BlockNumber), uint64(args.MaxTimestamp))
+	if err != nil {
+		return nil, err
+	}
+
+	return &sendBundleResponse{BundleHash: hash}, nil
+}
+
 // SendTransaction rejects eth_sendTransaction json-rpc call as we don't support wallet management
 func (e *Eth) SendTransaction(_ *txnArgs) (interface{}, error) {
 	return nil, fmt.Errorf("request calls to eth_sendTransaction method are not supported," +
@@ -328,37 +477,47 @@ func (e *Eth) GetTransactionReceipt(hash types.Hash) (interface{}, error) {
 		logIndex += len(receipts[i].Logs)
 	}
 
-	raw := receipts[txIndex]
-
-	logs := make([]*Log, len(raw.Logs))
-	for i, elem := range raw.Logs {
-		logs[i] = &Log{
-			Address:     elem.Address,
-			Topics:      elem.Topics,
-			Data:        argBytes(elem.Data),
-			BlockHash:   block.Hash(),
-			BlockNumber: argUint64(block.Number()),
-			TxHash:      hash,
-			TxIndex:     argUint64(txIndex),
-			LogIndex:    argUint64(logIndex + i),
-			Removed:     false,
-		}
+	return toReceipt(receipts[txIndex], txn, uint64(txIndex), block.Header, uint64(logIndex)), nil
+}
+
+// GetBlockReceipts returns the receipts of all the transactions in the block
+func (e *Eth) GetBlockReceipts(filter BlockNumberOrHash) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := e.store.GetBlockByHash(header.Hash, true)
+	if !ok {
+		return nil, nil
+	}
+
+	if len(block.Transactions) == 0 {
+		return []*receipt{}, nil
 	}
 
-	res := &receipt{
-		Root:              raw.Root,
-		CumulativeGasUsed: argUint64(raw.CumulativeGasUsed),
-		LogsBloom:         raw.LogsBloom,
-		Status:            argUint64(*raw.Status),
-		TxHash:            hash,
-		TxIndex:           argUint64(txIndex),
-		BlockHash:         block.Hash(),
-		BlockNumber:       argUint64(block.Number()),
-		GasUsed:           argUint64(raw.GasUsed),
-		ContractAddress:   raw.ContractAddress,
-		FromAddr:          txn.From,
-		ToAddr:            txn.To,
-		Logs:              logs,
+	receipts, err := e.store.GetReceiptsByHash(block.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(receipts) != len(block.Transactions) {
+		// Receipts not written yet on the db
+		e.logger.Warn(
+			fmt.Sprintf("No receipts found for block with hash [%s]", block.Hash().String()),
+		)
+
+		return nil, nil
+	}
+
+	var (
+		res      = make([]*receipt, len(receipts))
+		logIndex = uint64(0)
+	)
+
+	for i, txn := range block.Transactions {
+		res[i] = toReceipt(receipts[i], txn, uint64(i), block.Header, logIndex)
+		logIndex += uint64(len(receipts[i].Logs))
 	}
 
 	return res, nil
@@ -477,6 +636,60 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash, apiOverride *stateOve
 	return argBytesPtr(result.ReturnValue), nil
 }
 
+// CreateAccessList creates the access list of the given transaction
+// together with the gas used when the transaction is sent with that access list
+func (e *Eth) CreateAccessList(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := DecodeTxn(arg, header.Number, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction.Gas == 0 {
+		transaction.Gas = header.GasLimit
+	}
+
+	forks := e.store.GetForksInTime(header.Number)
+	precompiles := precompiled.NewPrecompiled().Addresses(&forks)
+
+	// the accessed slots and addresses can change with the access list (e.g. gas dependent branches),
+	// so the transaction is traced until the access list doesn't grow anymore
+	prevList := transaction.AccessList
+
+	for {
+		transaction.AccessList = prevList
+
+		res, err := e.store.TraceCall(transaction, header, accesslisttracer.NewAccessListTracer(precompiles, prevList))
+		if err != nil {
+			return nil, err
+		}
+
+		result, ok := res.(*accesslisttracer.Result)
+		if !ok {
+			return nil, fmt.Errorf("unexpected access list tracer result %T", res)
+		}
+
+		if len(result.AccessList) == len(prevList) && result.AccessList.StorageKeys() == prevList.StorageKeys() {
+			accessList := &accessListResult{
+				AccessList: result.AccessList,
+				GasUsed:    argUint64(result.GasUsed),
+			}
+
+			if result.Err != nil {
+				accessList.Error = result.Err.Error()
+			}
+
+			return accessList, nil
+		}
+
+		prevList = result.AccessList
+	}
+}
+
 // EstimateGas estimates the gas needed to execute a transaction
 func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error) {
 	number := LatestBlockNumber
@@ -757,6 +970,25 @@ func (e *Eth) GetCode(address types.Address, filter BlockNumberOrHash) (interfac
 	return argBytesPtr(code), nil
 }
 
+// GetProof returns the merkle proofs of the account and of its storage slots at the given block (EIP-1186)
+func (e *Eth) GetProof(
+	address types.Address,
+	storageKeys []types.Hash,
+	filter BlockNumberOrHash,
+) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := e.store.GetProof(header.StateRoot, address, storageKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAccountProofResult(address, proof), nil
+}
+
 // NewFilter creates a filter object, based on filter options, to notify when the state changes (logs).
 func (e *Eth) NewFilter(filter *LogQuery) (interface{}, error) {
 	return e.filterManager.NewLogFilter(filter, nil), nil
@@ -767,6 +999,11 @@ func (e *Eth) NewBlockFilter() (interface{}, error) {
 	return e.filterManager.NewBlockFilter(nil), nil
 }
 
+// NewPendingTransactionFilter creates a filter in the node, to notify when new transactions are promoted in the pool
+func (e *Eth) NewPendingTransactionFilter() (interface{}, error) {
+	return e.filterManager.NewPendingTxFilter(false, nil), nil
+}
+
 // GetFilterChanges is a polling method for a filter, which returns an array of logs which occurred since last poll.
 func (e *Eth) GetFilterChanges(id string) (interface{}, error) {
 	return e.filterManager.GetFilterChanges(id)
@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"encoding/json"
 	"errors"
 	"math/big"
 	"testing"
@@ -9,8 +10,11 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -575,6 +579,45 @@ func TestEth_State_GetStorageAt(t *testing.T) {
 	}
 }
 
+func TestEth_State_GetProof(t *testing.T) {
+	t.Parallel()
+
+	store := getExampleStore()
+	store.account.account.Nonce = 2
+	store.block.Header.StateRoot = types.StringToHash("0x2")
+
+	slot := types.StringToHash("0x3")
+	store.account.Storage(slot, []byte{0x1, 0x0})
+
+	eth := newTestEthEndpoint(store)
+
+	res, err := eth.GetProof(addr0, []types.Hash{slot}, BlockNumberOrHash{BlockHash: &hash1})
+	require.NoError(t, err)
+
+	data, err := json.Marshal(res)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"address": "`+addr0.String()+`",
+		"accountProof": ["0x0102"],
+		"balance": "0x64",
+		"codeHash": "`+types.EmptyCodeHash.String()+`",
+		"nonce": "0x2",
+		"storageHash": "`+types.EmptyRootHash.String()+`",
+		"storageProof": [{
+			"key": "`+slot.String()+`",
+			"value": "0x100",
+			"proof": ["0x0304", "0x05"]
+		}]
+	}`, string(data))
+
+	// the block doesn't exist
+	missing := types.StringToHash("0x4")
+
+	_, err = eth.GetProof(addr0, nil, BlockNumberOrHash{BlockHash: &missing})
+	require.Error(t, err)
+}
+
 func constructMockTx(gasLimit *argUint64, data *argBytes) *txnArgs {
 	return &txnArgs{
 		From:     &addr0,
@@ -742,6 +785,46 @@ func TestEth_EstimateGas_Reverts(t *testing.T) {
 	assert.ErrorAs(t, estimateErr, &revertReason)
 }
 
+func TestEth_CreateAccessList(t *testing.T) {
+	var (
+		slot1 = types.StringToHash("1")
+		slot2 = types.StringToHash("2")
+	)
+
+	store := getExampleStore()
+	ethEndpoint := newTestEthEndpoint(store)
+
+	traces := 0
+
+	// the second slot is accessed only when the first one is warm
+	store.traceCallHook = func(txn *types.Transaction, tracer tracer.Tracer) {
+		traces++
+
+		tracer.TxStart(txn.Gas)
+		tracer.CaptureState(nil, []*big.Int{new(big.Int).SetBytes(slot1.Bytes())}, evm.SLOAD, addr1, 1, nil, nil)
+
+		if len(txn.AccessList) > 0 {
+			tracer.CaptureState(nil, []*big.Int{new(big.Int).SetBytes(slot2.Bytes())}, evm.SLOAD, addr1, 1, nil, nil)
+		}
+
+		tracer.TxEnd(txn.Gas - 30000)
+	}
+
+	res, err := ethEndpoint.CreateAccessList(
+		constructMockTx(nil, nil),
+		BlockNumberOrHash{BlockHash: &hash1},
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, traces)
+	assert.Equal(t, &accessListResult{
+		AccessList: types.TxAccessList{
+			{Address: addr1, StorageKeys: []types.Hash{slot1, slot2}},
+		},
+		GasUsed: 30000,
+	}, res)
+}
+
 func TestEth_EstimateGas_Errors(t *testing.T) {
 	store := getExampleStore()
 	ethEndpoint := newTestEthEndpoint(store)
@@ -770,7 +853,8 @@ type mockSpecialStore struct {
 	account *mockAccount
 	block   *types.Block
 
-	applyTxnHook func(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error)
+	applyTxnHook  func(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error)
+	traceCallHook func(txn *types.Transaction, tracer tracer.Tracer)
 }
 
 func (m *mockSpecialStore) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
@@ -836,6 +920,31 @@ func (m *mockSpecialStore) GetCode(root types.Hash, addr types.Address) ([]byte,
 	return m.account.code, nil
 }
 
+func (m *mockSpecialStore) GetProof(root types.Hash, addr types.Address, slots []types.Hash) (*AccountProof, error) {
+	if root != m.block.Header.StateRoot {
+		return nil, ErrStateNotFound
+	}
+
+	proof := &AccountProof{
+		Balance:      m.account.account.Balance,
+		Nonce:        m.account.account.Nonce,
+		CodeHash:     types.EmptyCodeHash,
+		StorageHash:  types.EmptyRootHash,
+		Proof:        [][]byte{{0x1, 0x2}},
+		StorageProof: make([]*StorageProof, len(slots)),
+	}
+
+	for i, slot := range slots {
+		proof.StorageProof[i] = &StorageProof{
+			Key:   slot,
+			Value: types.BytesToHash(m.account.storage[slot]),
+			Proof: [][]byte{{0x3, 0x4}, {0x5}},
+		}
+	}
+
+	return proof, nil
+}
+
 func (m *mockSpecialStore) GetForksInTime(blockNumber uint64) chain.ForksInTime {
 	return chain.ForksInTime{}
 }
@@ -847,3 +956,15 @@ func (m *mockSpecialStore) ApplyTxn(header *types.Header, txn *types.Transaction
 
 	return &runtime.ExecutionResult{}, nil
 }
+
+func (m *mockSpecialStore) TraceCall(
+	txn *types.Transaction,
+	header *types.Header,
+	tracer tracer.Tracer,
+) (interface{}, error) {
+	if m.traceCallHook != nil {
+		m.traceCallHook(txn, tracer)
+	}
+
+	return tracer.GetResult()
+}
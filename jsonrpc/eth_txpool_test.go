@@ -6,6 +6,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEth_TxnPool_SendRawTransaction(t *testing.T) {
@@ -46,10 +47,42 @@ func TestEth_TxnPool_SendTransaction(t *testing.T) {
 	assert.NotEqual(t, store.txn.Hash, types.ZeroHash)
 }
 
+func TestEth_TxnPool_SendBundle(t *testing.T) {
+	store := &mockStoreTxn{}
+	eth := newTestEthEndpoint(store)
+
+	args := &bundleArgs{BlockNumber: argUint64(10), MaxTimestamp: argUint64(100)}
+
+	for i := uint64(0); i < 2; i++ {
+		args.Txs = append(args.Txs, (&types.Transaction{
+			From:  addr0,
+			Nonce: i,
+			V:     big.NewInt(1),
+		}).MarshalRLP())
+	}
+
+	res, err := eth.SendBundle(args)
+	require.NoError(t, err)
+
+	require.Len(t, store.bundle, 2)
+	assert.Equal(t, uint64(1), store.bundle[1].Nonce)
+	assert.Equal(t, uint64(10), store.bundleBlock)
+	assert.Equal(t, uint64(100), store.bundleMaxTimestamp)
+	assert.Equal(t, &sendBundleResponse{BundleHash: types.StringToHash("0x1")}, res)
+
+	// invalid raw transaction
+	_, err = eth.SendBundle(&bundleArgs{Txs: []argBytes{{0x1}}})
+	assert.Error(t, err)
+}
+
 type mockStoreTxn struct {
 	ethStore
 	accounts map[types.Address]*mockAccount
 	txn      *types.Transaction
+
+	bundle             []*types.Transaction
+	bundleBlock        uint64
+	bundleMaxTimestamp uint64
 }
 
 func (m *mockStoreTxn) AddTx(tx *types.Transaction) error {
@@ -60,6 +93,17 @@ func (m *mockStoreTxn) AddTx(tx *types.Transaction) error {
 	return nil
 }
 
+func (m *mockStoreTxn) AddBundle(
+	txs []*types.Transaction,
+	blockNumber, maxTimestamp uint64,
+) (types.Hash, error) {
+	m.bundle = txs
+	m.bundleBlock = blockNumber
+	m.bundleMaxTimestamp = maxTimestamp
+
+	return types.StringToHash("0x1"), nil
+}
+
 func (m *mockStoreTxn) GetNonce(addr types.Address) uint64 {
 	return 1
 }
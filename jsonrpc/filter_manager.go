@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
@@ -212,6 +214,76 @@ func (f *logFilter) sendUpdates() error {
 	return nil
 }
 
+// queueFilter is a base for the filters which store their updates in a queue
+type queueFilter struct {
+	filterBase
+	sync.Mutex
+
+	updates []interface{}
+}
+
+func newQueueFilter(ws wsConn) queueFilter {
+	return queueFilter{
+		filterBase: newFilterBase(ws),
+		updates:    make([]interface{}, 0),
+	}
+}
+
+// appendUpdate appends new update to updates
+func (f *queueFilter) appendUpdate(update interface{}) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.updates = append(f.updates, update)
+}
+
+// takeUpdates returns all saved updates in filter and set new update slice
+func (f *queueFilter) takeUpdates() []interface{} {
+	f.Lock()
+	defer f.Unlock()
+
+	updates := f.updates
+	f.updates = make([]interface{}, 0)
+
+	return updates
+}
+
+// getUpdates returns stored updates
+func (f *queueFilter) getUpdates() (interface{}, error) {
+	return f.takeUpdates(), nil
+}
+
+// sendUpdates writes stored updates to web socket stream
+func (f *queueFilter) sendUpdates() error {
+	updates := f.takeUpdates()
+
+	for _, update := range updates {
+		res, err := json.Marshal(update)
+		if err != nil {
+			return err
+		}
+
+		if err := f.writeMessageToWs(string(res)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pendingTxFilter is a filter to store the transactions promoted in the transaction pool
+type pendingTxFilter struct {
+	queueFilter
+
+	// fullTx indicates that the filter stores the transactions instead of their hashes
+	fullTx bool
+}
+
+// syncingFilter is a filter to store the changes of the sync status
+type syncingFilter struct {
+	queueFilter
+}
+
 // filterManagerStore provides methods required by FilterManager
 type filterManagerStore interface {
 	// Header returns the current header of the chain (genesis if empty)
@@ -228,6 +300,15 @@ type filterManagerStore interface {
 
 	// GetBlockByNumber returns a block using the provided number
 	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// GetPendingTx gets the pending transaction from the transaction pool, if it's present
+	GetPendingTx(txHash types.Hash) (*types.Transaction, bool)
+
+	// SubscribeTxEvents subscribes for the transaction pool events of the given types
+	SubscribeTxEvents(eventTypes ...proto.EventType) (<-chan *proto.TxPoolEvent, func())
+
+	// GetSyncProgression retrieves the current sync progression, if any
+	GetSyncProgression() *progress.Progression
 }
 
 // FilterManager manages all running filters
@@ -246,6 +327,13 @@ type FilterManager struct {
 	filters  map[string]filter
 	timeouts timeHeapImpl
 
+	// txEventsOnce starts the subscription to the transaction pool events with the first pending transaction filter
+	txEventsOnce sync.Once
+	pendingTxCh  chan types.Hash
+
+	// syncProgression is the last sync status appended to the syncing filters
+	syncProgression *progress.Progression
+
 	updateCh chan struct{}
 	closeCh  chan struct{}
 }
@@ -258,6 +346,7 @@ func NewFilterManager(logger hclog.Logger, store filterManagerStore, blockRangeL
 		blockRangeLimit: blockRangeLimit,
 		filters:         make(map[string]filter),
 		timeouts:        timeHeapImpl{},
+		pendingTxCh:     make(chan types.Hash),
 		updateCh:        make(chan struct{}),
 		closeCh:         make(chan struct{}),
 	}
@@ -309,6 +398,12 @@ func (f *FilterManager) Run() {
 				f.logger.Error("failed to dispatch event", "err", err)
 			}
 
+		case txHash := <-f.pendingTxCh:
+			// new transaction promoted in the transaction pool
+			if err := f.dispatchPendingTx(txHash); err != nil {
+				f.logger.Error("failed to dispatch pending transaction", "err", err)
+			}
+
 		case <-timeoutCh:
 			// timeout for filter
 			// if filter still exists
@@ -333,30 +428,84 @@ func (f *FilterManager) Close() {
 
 // NewBlockFilter adds new BlockFilter
 func (f *FilterManager) NewBlockFilter(ws wsConn) string {
-	filter := &blockFilter{
+	return f.addFilter(f.newBlockFilter(ws))
+}
+
+// newBlockFilter creates a BlockFilter starting from the current head
+func (f *FilterManager) newBlockFilter(ws wsConn) *blockFilter {
+	return &blockFilter{
 		filterBase: newFilterBase(ws),
 		block:      f.blockStream.getHead(),
 	}
-
-	if filter.hasWSConn() {
-		ws.SetFilterID(filter.id)
-	}
-
-	return f.addFilter(filter)
 }
 
 // NewLogFilter adds new LogFilter
 func (f *FilterManager) NewLogFilter(logQuery *LogQuery, ws wsConn) string {
-	filter := &logFilter{
+	return f.addFilter(newLogFilter(logQuery, ws))
+}
+
+// newLogFilter creates a LogFilter for the given query
+func newLogFilter(logQuery *LogQuery, ws wsConn) *logFilter {
+	return &logFilter{
 		filterBase: newFilterBase(ws),
 		query:      logQuery,
 	}
+}
 
-	if filter.hasWSConn() {
-		ws.SetFilterID(filter.id)
+// NewPendingTxFilter adds new PendingTxFilter
+func (f *FilterManager) NewPendingTxFilter(fullTx bool, ws wsConn) string {
+	return f.addFilter(f.newPendingTxFilter(fullTx, ws))
+}
+
+// newPendingTxFilter creates a PendingTxFilter, subscribing for the transaction pool events if needed
+func (f *FilterManager) newPendingTxFilter(fullTx bool, ws wsConn) *pendingTxFilter {
+	f.txEventsOnce.Do(f.subscribeTxEvents)
+
+	return &pendingTxFilter{
+		queueFilter: newQueueFilter(ws),
+		fullTx:      fullTx,
 	}
+}
+
+// NewSyncingFilter adds new SyncingFilter
+func (f *FilterManager) NewSyncingFilter(ws wsConn) string {
+	return f.addFilter(newSyncingFilter(ws))
+}
 
-	return f.addFilter(filter)
+// newSyncingFilter creates a SyncingFilter
+func newSyncingFilter(ws wsConn) *syncingFilter {
+	return &syncingFilter{
+		queueFilter: newQueueFilter(ws),
+	}
+}
+
+// subscribeTxEvents subscribes for the transactions promoted in the transaction pool
+// and forwards their hashes to the worker until the filter manager is closed
+func (f *FilterManager) subscribeTxEvents() {
+	eventCh, cancel := f.store.SubscribeTxEvents(proto.EventType_PROMOTED)
+
+	go func() {
+		defer cancel()
+
+		for {
+			select {
+			case event, ok := <-eventCh:
+				if !ok {
+					// the transaction pool is closed
+					return
+				}
+
+				select {
+				case f.pendingTxCh <- types.StringToHash(event.TxHash):
+				case <-f.closeCh:
+					return
+				}
+
+			case <-f.closeCh:
+				return
+			}
+		}
+	}()
 }
 
 // Exists checks the filter with given ID exists
@@ -558,6 +707,19 @@ func (f *FilterManager) removeFilterByID(id string) bool {
 	return true
 }
 
+// wsFilterCount returns the number of filters of the given WS [NOT Thread Safe]
+func (f *FilterManager) wsFilterCount(ws wsConn) uint64 {
+	count := uint64(0)
+
+	for _, filter := range f.filters {
+		if filter.getFilterBase().ws == ws {
+			count++
+		}
+	}
+
+	return count
+}
+
 // RemoveFilterByWs removes the filter with given WS [Thread safe]
 func (f *FilterManager) RemoveFilterByWs(ws wsConn) {
 	f.Lock()
@@ -584,12 +746,32 @@ func (f *FilterManager) addFilter(filter filter) string {
 	f.Lock()
 	defer f.Unlock()
 
+	return f.insertFilter(filter)
+}
+
+// addWSFilter adds the given filter of a WS unless the WS already has maxFilters filters,
+// the value of 0 disables the limit. It returns false if the filter is not added [Thread safe]
+func (f *FilterManager) addWSFilter(filter filter, maxFilters uint64) (string, bool) {
+	f.Lock()
+	defer f.Unlock()
+
+	if maxFilters != 0 && f.wsFilterCount(filter.getFilterBase().ws) >= maxFilters {
+		return "", false
+	}
+
+	return f.insertFilter(filter), true
+}
+
+// insertFilter adds given filter to list and heap [NOT Thread Safe]
+func (f *FilterManager) insertFilter(filter filter) string {
 	base := filter.getFilterBase()
 
 	f.filters[base.id] = filter
 
-	// Set timeout and add to heap if filter doesn't have web socket connection
-	if !filter.hasWSConn() {
+	if filter.hasWSConn() {
+		base.ws.SetFilterID(base.id)
+	} else {
+		// Set timeout and add to heap if filter doesn't have web socket connection
 		f.addFilterTimeout(base)
 	}
 
@@ -649,6 +831,92 @@ func (f *FilterManager) processEvent(evnt *blockchain.Event) {
 			f.logger.Error(fmt.Sprintf("Unable to process block, %v", processErr))
 		}
 	}
+
+	// the sync progression is updated by the new blocks
+	f.appendSyncingToFilters()
+}
+
+// dispatchPendingTx is an event handler for new transaction promoted in the transaction pool
+func (f *FilterManager) dispatchPendingTx(txHash types.Hash) error {
+	f.appendPendingTxToFilters(txHash)
+
+	// send data to web socket stream
+	return f.flushWsFilters()
+}
+
+// appendPendingTxToFilters makes each PendingTxFilter append the transaction
+func (f *FilterManager) appendPendingTxToFilters(txHash types.Hash) {
+	f.RLock()
+	defer f.RUnlock()
+
+	// the transaction is fetched once, only if there is any filter which needs it
+	var tx *transaction
+
+	for _, filter := range f.filters {
+		txFilter, ok := filter.(*pendingTxFilter)
+		if !ok {
+			continue
+		}
+
+		if !txFilter.fullTx {
+			txFilter.appendUpdate(txHash)
+
+			continue
+		}
+
+		if tx == nil {
+			pendingTx, found := f.store.GetPendingTx(txHash)
+			if !found {
+				// the transaction has already left the pool
+				continue
+			}
+
+			tx = toPendingTransaction(pendingTx)
+		}
+
+		txFilter.appendUpdate(tx)
+	}
+}
+
+// appendSyncingToFilters makes each SyncingFilter append the sync status if it has changed [NOT Thread Safe]
+func (f *FilterManager) appendSyncingToFilters() {
+	syncingFilters := make([]*syncingFilter, 0)
+
+	for _, filter := range f.filters {
+		if syncFilter, ok := filter.(*syncingFilter); ok {
+			syncingFilters = append(syncingFilters, syncFilter)
+		}
+	}
+
+	if len(syncingFilters) == 0 {
+		return
+	}
+
+	var current *progress.Progression
+	if syncProgression := f.store.GetSyncProgression(); syncProgression != nil {
+		progressionCopy := *syncProgression
+		current = &progressionCopy
+	}
+
+	if current == nil && f.syncProgression == nil ||
+		current != nil && f.syncProgression != nil && *current == *f.syncProgression {
+		// no changes
+		return
+	}
+
+	f.syncProgression = current
+
+	var update interface{} = false
+	if current != nil {
+		update = &syncingResult{
+			Syncing: true,
+			Status:  toProgression(current),
+		}
+	}
+
+	for _, filter := range syncingFilters {
+		filter.appendUpdate(update)
+	}
 }
 
 // appendLogsToFilters makes each LogFilters append logs in the header
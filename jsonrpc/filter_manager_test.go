@@ -2,6 +2,7 @@ package jsonrpc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -12,6 +13,8 @@ import (
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-hclog"
@@ -546,6 +549,142 @@ func TestFilterWebsocket(t *testing.T) {
 	}
 }
 
+// readWsResult waits for the next subscription message and returns its result
+func readWsResult(t *testing.T, msgCh <-chan []byte) json.RawMessage {
+	t.Helper()
+
+	var msg struct {
+		Params struct {
+			Result json.RawMessage `json:"result"`
+		} `json:"params"`
+	}
+
+	select {
+	case data := <-msgCh:
+		require.NoError(t, json.Unmarshal(data, &msg))
+	case <-time.After(2 * time.Second):
+		t.Fatal("subscription message not received")
+	}
+
+	return msg.Params.Result
+}
+
+func TestFilterPendingTx(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	tx := &types.Transaction{
+		Nonce:    1,
+		GasPrice: big.NewInt(10),
+		Value:    big.NewInt(0),
+		V:        big.NewInt(0),
+		R:        big.NewInt(0),
+		S:        big.NewInt(0),
+		Hash:     hash1,
+	}
+	store.pendingTxs[tx.Hash] = tx
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	go m.Run()
+
+	hashWs, hashMsgCh := newMockWsConnWithMsgCh()
+	txWs, txMsgCh := newMockWsConnWithMsgCh()
+
+	m.NewPendingTxFilter(false, hashWs)
+	m.NewPendingTxFilter(true, txWs)
+	id := m.NewPendingTxFilter(false, nil)
+
+	store.txEvents <- &proto.TxPoolEvent{
+		Type:   proto.EventType_PROMOTED,
+		TxHash: tx.Hash.String(),
+	}
+
+	var hash types.Hash
+
+	require.NoError(t, json.Unmarshal(readWsResult(t, hashMsgCh), &hash))
+	assert.Equal(t, tx.Hash, hash)
+
+	var result transaction
+
+	require.NoError(t, json.Unmarshal(readWsResult(t, txMsgCh), &result))
+	assert.Equal(t, tx.Hash, result.Hash)
+	assert.Equal(t, argUint64(tx.Nonce), result.Nonce)
+
+	// the http filter returns the hashes on polling
+	changes, err := m.GetFilterChanges(id)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{tx.Hash}, changes)
+
+	changes, err = m.GetFilterChanges(id)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestFilterSyncing(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	go m.Run()
+
+	ws, msgCh := newMockWsConnWithMsgCh()
+	m.NewSyncingFilter(ws)
+
+	emitBlock := func(number uint64) {
+		store.emitEvent(&mockEvent{
+			NewChain: []*mockHeader{
+				{
+					header: &types.Header{
+						Number: number,
+						Hash:   types.StringToHash(strconv.FormatUint(number, 10)),
+					},
+				},
+			},
+		})
+	}
+
+	store.setSyncProgression(&progress.Progression{
+		SyncType:      progress.ChainSyncBulk,
+		StartingBlock: 1,
+		CurrentBlock:  2,
+		HighestBlock:  10,
+	})
+	emitBlock(2)
+
+	var status syncingResult
+
+	require.NoError(t, json.Unmarshal(readWsResult(t, msgCh), &status))
+	assert.Equal(t, syncingResult{
+		Syncing: true,
+		Status: progression{
+			Type:          string(progress.ChainSyncBulk),
+			StartingBlock: 1,
+			CurrentBlock:  2,
+			HighestBlock:  10,
+		},
+	}, status)
+
+	// the status is sent only when it changes
+	emitBlock(3)
+
+	select {
+	case <-msgCh:
+		t.Fatal("unexpected message for an unchanged sync status")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	store.setSyncProgression(nil)
+	emitBlock(4)
+
+	assert.JSONEq(t, "false", string(readWsResult(t, msgCh)))
+}
+
 type mockWsConn struct {
 	SetFilterIDFn  func(string)
 	GetFilterIDFn  func() string
@@ -238,6 +238,10 @@ func DecodeTxn(arg *txnArgs, blockNumber uint64, store nonceGetter) (*types.Tran
 		txn.To = arg.To
 	}
 
+	if arg.AccessList != nil {
+		txn.AccessList = *arg.AccessList
+	}
+
 	txn.ComputeHash(blockNumber)
 
 	return txn, nil
@@ -40,6 +40,7 @@ type JSONRPC struct {
 	logger     hclog.Logger
 	config     *Config
 	dispatcher dispatcher
+	limiter    *rateLimiter
 }
 
 type dispatcher interface {
@@ -57,6 +58,7 @@ type JSONRPCStore interface {
 	filterManagerStore
 	bridgeStore
 	debugStore
+	traceStore
 }
 
 type Config struct {
@@ -68,6 +70,14 @@ type Config struct {
 	PriceLimit               uint64
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+	RateLimit                *RateLimitConfig
+
+	// Namespaces are the exposed namespaces, all of them are exposed if it's empty
+	Namespaces []string
+	// AllowedMethods are exposed even if their namespace is not
+	AllowedMethods []string
+	// DeniedMethods are not exposed even if their namespace is
+	DeniedMethods []string
 }
 
 // NewJSONRPC returns the JSONRPC http server
@@ -81,6 +91,10 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 			priceLimit:              config.PriceLimit,
 			jsonRPCBatchLengthLimit: config.BatchLengthLimit,
 			blockRangeLimit:         config.BlockRangeLimit,
+			maxSubscriptionsPerConn: config.RateLimit.maxSubscriptionsPerConn(),
+			namespaces:              config.Namespaces,
+			allowedMethods:          config.AllowedMethods,
+			deniedMethods:           config.DeniedMethods,
 		},
 	)
 
@@ -92,6 +106,7 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 		logger:     logger.Named("jsonrpc"),
 		config:     config,
 		dispatcher: d,
+		limiter:    newRateLimiter(config.RateLimit),
 	}
 
 	// start http server
@@ -211,6 +226,16 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 	// CORS rule - Allow requests from anywhere
 	wsUpgrader.CheckOrigin = func(r *http.Request) bool { return true }
 
+	if !j.limiter.addWSConnection() {
+		http.Error(w, "too many websocket connections", http.StatusServiceUnavailable)
+
+		return
+	}
+
+	defer j.limiter.removeWSConnection()
+
+	client := j.limiter.clientID(req)
+
 	// Upgrade the connection to a WS one
 	ws, err := wsUpgrader.Upgrade(w, req, nil)
 	if err != nil {
@@ -256,6 +281,16 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 
 		if isSupportedWSType(msgType) {
 			go func() {
+				release, limitErr := j.limiter.limit(client, message)
+				if limitErr != nil {
+					resp, _ := NewRPCResponse(nil, "2.0", nil, limitErr).Bytes()
+					_ = wrapConn.WriteMessage(msgType, resp)
+
+					return
+				}
+
+				defer release()
+
 				resp, handleErr := j.dispatcher.HandleWs(message, wrapConn)
 				if handleErr != nil {
 					j.logger.Error(fmt.Sprintf("Unable to handle WS request, %s", handleErr.Error()))
@@ -303,6 +338,18 @@ func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request)
 	// log request
 	j.logger.Debug("handle", "request", string(data))
 
+	release, limitErr := j.limiter.limit(j.limiter.clientID(req), data)
+	if limitErr != nil {
+		resp, _ := NewRPCResponse(nil, "2.0", nil, limitErr).Bytes()
+
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write(resp)
+
+		return
+	}
+
+	defer release()
+
 	resp, err := j.dispatcher.Handle(data)
 
 	if err != nil {
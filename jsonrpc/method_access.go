@@ -0,0 +1,72 @@
+package jsonrpc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// methodAccess defines which of the registered methods are exposed by the dispatcher
+type methodAccess struct {
+	// namespaces are the enabled namespaces, nil enables all of them
+	namespaces map[string]struct{}
+	// allowed are the methods enabled even if their namespace is disabled
+	allowed map[string]struct{}
+	// denied are the methods disabled even if their namespace is enabled
+	denied map[string]struct{}
+}
+
+// newMethodAccess builds the method access from the lists of namespaces and methods,
+// the namespaces must be registered in the service map
+func newMethodAccess(
+	serviceMap map[string]*serviceData,
+	namespaces, allowedMethods, deniedMethods []string,
+) (*methodAccess, error) {
+	access := &methodAccess{
+		allowed: toStringSet(allowedMethods),
+		denied:  toStringSet(deniedMethods),
+	}
+
+	if len(namespaces) == 0 {
+		return access, nil
+	}
+
+	for _, namespace := range namespaces {
+		if _, ok := serviceMap[namespace]; !ok {
+			return nil, fmt.Errorf("jsonrpc: unknown namespace '%s'", namespace)
+		}
+	}
+
+	access.namespaces = toStringSet(namespaces)
+
+	return access, nil
+}
+
+// isAvailable returns true if the method is exposed
+func (a *methodAccess) isAvailable(method string) bool {
+	if _, ok := a.denied[method]; ok {
+		return false
+	}
+
+	if _, ok := a.allowed[method]; ok {
+		return true
+	}
+
+	if a.namespaces == nil {
+		return true
+	}
+
+	namespace, _, _ := strings.Cut(method, "_")
+	_, ok := a.namespaces[namespace]
+
+	return ok
+}
+
+func toStringSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+
+	return set
+}
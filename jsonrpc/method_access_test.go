@@ -0,0 +1,82 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodAccess_IsAvailable(t *testing.T) {
+	t.Parallel()
+
+	serviceMap := map[string]*serviceData{
+		"eth":   {},
+		"debug": {},
+	}
+
+	cases := []struct {
+		name       string
+		namespaces []string
+		allowed    []string
+		denied     []string
+		available  map[string]bool
+	}{
+		{
+			name: "all namespaces by default",
+			available: map[string]bool{
+				"eth_call":               true,
+				"debug_traceTransaction": true,
+			},
+		},
+		{
+			name:       "disabled namespace",
+			namespaces: []string{"eth"},
+			available: map[string]bool{
+				"eth_call":               true,
+				"eth_subscribe":          true,
+				"debug_traceTransaction": false,
+			},
+		},
+		{
+			name:       "allowed method of a disabled namespace",
+			namespaces: []string{"eth"},
+			allowed:    []string{"debug_traceTransaction"},
+			available: map[string]bool{
+				"debug_traceTransaction": true,
+				"debug_traceBlock":       false,
+			},
+		},
+		{
+			name:   "denied method of an enabled namespace",
+			denied: []string{"eth_call", "eth_subscribe"},
+			available: map[string]bool{
+				"eth_call":      false,
+				"eth_subscribe": false,
+				"eth_chainId":   true,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			access, err := newMethodAccess(serviceMap, c.namespaces, c.allowed, c.denied)
+			require.NoError(t, err)
+
+			for method, available := range c.available {
+				assert.Equal(t, available, access.isAvailable(method), method)
+			}
+		})
+	}
+}
+
+func TestMethodAccess_UnknownNamespace(t *testing.T) {
+	t.Parallel()
+
+	_, err := newMethodAccess(map[string]*serviceData{"eth": {}}, []string{"eth", "admin"}, nil, nil)
+	require.ErrorContains(t, err, "unknown namespace 'admin'")
+}
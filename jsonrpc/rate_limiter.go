@@ -0,0 +1,294 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+)
+
+const (
+	// rateLimitCleanupInterval is the interval between the removals of the idle clients
+	rateLimitCleanupInterval = time.Minute
+
+	// apiKeyClientPrefix distinguishes the clients identified by API key from the IP addresses
+	apiKeyClientPrefix = "key:"
+)
+
+// RateLimitConfig defines the limits applied to the clients of the JSON-RPC server.
+// The zero value of each limit disables it
+type RateLimitConfig struct {
+	// RequestsPerSecond is the number of cost units a client can spend per second
+	RequestsPerSecond float64
+	// Burst is the max number of cost units a client can spend at once
+	Burst uint64
+	// MethodCosts is the cost weight of the methods, the methods which are not present cost 1.
+	// The methods with a cost greater than 1 are considered expensive
+	MethodCosts map[string]uint64
+	// MaxConcurrentExpensive is the max number of requests with expensive calls executed at the same time
+	MaxConcurrentExpensive uint64
+	// MaxWSConnections is the max number of open web socket connections
+	MaxWSConnections uint64
+	// MaxSubscriptionsPerConn is the max number of subscriptions of a web socket connection
+	MaxSubscriptionsPerConn uint64
+	// APIKeyHeader is the HTTP header with the API key identifying the client,
+	// the clients without it are identified by their IP address
+	APIKeyHeader string
+	// APIKeys are the accepted API keys, the clients with any other key are identified by their IP address
+	APIKeys []string
+}
+
+func (c *RateLimitConfig) maxSubscriptionsPerConn() uint64 {
+	if c == nil {
+		return 0
+	}
+
+	return c.MaxSubscriptionsPerConn
+}
+
+// tokenBucket is the budget of a client, refilled over time
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// rateLimiter applies the RateLimitConfig limits to the requests of the clients
+type rateLimiter struct {
+	config RateLimitConfig
+	burst  float64
+
+	// apiKeys is the set of the accepted API keys
+	apiKeys map[string]struct{}
+
+	lock          sync.Mutex
+	buckets       map[string]*tokenBucket
+	lastCleanup   time.Time
+	wsConnections uint64
+
+	// expensiveCalls holds a slot for each request with expensive calls being executed
+	expensiveCalls chan struct{}
+
+	now func() time.Time
+}
+
+func newRateLimiter(config *RateLimitConfig) *rateLimiter {
+	l := &rateLimiter{
+		buckets: make(map[string]*tokenBucket),
+		apiKeys: make(map[string]struct{}),
+		now:     time.Now,
+	}
+
+	if config == nil {
+		return l
+	}
+
+	l.config = *config
+
+	for _, apiKey := range config.APIKeys {
+		if apiKey != "" {
+			l.apiKeys[apiKey] = struct{}{}
+		}
+	}
+
+	// the burst is at least the budget of one second
+	l.burst = math.Max(float64(config.Burst), math.Ceil(config.RequestsPerSecond))
+
+	if config.MaxConcurrentExpensive > 0 {
+		l.expensiveCalls = make(chan struct{}, config.MaxConcurrentExpensive)
+	}
+
+	return l
+}
+
+// enabled returns true if any of the request limits is set
+func (l *rateLimiter) enabled() bool {
+	return l.config.RequestsPerSecond > 0 || l.expensiveCalls != nil
+}
+
+// clientID identifies the client of the request by its API key, if it's an accepted one, or by its IP address.
+// The unknown keys are ignored, otherwise any client could get a new budget by sending a new key
+func (l *rateLimiter) clientID(req *http.Request) string {
+	if l.config.APIKeyHeader != "" {
+		apiKey := req.Header.Get(l.config.APIKeyHeader)
+		if _, ok := l.apiKeys[apiKey]; ok {
+			return apiKeyClientPrefix + apiKey
+		}
+	}
+
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+
+	return host
+}
+
+// methodCost returns the cost weight of the method
+func (l *rateLimiter) methodCost(method string) uint64 {
+	if cost, ok := l.config.MethodCosts[method]; ok {
+		return cost
+	}
+
+	return 1
+}
+
+// limit checks the limits for the single or batch request of the client.
+// The returned function must be called once the request is handled
+func (l *rateLimiter) limit(client string, reqBody []byte) (func(), Error) {
+	if !l.enabled() {
+		return func() {}, nil
+	}
+
+	var (
+		cost      uint64
+		expensive bool
+	)
+
+	methods := requestMethods(reqBody)
+	if len(methods) == 0 {
+		// the malformed request is charged as a single call
+		cost = 1
+	}
+
+	for _, method := range methods {
+		methodCost := l.methodCost(method)
+
+		cost += methodCost
+		expensive = expensive || methodCost > 1
+	}
+
+	if !l.allow(client, cost) {
+		metrics.IncrCounter([]string{jsonRPCMetric, "rate_limit_exceeded"}, 1)
+
+		return nil, NewLimitExceededError("request rate limit exceeded")
+	}
+
+	if !expensive || l.expensiveCalls == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.expensiveCalls <- struct{}{}:
+		metrics.SetGauge([]string{jsonRPCMetric, "expensive_calls"}, float32(len(l.expensiveCalls)))
+	default:
+		metrics.IncrCounter([]string{jsonRPCMetric, "expensive_limit_exceeded"}, 1)
+
+		return nil, NewLimitExceededError("too many concurrent expensive requests")
+	}
+
+	return func() {
+		<-l.expensiveCalls
+		metrics.SetGauge([]string{jsonRPCMetric, "expensive_calls"}, float32(len(l.expensiveCalls)))
+	}, nil
+}
+
+// allow spends the cost from the budget of the client, if it's enough
+func (l *rateLimiter) allow(client string, cost uint64) bool {
+	if l.config.RequestsPerSecond <= 0 {
+		return true
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+
+	if now.Sub(l.lastCleanup) > rateLimitCleanupInterval {
+		l.removeIdleClients(now)
+	}
+
+	bucket, ok := l.buckets[client]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.burst, updated: now}
+		l.buckets[client] = bucket
+	} else {
+		bucket.tokens = l.refill(bucket, now)
+		bucket.updated = now
+	}
+
+	if bucket.tokens < float64(cost) {
+		return false
+	}
+
+	bucket.tokens -= float64(cost)
+
+	return true
+}
+
+// refill returns the tokens of the bucket at the given time
+func (l *rateLimiter) refill(bucket *tokenBucket, now time.Time) float64 {
+	elapsed := now.Sub(bucket.updated).Seconds()
+
+	return math.Min(l.burst, bucket.tokens+elapsed*l.config.RequestsPerSecond)
+}
+
+// removeIdleClients removes the buckets which have been refilled completely [NOT Thread Safe]
+func (l *rateLimiter) removeIdleClients(now time.Time) {
+	for client, bucket := range l.buckets {
+		if l.refill(bucket, now) >= l.burst {
+			delete(l.buckets, client)
+		}
+	}
+
+	l.lastCleanup = now
+
+	metrics.SetGauge([]string{jsonRPCMetric, "rate_limit_clients"}, float32(len(l.buckets)))
+}
+
+// addWSConnection registers a new web socket connection, if the limit is not reached
+func (l *rateLimiter) addWSConnection() bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.config.MaxWSConnections > 0 && l.wsConnections >= l.config.MaxWSConnections {
+		metrics.IncrCounter([]string{jsonRPCMetric, "ws_connections_rejected"}, 1)
+
+		return false
+	}
+
+	l.wsConnections++
+	metrics.SetGauge([]string{jsonRPCMetric, "ws_connections"}, float32(l.wsConnections))
+
+	return true
+}
+
+// removeWSConnection unregisters a closed web socket connection
+func (l *rateLimiter) removeWSConnection() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.wsConnections--
+	metrics.SetGauge([]string{jsonRPCMetric, "ws_connections"}, float32(l.wsConnections))
+}
+
+// requestMethods returns the methods of the single or batch request.
+// The malformed requests are left to the dispatcher, which rejects them
+func requestMethods(reqBody []byte) []string {
+	reqBody = bytes.TrimLeft(reqBody, " \t\r\n")
+
+	if len(reqBody) > 0 && reqBody[0] == openSquareBracket {
+		var batchReq BatchRequest
+		if err := json.Unmarshal(reqBody, &batchReq); err != nil {
+			return nil
+		}
+
+		methods := make([]string, len(batchReq))
+		for i, req := range batchReq {
+			methods[i] = req.Method
+		}
+
+		return methods
+	}
+
+	var req Request
+	if err := json.Unmarshal(reqBody, &req); err != nil {
+		return nil
+	}
+
+	return []string{req.Method}
+}
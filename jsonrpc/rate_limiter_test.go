@@ -0,0 +1,162 @@
+package jsonrpc
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_Disabled(t *testing.T) {
+	t.Parallel()
+
+	l := newRateLimiter(nil)
+
+	for i := 0; i < 100; i++ {
+		release, err := l.limit("client", []byte(`{"method": "eth_getLogs"}`))
+		require.Nil(t, err)
+
+		release()
+	}
+
+	assert.True(t, l.addWSConnection())
+}
+
+func TestRateLimiter_RequestsPerSecond(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	l := newRateLimiter(&RateLimitConfig{
+		RequestsPerSecond: 2,
+		Burst:             4,
+		MethodCosts: map[string]uint64{
+			"eth_getLogs": 3,
+		},
+	})
+	l.now = func() time.Time { return now }
+
+	limit := func(client string, reqBody string) Error {
+		t.Helper()
+
+		_, err := l.limit(client, []byte(reqBody))
+
+		return err
+	}
+
+	// the burst allows 4 calls at once
+	for i := 0; i < 4; i++ {
+		require.Nil(t, limit("client", `{"method": "eth_blockNumber"}`))
+	}
+
+	err := limit("client", `{"method": "eth_blockNumber"}`)
+	require.NotNil(t, err)
+	assert.Equal(t, -32005, err.ErrorCode())
+
+	// the budget of each client is independent
+	require.Nil(t, limit("other", `[{"method": "eth_blockNumber"}, {"method": "eth_getLogs"}]`))
+	require.NotNil(t, limit("other", `{"method": "eth_blockNumber"}`))
+
+	// the budget is refilled over time
+	now = now.Add(time.Second)
+
+	require.NotNil(t, limit("client", `{"method": "eth_getLogs"}`))
+	require.Nil(t, limit("client", `{"method": "eth_blockNumber"}`))
+
+	now = now.Add(time.Minute)
+
+	require.Nil(t, limit("client", `{"method": "eth_getLogs"}`))
+
+	// the idle clients are removed
+	assert.Len(t, l.buckets, 1)
+}
+
+func TestRateLimiter_MaxConcurrentExpensive(t *testing.T) {
+	t.Parallel()
+
+	l := newRateLimiter(&RateLimitConfig{
+		MethodCosts: map[string]uint64{
+			"debug_traceBlock": 10,
+		},
+		MaxConcurrentExpensive: 1,
+	})
+
+	release, err := l.limit("client", []byte(`{"method": "debug_traceBlock"}`))
+	require.Nil(t, err)
+
+	// the cheap calls are not limited
+	_, err = l.limit("client", []byte(`{"method": "eth_blockNumber"}`))
+	require.Nil(t, err)
+
+	_, err = l.limit("other", []byte(`[{"method": "eth_blockNumber"}, {"method": "debug_traceBlock"}]`))
+	require.NotNil(t, err)
+
+	release()
+
+	_, err = l.limit("other", []byte(`{"method": "debug_traceBlock"}`))
+	require.Nil(t, err)
+}
+
+func TestRateLimiter_MaxWSConnections(t *testing.T) {
+	t.Parallel()
+
+	l := newRateLimiter(&RateLimitConfig{
+		MaxWSConnections: 2,
+	})
+
+	require.True(t, l.addWSConnection())
+	require.True(t, l.addWSConnection())
+	require.False(t, l.addWSConnection())
+
+	l.removeWSConnection()
+
+	require.True(t, l.addWSConnection())
+}
+
+func TestRateLimiter_ClientID(t *testing.T) {
+	t.Parallel()
+
+	l := newRateLimiter(&RateLimitConfig{
+		APIKeyHeader: "X-Api-Key",
+		APIKeys:      []string{"secret"},
+	})
+
+	req, err := http.NewRequest(http.MethodPost, "/", nil)
+	require.NoError(t, err)
+
+	req.RemoteAddr = "10.0.0.1:5555"
+	assert.Equal(t, "10.0.0.1", l.clientID(req))
+
+	req.Header.Set("X-Api-Key", "secret")
+	assert.Equal(t, apiKeyClientPrefix+"secret", l.clientID(req))
+
+	// the unknown keys are charged to the IP address
+	req.Header.Set("X-Api-Key", "unknown")
+	assert.Equal(t, "10.0.0.1", l.clientID(req))
+}
+
+func TestRequestMethods(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		reqBody string
+		methods []string
+	}{
+		{"single", `{"method": "eth_call"}`, []string{"eth_call"}},
+		{"batch", ` [{"method": "eth_call"}, {"method": "eth_getLogs"}]`, []string{"eth_call", "eth_getLogs"}},
+		{"malformed", `{"method": `, nil},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, c.methods, requestMethods([]byte(c.reqBody)))
+		})
+	}
+}
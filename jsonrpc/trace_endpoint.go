@@ -0,0 +1,352 @@
+package jsonrpc
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/paritytracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// traceTypeTrace is the trace type of trace_replayBlockTransactions returning the flat traces
+	traceTypeTrace = "trace"
+)
+
+var (
+	// ErrUnsupportedTraceType is an error returned when the requested trace type is not supported
+	ErrUnsupportedTraceType = errors.New("unsupported trace type")
+	// ErrUnexpectedTraceResult is an error returned when the tracer returns an unknown result
+	ErrUnexpectedTraceResult = errors.New("unexpected trace result")
+)
+
+type traceStore interface {
+	// Header returns the current header of the chain (genesis if empty)
+	Header() *types.Header
+
+	// GetHeaderByNumber gets a header using the provided number
+	GetHeaderByNumber(uint64) (*types.Header, bool)
+
+	// ReadTxLookup returns a block hash in which a given txn was mined
+	ReadTxLookup(txnHash types.Hash) (types.Hash, bool)
+
+	// GetBlockByHash gets a block using the provided hash
+	GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool)
+
+	// GetBlockByNumber gets a block using the provided height
+	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// TraceBlock traces all transactions in the given block
+	TraceBlock(*types.Block, tracer.Tracer) ([]interface{}, error)
+
+	// TraceTxn traces a transaction in the block, associated with the given hash
+	TraceTxn(*types.Block, types.Hash, tracer.Tracer) (interface{}, error)
+}
+
+// Trace is the trace jsonrpc endpoint, returning the traces in the Parity/OpenEthereum format
+type Trace struct {
+	store           traceStore
+	blockRangeLimit uint64
+}
+
+// TraceResult is a flat trace with the information of the transaction it belongs to
+type TraceResult struct {
+	*paritytracer.Trace
+
+	BlockHash           types.Hash `json:"blockHash"`
+	BlockNumber         uint64     `json:"blockNumber"`
+	TransactionHash     types.Hash `json:"transactionHash"`
+	TransactionPosition uint64     `json:"transactionPosition"`
+}
+
+// ReplayResult is the result of the transaction replayed by trace_replayBlockTransactions
+type ReplayResult struct {
+	Output          argBytes              `json:"output"`
+	StateDiff       interface{}           `json:"stateDiff"`
+	Trace           []*paritytracer.Trace `json:"trace"`
+	VMTrace         interface{}           `json:"vmTrace"`
+	TransactionHash types.Hash            `json:"transactionHash"`
+}
+
+// TraceFilterRequest is the filter of trace_filter
+type TraceFilterRequest struct {
+	FromBlock   *BlockNumber    `json:"fromBlock"`
+	ToBlock     *BlockNumber    `json:"toBlock"`
+	FromAddress []types.Address `json:"fromAddress"`
+	ToAddress   []types.Address `json:"toAddress"`
+	After       *uint64         `json:"after"`
+	Count       *uint64         `json:"count"`
+}
+
+// matches returns true if the trace satisfies the address filters
+func (f *TraceFilterRequest) matches(trace *paritytracer.Trace) bool {
+	return containsAddress(f.FromAddress, trace.From()) && containsAddress(f.ToAddress, trace.To())
+}
+
+// containsAddress returns true if the address is in the list or the list is empty
+func containsAddress(addresses []types.Address, addr *types.Address) bool {
+	if len(addresses) == 0 {
+		return true
+	}
+
+	if addr == nil {
+		return false
+	}
+
+	for _, a := range addresses {
+		if a == *addr {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Block returns the traces of all transactions in the given block
+func (t *Trace) Block(blockNumber BlockNumber) (interface{}, error) {
+	num, err := GetNumericBlockNumber(blockNumber, t.store)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := t.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, fmt.Errorf("block %d not found", num)
+	}
+
+	if block.Number() == 0 {
+		return nil, ErrTraceGenesisBlock
+	}
+
+	blockTraces, err := t.traceBlock(block, defaultTraceTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*TraceResult, 0)
+
+	for idx, txTraces := range blockTraces {
+		results = append(results, toTraceResults(txTraces, block, idx)...)
+	}
+
+	return results, nil
+}
+
+// Transaction returns the traces of the given transaction
+func (t *Trace) Transaction(txHash types.Hash) (interface{}, error) {
+	tx, block := GetTxAndBlockByTxHash(txHash, t.store)
+	if tx == nil {
+		return nil, fmt.Errorf("tx %s not found", txHash.String())
+	}
+
+	if block.Number() == 0 {
+		return nil, ErrTraceGenesisBlock
+	}
+
+	tracer := paritytracer.NewParityTracer()
+
+	cancel := startTraceTimeout(tracer, defaultTraceTimeout)
+	defer cancel()
+
+	res, err := t.store.TraceTxn(block, tx.Hash, tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	txTraces, ok := res.([]*paritytracer.Trace)
+	if !ok {
+		return nil, ErrUnexpectedTraceResult
+	}
+
+	_, idx := types.FindTxByHash(block.Transactions, tx.Hash)
+
+	return toTraceResults(txTraces, block, idx), nil
+}
+
+// ReplayBlockTransactions replays all transactions in the given block and returns the requested traces
+func (t *Trace) ReplayBlockTransactions(blockNumber BlockNumber, traceTypes []string) (interface{}, error) {
+	for _, traceType := range traceTypes {
+		if traceType != traceTypeTrace {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedTraceType, traceType)
+		}
+	}
+
+	num, err := GetNumericBlockNumber(blockNumber, t.store)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := t.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, fmt.Errorf("block %d not found", num)
+	}
+
+	if block.Number() == 0 {
+		return nil, ErrTraceGenesisBlock
+	}
+
+	blockTraces, err := t.traceBlock(block, defaultTraceTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*ReplayResult, len(blockTraces))
+
+	for idx, txTraces := range blockTraces {
+		results[idx] = &ReplayResult{
+			TransactionHash: block.Transactions[idx].Hash,
+			Output:          argBytes{},
+		}
+
+		if len(traceTypes) > 0 {
+			results[idx].Trace = txTraces
+		}
+
+		if len(txTraces) > 0 && txTraces[0].Result != nil {
+			output := txTraces[0].Result.Output
+			if txTraces[0].Type == paritytracer.TraceTypeCreate {
+				output = txTraces[0].Result.Code
+			}
+
+			if results[idx].Output, err = hex.DecodeHex(output); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// Filter returns the traces matching the given filter
+func (t *Trace) Filter(filter TraceFilterRequest) (interface{}, error) {
+	from, to := LatestBlockNumber, LatestBlockNumber
+
+	if filter.FromBlock != nil {
+		from = *filter.FromBlock
+	}
+
+	if filter.ToBlock != nil {
+		to = *filter.ToBlock
+	}
+
+	fromNum, err := GetNumericBlockNumber(from, t.store)
+	if err != nil {
+		return nil, err
+	}
+
+	toNum, err := GetNumericBlockNumber(to, t.store)
+	if err != nil {
+		return nil, err
+	}
+
+	if toNum < fromNum {
+		return nil, ErrIncorrectBlockRange
+	}
+
+	// if not disabled, avoid handling large block ranges
+	if t.blockRangeLimit != 0 && toNum-fromNum > t.blockRangeLimit {
+		return nil, ErrBlockRangeTooHigh
+	}
+
+	// genesis block can't be traced, skip it
+	if fromNum == 0 {
+		fromNum = 1
+	}
+
+	var (
+		results = make([]*TraceResult, 0)
+		skipped = uint64(0)
+		// the whole range is traced within the single timeout
+		deadline = time.Now().Add(defaultTraceTimeout)
+	)
+
+	for i := fromNum; i <= toNum; i++ {
+		block, ok := t.store.GetBlockByNumber(i, true)
+		if !ok {
+			break
+		}
+
+		if len(block.Transactions) == 0 {
+			continue
+		}
+
+		timeout := time.Until(deadline)
+		if timeout <= 0 {
+			return nil, ErrExecutionTimeout
+		}
+
+		blockTraces, err := t.traceBlock(block, timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		for idx, txTraces := range blockTraces {
+			for _, result := range toTraceResults(txTraces, block, idx) {
+				if !filter.matches(result.Trace) {
+					continue
+				}
+
+				if filter.After != nil && skipped < *filter.After {
+					skipped++
+
+					continue
+				}
+
+				results = append(results, result)
+
+				if filter.Count != nil && uint64(len(results)) >= *filter.Count {
+					return results, nil
+				}
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// traceBlock traces all transactions in the block within the timeout
+// and returns the flat traces of each transaction
+func (t *Trace) traceBlock(block *types.Block, timeout time.Duration) ([][]*paritytracer.Trace, error) {
+	tracer := paritytracer.NewParityTracer()
+
+	cancel := startTraceTimeout(tracer, timeout)
+	defer cancel()
+
+	results, err := t.store.TraceBlock(block, tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	blockTraces := make([][]*paritytracer.Trace, len(results))
+
+	for idx, res := range results {
+		txTraces, ok := res.([]*paritytracer.Trace)
+		if !ok {
+			return nil, ErrUnexpectedTraceResult
+		}
+
+		blockTraces[idx] = txTraces
+	}
+
+	return blockTraces, nil
+}
+
+// toTraceResults attaches the information of the transaction to its traces
+func toTraceResults(txTraces []*paritytracer.Trace, block *types.Block, txIndex int) []*TraceResult {
+	results := make([]*TraceResult, len(txTraces))
+
+	for i, trace := range txTraces {
+		results[i] = &TraceResult{
+			Trace:               trace,
+			BlockHash:           block.Hash(),
+			BlockNumber:         block.Number(),
+			TransactionHash:     block.Transactions[txIndex].Hash,
+			TransactionPosition: uint64(txIndex),
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,393 @@
+package jsonrpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/paritytracer"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testTraceFrom = types.StringToAddress("1")
+	testTraceTo   = types.StringToAddress("2")
+)
+
+func createTestTraceBlock(height uint64, txHashes ...types.Hash) *types.Block {
+	block := wrapHeaderWithTestBlock(createTestHeader(height))
+
+	for _, hash := range txHashes {
+		block.Transactions = append(block.Transactions, createTestTransaction(hash))
+	}
+
+	return block
+}
+
+func createTestParityTraces(from, to types.Address) []*paritytracer.Trace {
+	return []*paritytracer.Trace{
+		{
+			Type: paritytracer.TraceTypeCall,
+			Action: &paritytracer.Action{
+				CallType: "call",
+				From:     &from,
+				To:       &to,
+			},
+			Result: &paritytracer.Result{
+				GasUsed: "0x0",
+				Output:  "0x01",
+			},
+			TraceAddress: []int{},
+		},
+	}
+}
+
+func TestTraceBlockTraces(t *testing.T) {
+	t.Parallel()
+
+	block := createTestTraceBlock(10, testTxHash1)
+	traces := createTestParityTraces(testTraceFrom, testTraceTo)
+
+	tests := []struct {
+		name        string
+		blockNumber BlockNumber
+		store       *debugEndpointMockStore
+		result      interface{}
+		err         bool
+	}{
+		{
+			name:        "should return the traces of the block",
+			blockNumber: 10,
+			store: &debugEndpointMockStore{
+				getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+					assert.Equal(t, uint64(10), num)
+					assert.True(t, full)
+
+					return block, true
+				},
+				traceBlockFn: func(b *types.Block, tracer tracer.Tracer) ([]interface{}, error) {
+					assert.Equal(t, block, b)
+
+					return []interface{}{traces}, nil
+				},
+			},
+			result: []*TraceResult{
+				{
+					Trace:               traces[0],
+					BlockHash:           block.Hash(),
+					BlockNumber:         10,
+					TransactionHash:     testTxHash1,
+					TransactionPosition: 0,
+				},
+			},
+			err: false,
+		},
+		{
+			name:        "should return errTraceGenesisBlock for genesis block",
+			blockNumber: 0,
+			store: &debugEndpointMockStore{
+				getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+					return testGenesisBlock, true
+				},
+			},
+			result: nil,
+			err:    true,
+		},
+		{
+			name:        "should return error for unexpected trace result",
+			blockNumber: 10,
+			store: &debugEndpointMockStore{
+				getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+					return block, true
+				},
+				traceBlockFn: func(b *types.Block, tracer tracer.Tracer) ([]interface{}, error) {
+					return testTraceResults, nil
+				},
+			},
+			result: nil,
+			err:    true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			endpoint := &Trace{store: test.store}
+
+			res, err := endpoint.Block(test.blockNumber)
+
+			assert.Equal(t, test.result, res)
+
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTraceTransactionTraces(t *testing.T) {
+	t.Parallel()
+
+	testTxHash2 := types.BytesToHash([]byte{2})
+	block := createTestTraceBlock(10, testTxHash1, testTxHash2)
+	traces := createTestParityTraces(testTraceFrom, testTraceTo)
+
+	endpoint := &Trace{
+		store: &debugEndpointMockStore{
+			readTxLookupFn: func(hash types.Hash) (types.Hash, bool) {
+				assert.Equal(t, testTxHash2, hash)
+
+				return block.Hash(), true
+			},
+			getBlockByHashFn: func(hash types.Hash, full bool) (*types.Block, bool) {
+				assert.Equal(t, block.Hash(), hash)
+
+				return block, true
+			},
+			traceTxnFn: func(b *types.Block, txHash types.Hash, tracer tracer.Tracer) (interface{}, error) {
+				assert.Equal(t, testTxHash2, txHash)
+
+				return traces, nil
+			},
+		},
+	}
+
+	res, err := endpoint.Transaction(testTxHash2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*TraceResult{
+		{
+			Trace:               traces[0],
+			BlockHash:           block.Hash(),
+			BlockNumber:         10,
+			TransactionHash:     testTxHash2,
+			TransactionPosition: 1,
+		},
+	}, res)
+}
+
+func TestTraceReplayBlockTransactions(t *testing.T) {
+	t.Parallel()
+
+	block := createTestTraceBlock(10, testTxHash1)
+	traces := createTestParityTraces(testTraceFrom, testTraceTo)
+
+	store := &debugEndpointMockStore{
+		getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+			return block, true
+		},
+		traceBlockFn: func(b *types.Block, tracer tracer.Tracer) ([]interface{}, error) {
+			return []interface{}{traces}, nil
+		},
+	}
+
+	t.Run("should return the replayed transactions", func(t *testing.T) {
+		t.Parallel()
+
+		endpoint := &Trace{store: store}
+
+		res, err := endpoint.ReplayBlockTransactions(10, []string{"trace"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*ReplayResult{
+			{
+				Output:          argBytes{0x1},
+				Trace:           traces,
+				TransactionHash: testTxHash1,
+			},
+		}, res)
+	})
+
+	t.Run("should return error for unsupported trace type", func(t *testing.T) {
+		t.Parallel()
+
+		endpoint := &Trace{store: store}
+
+		res, err := endpoint.ReplayBlockTransactions(10, []string{"vmTrace"})
+
+		assert.Nil(t, res)
+		assert.ErrorIs(t, err, ErrUnsupportedTraceType)
+	})
+}
+
+func TestTraceFilter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		otherAddr = types.StringToAddress("3")
+		testHash2 = types.BytesToHash([]byte{2})
+		testHash3 = types.BytesToHash([]byte{3})
+
+		blocks = map[uint64]*types.Block{
+			1: createTestTraceBlock(1, testTxHash1),
+			2: createTestTraceBlock(2),
+			3: createTestTraceBlock(3, testHash2, testHash3),
+		}
+		traces = map[types.Hash][]*paritytracer.Trace{
+			testTxHash1: createTestParityTraces(testTraceFrom, testTraceTo),
+			testHash2:   createTestParityTraces(otherAddr, testTraceTo),
+			testHash3:   createTestParityTraces(testTraceFrom, otherAddr),
+		}
+	)
+
+	store := &debugEndpointMockStore{
+		headerFn: func() *types.Header {
+			return blocks[3].Header
+		},
+		getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+			block, ok := blocks[num]
+
+			return block, ok
+		},
+		traceBlockFn: func(block *types.Block, tracer tracer.Tracer) ([]interface{}, error) {
+			results := make([]interface{}, len(block.Transactions))
+			for i, tx := range block.Transactions {
+				results[i] = traces[tx.Hash]
+			}
+
+			return results, nil
+		},
+	}
+
+	blockNumPtr := func(num BlockNumber) *BlockNumber {
+		return &num
+	}
+
+	uint64Ptr := func(num uint64) *uint64 {
+		return &num
+	}
+
+	tests := []struct {
+		name            string
+		filter          TraceFilterRequest
+		blockRangeLimit uint64
+		txHashes        []types.Hash
+		err             error
+	}{
+		{
+			name:     "should return all traces",
+			filter:   TraceFilterRequest{FromBlock: blockNumPtr(EarliestBlockNumber)},
+			txHashes: []types.Hash{testTxHash1, testHash2, testHash3},
+		},
+		{
+			name: "should filter by from address",
+			filter: TraceFilterRequest{
+				FromBlock:   blockNumPtr(EarliestBlockNumber),
+				FromAddress: []types.Address{testTraceFrom},
+			},
+			txHashes: []types.Hash{testTxHash1, testHash3},
+		},
+		{
+			name: "should filter by to address",
+			filter: TraceFilterRequest{
+				FromBlock: blockNumPtr(1),
+				ToBlock:   blockNumPtr(3),
+				ToAddress: []types.Address{otherAddr},
+			},
+			txHashes: []types.Hash{testHash3},
+		},
+		{
+			name: "should apply after and count",
+			filter: TraceFilterRequest{
+				FromBlock: blockNumPtr(EarliestBlockNumber),
+				After:     uint64Ptr(1),
+				Count:     uint64Ptr(1),
+			},
+			txHashes: []types.Hash{testHash2},
+		},
+		{
+			name: "should return error for incorrect block range",
+			filter: TraceFilterRequest{
+				FromBlock: blockNumPtr(3),
+				ToBlock:   blockNumPtr(1),
+			},
+			err: ErrIncorrectBlockRange,
+		},
+		{
+			name:            "should return error for too high block range",
+			filter:          TraceFilterRequest{FromBlock: blockNumPtr(EarliestBlockNumber)},
+			blockRangeLimit: 2,
+			err:             ErrBlockRangeTooHigh,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			endpoint := &Trace{store, test.blockRangeLimit}
+
+			res, err := endpoint.Filter(test.filter)
+
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				assert.Nil(t, res)
+
+				return
+			}
+
+			assert.NoError(t, err)
+
+			results, ok := res.([]*TraceResult)
+			assert.True(t, ok)
+
+			txHashes := make([]types.Hash, len(results))
+			for i, result := range results {
+				txHashes[i] = result.TransactionHash
+			}
+
+			assert.Equal(t, test.txHashes, txHashes)
+		})
+	}
+}
+
+func TestTraceFilter_Timeout(t *testing.T) {
+	t.Parallel()
+
+	blocks := map[uint64]*types.Block{}
+	for i := uint64(1); i <= 3; i++ {
+		blocks[i] = createTestTraceBlock(i, types.BytesToHash([]byte{byte(i)}))
+	}
+
+	traced := 0
+
+	store := &debugEndpointMockStore{
+		headerFn: func() *types.Header {
+			return blocks[3].Header
+		},
+		getBlockByNumberFn: func(num uint64, full bool) (*types.Block, bool) {
+			block, ok := blocks[num]
+
+			return block, ok
+		},
+		traceBlockFn: func(block *types.Block, tracer tracer.Tracer) ([]interface{}, error) {
+			traced++
+
+			// each block takes more than half of the timeout
+			time.Sleep(defaultTraceTimeout * 3 / 5)
+
+			if _, err := tracer.GetResult(); err != nil {
+				return nil, err
+			}
+
+			return []interface{}{createTestParityTraces(testTraceFrom, testTraceTo)}, nil
+		},
+	}
+
+	endpoint := &Trace{store, 0}
+	from := BlockNumber(1)
+
+	res, err := endpoint.Filter(TraceFilterRequest{FromBlock: &from})
+
+	assert.ErrorIs(t, err, ErrExecutionTimeout)
+	assert.Nil(t, res)
+	assert.Equal(t, 2, traced)
+}
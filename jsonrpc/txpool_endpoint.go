@@ -1,7 +1,10 @@
 package jsonrpc
 
 import (
+	"bytes"
 	"fmt"
+	"math/big"
+	"sort"
 	"strconv"
 
 	"github.com/0xPolygon/polygon-edge/types"
@@ -12,11 +15,21 @@ type txPoolStore interface {
 	// GetTxs gets tx pool transactions currently pending for inclusion and currently queued for validation
 	GetTxs(inclQueued bool) (map[types.Address][]*types.Transaction, map[types.Address][]*types.Transaction)
 
+	// GetAccountTxs gets tx pool transactions of the account pending for inclusion and queued for validation
+	GetAccountTxs(addr types.Address) ([]*types.Transaction, []*types.Transaction)
+
 	// GetCapacity returns the current and max capacity of the pool in slots
 	GetCapacity() (uint64, uint64)
 
 	// GetBaseFee returns current base fee
 	GetBaseFee() uint64
+
+	// GetTxStatus returns the state of the transaction in the pool, false if it's unknown
+	GetTxStatus(hash types.Hash) (*TxStatus, bool)
+
+	// GetReplacementFees returns the min fee cap and tip cap of a transaction replacing
+	// the one of the account with the nonce, false if there's no such transaction
+	GetReplacementFees(addr types.Address, nonce uint64) (*big.Int, *big.Int, bool)
 }
 
 // TxPool is the txpool jsonrpc endpoint
@@ -29,6 +42,27 @@ type ContentResponse struct {
 	Queued  map[types.Address]map[uint64]*transaction `json:"queued"`
 }
 
+type ContentFromResponse struct {
+	Pending map[uint64]*transaction `json:"pending"`
+	Queued  map[uint64]*transaction `json:"queued"`
+}
+
+type ContentPageResponse struct {
+	ContentResponse
+	// TotalAccounts is the number of the accounts matching the filter
+	TotalAccounts uint64 `json:"totalAccounts"`
+}
+
+// contentFilter selects the page of the accounts returned by txpool_contentPage
+type contentFilter struct {
+	// Offset is the number of the accounts skipped, sorted by address
+	Offset argUint64 `json:"offset"`
+	// Limit is the max number of the accounts returned, it's capped to maxContentPageLimit
+	Limit argUint64 `json:"limit"`
+	// PendingOnly excludes the queued transactions and the accounts without pending transactions
+	PendingOnly bool `json:"pendingOnly"`
+}
+
 type InspectResponse struct {
 	Pending         map[string]map[string]string `json:"pending"`
 	Queued          map[string]map[string]string `json:"queued"`
@@ -37,10 +71,50 @@ type InspectResponse struct {
 }
 
 type StatusResponse struct {
+	Pending         uint64                          `json:"pending"`
+	Queued          uint64                          `json:"queued"`
+	BaseFee         argUint64                       `json:"baseFee"`
+	CurrentCapacity uint64                          `json:"currentCapacity"`
+	MaxCapacity     uint64                          `json:"maxCapacity"`
+	Accounts        map[types.Address]AccountStatus `json:"accounts"`
+}
+
+type AccountStatus struct {
 	Pending uint64 `json:"pending"`
 	Queued  uint64 `json:"queued"`
 }
 
+// TxStatus is the state of a transaction in the pool
+type TxStatus struct {
+	// State is either promoted, enqueued or dropped
+	State      string `json:"state"`
+	Demotions  uint64 `json:"demotions"`
+	DropReason string `json:"dropReason,omitempty"`
+}
+
+type ReplacementFeesResponse struct {
+	GasFeeCap argBig `json:"maxFeePerGas"`
+	GasTipCap argBig `json:"maxPriorityFeePerGas"`
+}
+
+const (
+	// defaultContentPageLimit is the number of the accounts returned by txpool_contentPage if not set
+	defaultContentPageLimit = 100
+
+	// maxContentPageLimit is the max number of the accounts returned by txpool_contentPage
+	maxContentPageLimit = 1000
+)
+
+func toTxPoolTransactions(txs []*types.Transaction) map[uint64]*transaction {
+	result := make(map[uint64]*transaction, len(txs))
+
+	for _, tx := range txs {
+		result[tx.Nonce] = toTransaction(tx, nil, &types.ZeroHash, nil)
+	}
+
+	return result
+}
+
 // Create response for txpool_content request.
 // See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_content.
 func (t *TxPool) Content() (interface{}, error) {
@@ -48,11 +122,7 @@ func (t *TxPool) Content() (interface{}, error) {
 		result := make(map[types.Address]map[uint64]*transaction, len(txMap))
 
 		for addr, txs := range txMap {
-			result[addr] = make(map[uint64]*transaction, len(txs))
-
-			for _, tx := range txs {
-				result[addr][tx.Nonce] = toTransaction(tx, nil, &types.ZeroHash, nil)
-			}
+			result[addr] = toTxPoolTransactions(txs)
 		}
 
 		return result
@@ -67,6 +137,78 @@ func (t *TxPool) Content() (interface{}, error) {
 	return resp, nil
 }
 
+// Create response for txpool_contentFrom request.
+// See https://geth.ethereum.org/docs/interacting-with-geth/rpc/ns-txpool#txpool-contentfrom.
+func (t *TxPool) ContentFrom(address types.Address) (interface{}, error) {
+	pendingTxs, queuedTxs := t.store.GetAccountTxs(address)
+	resp := ContentFromResponse{
+		Pending: toTxPoolTransactions(pendingTxs),
+		Queued:  toTxPoolTransactions(queuedTxs),
+	}
+
+	return resp, nil
+}
+
+// Create response for txpool_contentPage request, the content of the page
+// of the accounts selected by the filter
+func (t *TxPool) ContentPage(filter contentFilter) (interface{}, error) {
+	limit := uint64(filter.Limit)
+	if limit == 0 {
+		limit = defaultContentPageLimit
+	} else if limit > maxContentPageLimit {
+		limit = maxContentPageLimit
+	}
+
+	pendingTxs, queuedTxs := t.store.GetTxs(!filter.PendingOnly)
+
+	// the accounts are paginated in the order of their addresses
+	accounts := make([]types.Address, 0, len(pendingTxs)+len(queuedTxs))
+
+	for addr := range pendingTxs {
+		accounts = append(accounts, addr)
+	}
+
+	for addr := range queuedTxs {
+		if _, ok := pendingTxs[addr]; !ok {
+			accounts = append(accounts, addr)
+		}
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return bytes.Compare(accounts[i].Bytes(), accounts[j].Bytes()) < 0
+	})
+
+	resp := ContentPageResponse{
+		ContentResponse: ContentResponse{
+			Pending: make(map[types.Address]map[uint64]*transaction),
+			Queued:  make(map[types.Address]map[uint64]*transaction),
+		},
+		TotalAccounts: uint64(len(accounts)),
+	}
+
+	offset := uint64(filter.Offset)
+	if offset >= uint64(len(accounts)) {
+		return resp, nil
+	}
+
+	accounts = accounts[offset:]
+	if uint64(len(accounts)) > limit {
+		accounts = accounts[:limit]
+	}
+
+	for _, addr := range accounts {
+		if txs, ok := pendingTxs[addr]; ok {
+			resp.Pending[addr] = toTxPoolTransactions(txs)
+		}
+
+		if txs, ok := queuedTxs[addr]; ok {
+			resp.Queued[addr] = toTxPoolTransactions(txs)
+		}
+	}
+
+	return resp, nil
+}
+
 // Create response for txpool_inspect request.
 // See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_inspect.
 func (t *TxPool) Inspect() (interface{}, error) {
@@ -105,23 +247,76 @@ func (t *TxPool) Inspect() (interface{}, error) {
 // See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_status.
 func (t *TxPool) Status() (interface{}, error) {
 	pendingTxs, queuedTxs := t.store.GetTxs(true)
+	accounts := make(map[types.Address]AccountStatus, len(pendingTxs))
 
 	var pendingCount uint64
 
-	for _, t := range pendingTxs {
+	for addr, t := range pendingTxs {
 		pendingCount += uint64(len(t))
+
+		status := accounts[addr]
+		status.Pending = uint64(len(t))
+		accounts[addr] = status
 	}
 
 	var queuedCount uint64
 
-	for _, t := range queuedTxs {
+	for addr, t := range queuedTxs {
 		queuedCount += uint64(len(t))
+
+		status := accounts[addr]
+		status.Queued = uint64(len(t))
+		accounts[addr] = status
 	}
 
+	// get capacity of the TxPool
+	current, max := t.store.GetCapacity()
+
 	resp := StatusResponse{
-		Pending: pendingCount,
-		Queued:  queuedCount,
+		Pending:         pendingCount,
+		Queued:          queuedCount,
+		BaseFee:         argUint64(t.store.GetBaseFee()),
+		CurrentCapacity: current,
+		MaxCapacity:     max,
+		Accounts:        accounts,
+	}
+
+	return resp, nil
+}
+
+// Create response for txpool_statusFrom request, the number of
+// the pending and queued transactions of the account
+func (t *TxPool) StatusFrom(address types.Address) (interface{}, error) {
+	pendingTxs, queuedTxs := t.store.GetAccountTxs(address)
+	resp := AccountStatus{
+		Pending: uint64(len(pendingTxs)),
+		Queued:  uint64(len(queuedTxs)),
 	}
 
 	return resp, nil
 }
+
+// Create response for txpool_txStatus request, the state of the transaction in the pool,
+// the number of its demotions and the reason it was dropped. Returns nil if it's unknown
+func (t *TxPool) TxStatus(hash types.Hash) (interface{}, error) {
+	status, ok := t.store.GetTxStatus(hash)
+	if !ok {
+		return nil, nil
+	}
+
+	return status, nil
+}
+
+// Create response for txpool_replacementFees request, the min fees a transaction
+// replacing the one of the account with the given nonce has to pay
+func (t *TxPool) ReplacementFees(address types.Address, nonce argUint64) (interface{}, error) {
+	gasFeeCap, gasTipCap, ok := t.store.GetReplacementFees(address, uint64(nonce))
+	if !ok {
+		return nil, fmt.Errorf("no transaction with nonce %d of the account %s in the pool", nonce, address)
+	}
+
+	return ReplacementFeesResponse{
+		GasFeeCap: argBig(*gasFeeCap),
+		GasTipCap: argBig(*gasTipCap),
+	}, nil
+}
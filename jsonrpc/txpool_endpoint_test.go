@@ -8,6 +8,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/types"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestContentEndpoint(t *testing.T) {
@@ -261,7 +262,188 @@ func TestStatusEndpoint(t *testing.T) {
 
 		assert.Equal(t, uint64(3), response.Pending)
 		assert.Equal(t, uint64(2), response.Queued)
+		assert.Equal(t, AccountStatus{Pending: 2, Queued: 1}, response.Accounts[address1])
+		assert.Equal(t, AccountStatus{Pending: 1, Queued: 1}, response.Accounts[address2])
 	})
+
+	t.Run("returns base fee and capacity", func(t *testing.T) {
+		t.Parallel()
+
+		mockStore := newMockTxPoolStore()
+		mockStore.capacity = 10
+		mockStore.maxSlots = 1024
+		mockStore.baseFee = 1000
+		txPoolEndpoint := &TxPool{mockStore}
+
+		result, _ := txPoolEndpoint.Status()
+		//nolint:forcetypeassert
+		response := result.(StatusResponse)
+
+		assert.Equal(t, argUint64(1000), response.BaseFee)
+		assert.Equal(t, uint64(10), response.CurrentCapacity)
+		assert.Equal(t, uint64(1024), response.MaxCapacity)
+		assert.Empty(t, response.Accounts)
+	})
+}
+
+func TestStatusFromEndpoint(t *testing.T) {
+	t.Parallel()
+
+	mockStore := newMockTxPoolStore()
+	address1 := types.Address{0x1}
+	address2 := types.Address{0x2}
+	mockStore.pending[address1] = []*types.Transaction{
+		newTestTransaction(2, address1),
+		newTestTransaction(3, address1),
+	}
+	mockStore.queued[address1] = []*types.Transaction{newTestTransaction(5, address1)}
+	mockStore.pending[address2] = []*types.Transaction{newTestTransaction(1, address2)}
+	txPoolEndpoint := &TxPool{mockStore}
+
+	result, _ := txPoolEndpoint.StatusFrom(address1)
+	assert.Equal(t, AccountStatus{Pending: 2, Queued: 1}, result)
+
+	// the account without transactions
+	result, _ = txPoolEndpoint.StatusFrom(types.Address{0x3})
+	assert.Equal(t, AccountStatus{}, result)
+}
+
+func TestTxStatusEndpoint(t *testing.T) {
+	t.Parallel()
+
+	mockStore := newMockTxPoolStore()
+	dropped := &TxStatus{State: "dropped", Demotions: 3, DropReason: "expired"}
+	mockStore.statuses[types.StringToHash("1")] = dropped
+	txPoolEndpoint := &TxPool{mockStore}
+
+	result, err := txPoolEndpoint.TxStatus(types.StringToHash("1"))
+	require.NoError(t, err)
+	assert.Equal(t, dropped, result)
+
+	// the unknown transaction
+	result, err = txPoolEndpoint.TxStatus(types.StringToHash("2"))
+	require.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestReplacementFeesEndpoint(t *testing.T) {
+	t.Parallel()
+
+	mockStore := newMockTxPoolStore()
+	address1 := types.Address{0x1}
+	mockStore.pending[address1] = []*types.Transaction{newTestDynamicFeeTransaction(2, address1)}
+	txPoolEndpoint := &TxPool{mockStore}
+
+	result, err := txPoolEndpoint.ReplacementFees(address1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, ReplacementFeesResponse{
+		GasFeeCap: argBig(*big.NewInt(5)),
+		GasTipCap: argBig(*big.NewInt(3)),
+	}, result)
+
+	_, err = txPoolEndpoint.ReplacementFees(address1, 3)
+	assert.Error(t, err)
+}
+
+func TestContentFromEndpoint(t *testing.T) {
+	t.Parallel()
+
+	mockStore := newMockTxPoolStore()
+	address1 := types.Address{0x1}
+	testTx1 := newTestTransaction(2, address1)
+	testTx2 := newTestTransaction(5, address1)
+	address2 := types.Address{0x2}
+	mockStore.pending[address1] = []*types.Transaction{testTx1}
+	mockStore.queued[address1] = []*types.Transaction{testTx2}
+	mockStore.pending[address2] = []*types.Transaction{newTestTransaction(1, address2)}
+	txPoolEndpoint := &TxPool{mockStore}
+
+	result, _ := txPoolEndpoint.ContentFrom(address1)
+	//nolint:forcetypeassert
+	response := result.(ContentFromResponse)
+
+	assert.Len(t, response.Pending, 1)
+	assert.Len(t, response.Queued, 1)
+	assert.Equal(t, testTx1.Hash, response.Pending[testTx1.Nonce].Hash)
+	assert.Equal(t, testTx2.Hash, response.Queued[testTx2.Nonce].Hash)
+
+	// the account without transactions
+	result, _ = txPoolEndpoint.ContentFrom(types.Address{0x3})
+	//nolint:forcetypeassert
+	response = result.(ContentFromResponse)
+
+	assert.Empty(t, response.Pending)
+	assert.Empty(t, response.Queued)
+}
+
+func TestContentPageEndpoint(t *testing.T) {
+	t.Parallel()
+
+	mockStore := newMockTxPoolStore()
+
+	for i := byte(1); i <= 5; i++ {
+		addr := types.Address{i}
+		mockStore.pending[addr] = []*types.Transaction{newTestTransaction(1, addr)}
+	}
+
+	// the account with queued transactions only
+	queuedAddr := types.Address{0x6}
+	mockStore.queued[queuedAddr] = []*types.Transaction{newTestTransaction(3, queuedAddr)}
+
+	txPoolEndpoint := &TxPool{mockStore}
+
+	cases := []struct {
+		name     string
+		filter   contentFilter
+		total    uint64
+		accounts []types.Address
+	}{
+		{
+			"default limit",
+			contentFilter{},
+			6,
+			[]types.Address{{0x1}, {0x2}, {0x3}, {0x4}, {0x5}, {0x6}},
+		},
+		{
+			"offset and limit",
+			contentFilter{Offset: 2, Limit: 2},
+			6,
+			[]types.Address{{0x3}, {0x4}},
+		},
+		{
+			"offset out of range",
+			contentFilter{Offset: 10},
+			6,
+			nil,
+		},
+		{
+			"pending only",
+			contentFilter{Offset: 4, PendingOnly: true},
+			5,
+			[]types.Address{{0x5}},
+		},
+	}
+
+	for _, c := range cases {
+		result, _ := txPoolEndpoint.ContentPage(c.filter)
+		//nolint:forcetypeassert
+		response := result.(ContentPageResponse)
+
+		accounts := make([]types.Address, 0, len(c.accounts))
+
+		for _, addr := range c.accounts {
+			_, pending := response.Pending[addr]
+			_, queued := response.Queued[addr]
+
+			if pending || queued {
+				accounts = append(accounts, addr)
+			}
+		}
+
+		assert.Equal(t, c.total, response.TotalAccounts, c.name)
+		assert.Len(t, accounts, len(c.accounts), c.name)
+		assert.Equal(t, len(c.accounts), len(response.Pending)+len(response.Queued), c.name)
+	}
 }
 
 type mockTxPoolStore struct {
@@ -271,21 +453,31 @@ type mockTxPoolStore struct {
 	maxSlots      uint64
 	baseFee       uint64
 	includeQueued bool
+	statuses      map[types.Hash]*TxStatus
 }
 
 func newMockTxPoolStore() *mockTxPoolStore {
 	return &mockTxPoolStore{
-		pending: make(map[types.Address][]*types.Transaction),
-		queued:  make(map[types.Address][]*types.Transaction),
+		pending:  make(map[types.Address][]*types.Transaction),
+		queued:   make(map[types.Address][]*types.Transaction),
+		statuses: make(map[types.Hash]*TxStatus),
 	}
 }
 
 func (s *mockTxPoolStore) GetTxs(inclQueued bool) (map[types.Address][]*types.Transaction, map[types.Address][]*types.Transaction) {
 	s.includeQueued = inclQueued
 
+	if !inclQueued {
+		return s.pending, nil
+	}
+
 	return s.pending, s.queued
 }
 
+func (s *mockTxPoolStore) GetAccountTxs(addr types.Address) ([]*types.Transaction, []*types.Transaction) {
+	return s.pending[addr], s.queued[addr]
+}
+
 func (s *mockTxPoolStore) GetCapacity() (uint64, uint64) {
 	return s.capacity, s.maxSlots
 }
@@ -294,6 +486,24 @@ func (s *mockTxPoolStore) GetBaseFee() uint64 {
 	return s.baseFee
 }
 
+func (s *mockTxPoolStore) GetTxStatus(hash types.Hash) (*TxStatus, bool) {
+	status, ok := s.statuses[hash]
+
+	return status, ok
+}
+
+func (s *mockTxPoolStore) GetReplacementFees(addr types.Address, nonce uint64) (*big.Int, *big.Int, bool) {
+	for _, tx := range append(s.pending[addr], s.queued[addr]...) {
+		if tx.Nonce == nonce {
+			one := big.NewInt(1)
+
+			return new(big.Int).Add(tx.GetGasFeeCap(), one), new(big.Int).Add(tx.GetGasTipCap(), one), true
+		}
+	}
+
+	return nil, nil, false
+}
+
 func newTestTransaction(nonce uint64, from types.Address) *types.Transaction {
 	txn := &types.Transaction{
 		Nonce:    nonce,
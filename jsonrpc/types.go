@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -17,24 +18,29 @@ type transactionOrHash interface {
 }
 
 type transaction struct {
-	Nonce       argUint64      `json:"nonce"`
-	GasPrice    *argBig        `json:"gasPrice,omitempty"`
-	GasTipCap   *argBig        `json:"maxPriorityFeePerGas,omitempty"`
-	GasFeeCap   *argBig        `json:"maxFeePerGas,omitempty"`
-	Gas         argUint64      `json:"gas"`
-	To          *types.Address `json:"to"`
-	Value       argBig         `json:"value"`
-	Input       argBytes       `json:"input"`
-	V           argBig         `json:"v"`
-	R           argBig         `json:"r"`
-	S           argBig         `json:"s"`
-	Hash        types.Hash     `json:"hash"`
-	From        types.Address  `json:"from"`
-	BlockHash   *types.Hash    `json:"blockHash"`
-	BlockNumber *argUint64     `json:"blockNumber"`
-	TxIndex     *argUint64     `json:"transactionIndex"`
-	ChainID     *argBig        `json:"chainID,omitempty"`
-	Type        argUint64      `json:"type"`
+	Nonce       argUint64          `json:"nonce"`
+	GasPrice    *argBig            `json:"gasPrice,omitempty"`
+	GasTipCap   *argBig            `json:"maxPriorityFeePerGas,omitempty"`
+	GasFeeCap   *argBig            `json:"maxFeePerGas,omitempty"`
+	Gas         argUint64          `json:"gas"`
+	To          *types.Address     `json:"to"`
+	Value       argBig             `json:"value"`
+	Input       argBytes           `json:"input"`
+	V           argBig             `json:"v"`
+	R           argBig             `json:"r"`
+	S           argBig             `json:"s"`
+	Hash        types.Hash         `json:"hash"`
+	From        types.Address      `json:"from"`
+	BlockHash   *types.Hash        `json:"blockHash"`
+	BlockNumber *argUint64         `json:"blockNumber"`
+	TxIndex     *argUint64         `json:"transactionIndex"`
+	ChainID     *argBig            `json:"chainID,omitempty"`
+	Type        argUint64          `json:"type"`
+	AccessList  types.TxAccessList `json:"accessList,omitempty"`
+	Sponsor     *types.Address     `json:"sponsor,omitempty"`
+	SponsorV    *argBig            `json:"sponsorV,omitempty"`
+	SponsorR    *argBig            `json:"sponsorR,omitempty"`
+	SponsorS    *argBig            `json:"sponsorS,omitempty"`
 }
 
 func (t transaction) getHash() types.Hash { return t.Hash }
@@ -70,6 +76,7 @@ func toTransaction(
 		Hash:        t.Hash,
 		From:        t.From,
 		Type:        argUint64(t.Type),
+		AccessList:  t.AccessList,
 		BlockNumber: blockNumber,
 		BlockHash:   blockHash,
 	}
@@ -94,6 +101,15 @@ func toTransaction(
 		res.ChainID = &chainID
 	}
 
+	if t.IsSponsored() {
+		sponsor := t.Sponsor
+		res.Sponsor = &sponsor
+
+		res.SponsorV = argBigPtr(t.SponsorV)
+		res.SponsorR = argBigPtr(t.SponsorR)
+		res.SponsorS = argBigPtr(t.SponsorS)
+	}
+
 	if txIndex != nil {
 		res.TxIndex = argUintPtr(uint64(*txIndex))
 	}
@@ -138,6 +154,16 @@ func (b *block) Copy() *block {
 	return bb
 }
 
+// toBlockTransaction returns the sealed transaction at the index of the block
+func toBlockTransaction(b *types.Block, index int) *transaction {
+	return toTransaction(
+		b.Transactions[index],
+		argUintPtr(b.Number()),
+		argHashPtr(b.Hash()),
+		&index,
+	)
+}
+
 func toBlock(b *types.Block, fullTx bool) *block {
 	h := b.Header
 	res := &block{
@@ -206,6 +232,45 @@ type receipt struct {
 	ToAddr            *types.Address `json:"to"`
 }
 
+func toReceipt(
+	src *types.Receipt,
+	tx *types.Transaction,
+	txIndex uint64,
+	header *types.Header,
+	logIndex uint64,
+) *receipt {
+	logs := make([]*Log, len(src.Logs))
+	for i, elem := range src.Logs {
+		logs[i] = &Log{
+			Address:     elem.Address,
+			Topics:      elem.Topics,
+			Data:        argBytes(elem.Data),
+			BlockHash:   header.Hash,
+			BlockNumber: argUint64(header.Number),
+			TxHash:      tx.Hash,
+			TxIndex:     argUint64(txIndex),
+			LogIndex:    argUint64(logIndex + uint64(i)),
+			Removed:     false,
+		}
+	}
+
+	return &receipt{
+		Root:              src.Root,
+		CumulativeGasUsed: argUint64(src.CumulativeGasUsed),
+		LogsBloom:         src.LogsBloom,
+		Status:            argUint64(*src.Status),
+		TxHash:            tx.Hash,
+		TxIndex:           argUint64(txIndex),
+		BlockHash:         header.Hash,
+		BlockNumber:       argUint64(header.Number),
+		GasUsed:           argUint64(src.GasUsed),
+		ContractAddress:   src.ContractAddress,
+		FromAddr:          tx.From,
+		ToAddr:            tx.To,
+		Logs:              logs,
+	}
+}
+
 type Log struct {
 	Address     types.Address `json:"address"`
 	Topics      []types.Hash  `json:"topics"`
@@ -329,17 +394,71 @@ func encodeToHex(b []byte) []byte {
 
 // txnArgs is the transaction argument for the rpc endpoints
 type txnArgs struct {
-	From      *types.Address
-	To        *types.Address
-	Gas       *argUint64
-	GasPrice  *argBytes
-	GasTipCap *argBytes
-	GasFeeCap *argBytes
-	Value     *argBytes
-	Data      *argBytes
-	Input     *argBytes
-	Nonce     *argUint64
-	Type      *argUint64
+	From       *types.Address
+	To         *types.Address
+	Gas        *argUint64
+	GasPrice   *argBytes
+	GasTipCap  *argBytes
+	GasFeeCap  *argBytes
+	Value      *argBytes
+	Data       *argBytes
+	Input      *argBytes
+	Nonce      *argUint64
+	Type       *argUint64
+	AccessList *types.TxAccessList
+}
+
+type accessListResult struct {
+	AccessList types.TxAccessList `json:"accessList"`
+	GasUsed    argUint64          `json:"gasUsed"`
+	Error      string             `json:"error,omitempty"`
+}
+
+type storageProofResult struct {
+	Key   types.Hash `json:"key"`
+	Value argBig     `json:"value"`
+	Proof []argBytes `json:"proof"`
+}
+
+type accountProofResult struct {
+	Address      types.Address         `json:"address"`
+	AccountProof []argBytes            `json:"accountProof"`
+	Balance      argBig                `json:"balance"`
+	CodeHash     types.Hash            `json:"codeHash"`
+	Nonce        argUint64             `json:"nonce"`
+	StorageHash  types.Hash            `json:"storageHash"`
+	StorageProof []*storageProofResult `json:"storageProof"`
+}
+
+func toProofNodes(proof [][]byte) []argBytes {
+	nodes := make([]argBytes, len(proof))
+	for i, node := range proof {
+		nodes[i] = node
+	}
+
+	return nodes
+}
+
+func toAccountProofResult(addr types.Address, proof *AccountProof) *accountProofResult {
+	res := &accountProofResult{
+		Address:      addr,
+		AccountProof: toProofNodes(proof.Proof),
+		Balance:      argBig(*proof.Balance),
+		CodeHash:     proof.CodeHash,
+		Nonce:        argUint64(proof.Nonce),
+		StorageHash:  proof.StorageHash,
+		StorageProof: make([]*storageProofResult, len(proof.StorageProof)),
+	}
+
+	for i, storageProof := range proof.StorageProof {
+		res.StorageProof[i] = &storageProofResult{
+			Key:   storageProof.Key,
+			Value: argBig(*new(big.Int).SetBytes(storageProof.Value.Bytes())),
+			Proof: toProofNodes(storageProof.Proof),
+		}
+	}
+
+	return res
 }
 
 type progression struct {
@@ -349,6 +468,21 @@ type progression struct {
 	HighestBlock  argUint64 `json:"highestBlock"`
 }
 
+func toProgression(p *progress.Progression) progression {
+	return progression{
+		Type:          string(p.SyncType),
+		StartingBlock: argUint64(p.StartingBlock),
+		CurrentBlock:  argUint64(p.CurrentBlock),
+		HighestBlock:  argUint64(p.HighestBlock),
+	}
+}
+
+// syncingResult is the sync status sent to the syncing subscriptions
+type syncingResult struct {
+	Syncing bool        `json:"syncing"`
+	Status  progression `json:"status"`
+}
+
 type feeHistoryResult struct {
 	OldestBlock   argUint64     `json:"oldestBlock"`
 	BaseFeePerGas []argUint64   `json:"baseFeePerGas,omitempty"`
@@ -161,6 +161,33 @@ func TestToTransaction_EIP1559(t *testing.T) {
 	assert.Equal(t, hexWithoutLeading0, string(jsonS))
 }
 
+func TestToTransaction_Sponsored(t *testing.T) {
+	txn := &types.Transaction{
+		Type:     types.SponsoredTx,
+		GasPrice: big.NewInt(10),
+		Value:    big.NewInt(0),
+		V:        big.NewInt(1),
+		R:        big.NewInt(2),
+		S:        big.NewInt(3),
+		Sponsor:  types.StringToAddress("4"),
+		SponsorV: big.NewInt(0),
+		SponsorR: big.NewInt(5),
+		SponsorS: big.NewInt(6),
+	}
+
+	jsonTx := toTransaction(txn, nil, nil, nil)
+
+	require.NotNil(t, jsonTx.Sponsor)
+	assert.Equal(t, txn.Sponsor, *jsonTx.Sponsor)
+	assert.Equal(t, argBig(*txn.SponsorV), *jsonTx.SponsorV)
+	assert.Equal(t, argBig(*txn.SponsorR), *jsonTx.SponsorR)
+	assert.Equal(t, argBig(*txn.SponsorS), *jsonTx.SponsorS)
+
+	// the other transactions have no sponsor
+	txn.Type = types.LegacyTx
+	assert.Nil(t, toTransaction(txn, nil, nil, nil).Sponsor)
+}
+
 func TestBlock_Copy(t *testing.T) {
 	b := &block{
 		ExtraData: []byte{0x1},
@@ -294,4 +321,17 @@ func TestTransaction_Encoding(t *testing.T) {
 
 		testTransaction("testsuite/transaction-eip1559.json", tt)
 	})
+
+	t.Run("sponsored", func(t *testing.T) {
+		sponsor := types.StringToAddress("4")
+
+		tt := mockTxn()
+		tt.Type = argUint64(types.SponsoredTx)
+		tt.Sponsor = &sponsor
+		tt.SponsorV = argBigPtr(big.NewInt(1))
+		tt.SponsorR = argBigPtr(big.NewInt(4))
+		tt.SponsorS = argBigPtr(big.NewInt(5))
+
+		testTransaction("testsuite/transaction-sponsored.json", tt)
+	})
 }
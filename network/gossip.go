@@ -67,7 +67,26 @@ func (t *Topic) Publish(obj proto.Message) error {
 	return t.topic.Publish(context.Background(), data)
 }
 
+// ListPeers returns the connected peers subscribed to the topic
+func (t *Topic) ListPeers() []peer.ID {
+	return t.topic.ListPeers()
+}
+
 func (t *Topic) Subscribe(handler func(obj interface{}, from peer.ID)) error {
+	return t.subscribe(func(obj interface{}, msg *pubsub.Message) {
+		handler(obj, msg.GetFrom())
+	})
+}
+
+// SubscribeFromRelay subscribes to the topic like Subscribe, but the handler
+// gets the peer which relayed the message instead of the one which published it
+func (t *Topic) SubscribeFromRelay(handler func(obj interface{}, relay peer.ID)) error {
+	return t.subscribe(func(obj interface{}, msg *pubsub.Message) {
+		handler(obj, msg.ReceivedFrom)
+	})
+}
+
+func (t *Topic) subscribe(handler func(obj interface{}, msg *pubsub.Message)) error {
 	sub, err := t.topic.Subscribe(pubsub.WithBufferSize(subscribeOutputBufferSize))
 	if err != nil {
 		return err
@@ -81,7 +100,7 @@ func (t *Topic) Subscribe(handler func(obj interface{}, from peer.ID)) error {
 	return nil
 }
 
-func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{}, from peer.ID)) {
+func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{}, msg *pubsub.Message)) {
 	t.waitGroup.Add(1)
 	defer t.waitGroup.Done()
 
@@ -117,7 +136,7 @@ func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{},
 
 			metrics.SetGauge([]string{networkMetrics, "ingress_bytes"}, float32(len(msg.Data)))
 
-			handler(obj, msg.GetFrom())
+			handler(obj, msg)
 		}()
 	}
 }
@@ -118,3 +118,95 @@ func Test_RepeatedClose(t *testing.T) {
 	topic.Close()
 	topic.Close()
 }
+
+func TestSubscribeFromRelay(t *testing.T) {
+	// the servers are connected in line, the messages of the first one are relayed by the second one
+	noDiscoverParams := &CreateServerParams{
+		ConfigCallback: func(c *Config) {
+			c.NoDiscover = true
+		},
+	}
+
+	servers, createErr := createServers(3, map[int]*CreateServerParams{
+		0: noDiscoverParams,
+		1: noDiscoverParams,
+		2: noDiscoverParams,
+	})
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	for i := 0; i < len(servers)-1; i++ {
+		if joinErr := JoinAndWait(servers[i], servers[i+1], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+			t.Fatalf("Unable to join servers, %v", joinErr)
+		}
+	}
+
+	topicName := "msg-relay"
+	topics := make([]*Topic, len(servers))
+
+	for i, server := range servers {
+		topic, topicErr := server.NewTopic(topicName, &testproto.GenericMessage{})
+		if topicErr != nil {
+			t.Fatalf("Unable to create topic, %v", topicErr)
+		}
+
+		topics[i] = topic
+	}
+
+	publisherCh := make(chan peer.ID, 1)
+
+	if subscribeErr := topics[2].Subscribe(func(_ interface{}, from peer.ID) {
+		publisherCh <- from
+	}); subscribeErr != nil {
+		t.Fatalf("Unable to subscribe to topic, %v", subscribeErr)
+	}
+
+	relayCh := make(chan peer.ID, 1)
+
+	if subscribeErr := topics[2].SubscribeFromRelay(func(_ interface{}, relay peer.ID) {
+		relayCh <- relay
+	}); subscribeErr != nil {
+		t.Fatalf("Unable to subscribe to topic, %v", subscribeErr)
+	}
+
+	// the relay has to subscribe to forward the messages
+	if subscribeErr := topics[1].Subscribe(func(interface{}, peer.ID) {}); subscribeErr != nil {
+		t.Fatalf("Unable to subscribe to topic, %v", subscribeErr)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if waitErr := WaitForSubscribers(ctx, servers[1], topicName, 1); waitErr != nil {
+		t.Fatalf("Unable to wait for subscribers, %v", waitErr)
+	}
+
+	if waitErr := WaitForSubscribers(ctx, servers[0], topicName, 1); waitErr != nil {
+		t.Fatalf("Unable to wait for subscribers, %v", waitErr)
+	}
+
+	if publishErr := topics[0].Publish(&testproto.GenericMessage{Message: "relayed"}); publishErr != nil {
+		t.Fatalf("Unable to publish message, %v", publishErr)
+	}
+
+	expected := map[chan peer.ID]peer.ID{
+		publisherCh: servers[0].host.ID(),
+		relayCh:     servers[1].host.ID(),
+	}
+
+	for ch, expectedID := range expected {
+		select {
+		case id := <-ch:
+			if id != expectedID {
+				t.Fatalf("Expected message from %s, got %s", expectedID, id)
+			}
+		case <-time.After(15 * time.Second):
+			t.Fatalf("Message not received before timeout")
+		}
+	}
+}
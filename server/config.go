@@ -2,12 +2,15 @@ package server
 
 import (
 	"net"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/types"
 )
 
 const DefaultGRPCPort int = 9632
@@ -25,6 +28,40 @@ type Config struct {
 	MaxAccountEnqueued uint64
 	MaxSlots           uint64
 
+	// TxPoolJournal is the file of the txpool journal, relative to the data directory if not absolute
+	TxPoolJournal         string
+	TxPoolJournalRotation time.Duration
+	// TxPoolLifetime is the time after which the enqueued (and optionally promoted) transactions are evicted
+	TxPoolLifetime      time.Duration
+	TxPoolEvictPromoted bool
+
+	// PriorityAccounts are the accounts whose transactions are prioritized by the txpool
+	PriorityAccounts []types.Address
+
+	// TxOrdering is the policy the block builders order the txpool transactions by,
+	// the one of the genesis is used if it's empty
+	TxOrdering string
+	// MaxSenderTxs caps the transactions of a single account in a block, the genesis cap is used if it's 0
+	MaxSenderTxs uint64
+
+	// PruneRetain is the number of the most recent blocks whose state is kept, the pruning is disabled if it's 0
+	PruneRetain uint64
+	// PruneCheckpointInterval is the interval of the blocks whose state is never pruned
+	PruneCheckpointInterval uint64
+
+	// StorageBackend is the database engine of the blockchain and the trie stores, leveldb if it's empty
+	StorageBackend StorageBackend
+
+	// StateSnapshot enables the flat snapshot of the state serving the account and storage reads
+	StateSnapshot bool
+
+	// StateSync enables downloading the state of a recent block instead of executing the blocks from genesis
+	StateSync bool
+
+	// FreezerDepth is how deep below the head the blocks are moved from the database to the freezer,
+	// the freezer is disabled if it's 0
+	FreezerDepth uint64
+
 	Telemetry *Telemetry
 	Network   *network.Config
 
@@ -57,4 +94,12 @@ type JSONRPC struct {
 	AccessControlAllowOrigin []string
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+	RateLimit                *jsonrpc.RateLimitConfig
+	Namespaces               []string
+	AllowedMethods           []string
+	DeniedMethods            []string
+
+	// AdminAddr is the address of the listener serving the AdminNamespaces, it's disabled if nil
+	AdminAddr       *net.TCPAddr
+	AdminNamespaces []string
 }
@@ -10,11 +10,9 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 
-	"github.com/0xPolygon/polygon-edge/blockchain/storage"
-	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
-	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
 	consensusPolyBFT "github.com/0xPolygon/polygon-edge/consensus/polybft"
 	"github.com/0xPolygon/polygon-edge/forkmanager"
 	"github.com/0xPolygon/polygon-edge/gasprice"
@@ -72,6 +70,9 @@ type Server struct {
 	// jsonrpc stack
 	jsonrpcServer *jsonrpc.JSONRPC
 
+	// jsonrpcAdminServer serves the privileged namespaces
+	jsonrpcAdminServer *jsonrpc.JSONRPC
+
 	// system grpc server
 	grpcServer *grpc.Server
 
@@ -86,6 +87,13 @@ type Server struct {
 	// secrets manager
 	secretsManager secrets.SecretsManager
 
+	// stateSnapshot is the flat snapshot of the state, nil if it's disabled
+	stateSnapshot *itrie.FlatTree
+	// statePruner removes the state of the old blocks, nil if the pruning is disabled
+	statePruner *itrie.Pruner
+	// statePruningSub feeds the pruner with the state roots of the new blocks
+	statePruningSub blockchain.Subscription
+
 	// restore
 	restoreProgression *progress.ProgressionWrapper
 
@@ -154,16 +162,17 @@ func NewServer(config *Config) (*Server, error) {
 
 	m.logger.Info("Data dir", "path", config.DataDir)
 
-	var dirPaths = []string{
-		"blockchain",
-		"trie",
-	}
-
 	// Generate all the paths in the dataDir
-	if err := common.SetupDataDir(config.DataDir, dirPaths, 0770); err != nil {
+	if err := common.SetupDataDir(config.DataDir, StorageDatabases, 0770); err != nil {
 		return nil, fmt.Errorf("failed to create data directories: %w", err)
 	}
 
+	if config.DataDir != "" {
+		if err := checkStorageBackend(config.DataDir, config.StorageBackend); err != nil {
+			return nil, err
+		}
+	}
+
 	if config.Telemetry.PrometheusAddr != nil {
 		// Only setup telemetry if `PrometheusAddr` has been configured.
 		if err := m.setupTelemetry(); err != nil {
@@ -198,16 +207,39 @@ func NewServer(config *Config) (*Server, error) {
 	}
 
 	// start blockchain object
-	stateStorage, err := itrie.NewLevelDBStorage(filepath.Join(m.config.DataDir, "trie"), logger)
+	stateStorage, err := m.newStateStorage()
 	if err != nil {
 		return nil, err
 	}
 
+	if m.config.StateSnapshot {
+		// the flat entries aren't trie nodes, the snapshot bypasses the pruner
+		if m.stateSnapshot, err = itrie.NewFlatTree(stateStorage, logger); err != nil {
+			return nil, err
+		}
+	}
+
+	if m.config.PruneRetain > 0 {
+		m.statePruner, err = itrie.NewPruner(stateStorage, itrie.PrunerConfig{
+			Retain:             m.config.PruneRetain,
+			CheckpointInterval: m.config.PruneCheckpointInterval,
+		}, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		stateStorage = m.statePruner
+	}
+
 	m.stateStorage = stateStorage
 
 	st := itrie.NewState(stateStorage)
 	m.state = st
 
+	if m.stateSnapshot != nil {
+		st.SetFlatTree(m.stateSnapshot)
+	}
+
 	m.executor = state.NewExecutor(config.Chain.Params, st, logger)
 
 	// custom write genesis hook per consensus engine
@@ -299,22 +331,9 @@ func NewServer(config *Config) (*Server, error) {
 	)
 
 	// create storage instance for blockchain
-	var db storage.Storage
-	{
-		if m.config.DataDir == "" {
-			db, err = memory.NewMemoryStorage(nil)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			db, err = leveldb.NewLevelDBStorage(
-				filepath.Join(m.config.DataDir, "blockchain"),
-				m.logger,
-			)
-			if err != nil {
-				return nil, err
-			}
-		}
+	db, err := m.newBlockchainStorage()
+	if err != nil {
+		return nil, err
 	}
 
 	// blockchain object
@@ -356,6 +375,13 @@ func NewServer(config *Config) (*Server, error) {
 				PriceLimit:         m.config.PriceLimit,
 				MaxAccountEnqueued: m.config.MaxAccountEnqueued,
 				ChainID:            big.NewInt(m.config.Chain.Params.ChainID),
+				JournalPath:        m.txPoolJournalPath(),
+				JournalRotation:    m.config.TxPoolJournalRotation,
+				Lifetime:           m.config.TxPoolLifetime,
+				EvictPromoted:      m.config.TxPoolEvictPromoted,
+				PriorityAccounts:   m.config.PriorityAccounts,
+				Ordering:           m.txOrdering(),
+				MaxSenderTxs:       m.maxSenderTxs(),
 			},
 		)
 		if err != nil {
@@ -380,6 +406,14 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	if m.stateSnapshot != nil {
+		m.stateSnapshot.Init(m.blockchain.Header().StateRoot, m.stateStorage)
+	}
+
+	if m.statePruner != nil {
+		m.startStatePruning()
+	}
+
 	// initialize data in consensus layer
 	if err := m.consensus.Initialize(); err != nil {
 		return nil, err
@@ -436,6 +470,40 @@ func unaryInterceptor(
 	return handler(ctx, req)
 }
 
+// startStatePruning retains the states of the canonical blocks, the state
+// roots of the recent blocks and the checkpoints are added from the chain
+func (s *Server) startStatePruning() {
+	s.statePruner.Init(s.blockchain.Header().Number, func(number uint64) (types.Hash, bool) {
+		header, ok := s.blockchain.GetHeaderByNumber(number)
+		if !ok {
+			return types.Hash{}, false
+		}
+
+		return header.StateRoot, true
+	})
+
+	s.statePruningSub = s.blockchain.SubscribeEvents()
+
+	go func() {
+		for {
+			event := s.statePruningSub.GetEvent()
+			if event == nil {
+				return
+			}
+
+			// the new chain of a reorg starts with the head
+			headers := append([]*types.Header{}, event.NewChain...)
+			sort.Slice(headers, func(i, j int) bool {
+				return headers[i].Number < headers[j].Number
+			})
+
+			for _, header := range headers {
+				s.statePruner.AddRoot(header.Number, header.StateRoot)
+			}
+		}
+	}()
+}
+
 func (s *Server) restoreChain() error {
 	if s.config.RestoreFile == nil {
 		return nil
@@ -540,6 +608,42 @@ func (s *Server) setupSecretsManager() error {
 	return nil
 }
 
+// txPoolJournalPath returns the path of the txpool journal file, which is empty if the journal is disabled
+func (s *Server) txPoolJournalPath() string {
+	if s.config.TxPoolJournal == "" || filepath.IsAbs(s.config.TxPoolJournal) {
+		return s.config.TxPoolJournal
+	}
+
+	return filepath.Join(s.config.DataDir, s.config.TxPoolJournal)
+}
+
+// txOrdering returns the txpool ordering policy, the server config takes precedence over the genesis
+func (s *Server) txOrdering() txpool.OrderingType {
+	if s.config.TxOrdering != "" {
+		return txpool.OrderingType(s.config.TxOrdering)
+	}
+
+	if ordering := s.config.Chain.Params.TxOrdering; ordering != nil {
+		return txpool.OrderingType(ordering.Policy)
+	}
+
+	return txpool.PriceOrdering
+}
+
+// maxSenderTxs returns the cap on the transactions of an account in a block,
+// the server config takes precedence over the genesis
+func (s *Server) maxSenderTxs() uint64 {
+	if s.config.MaxSenderTxs != 0 {
+		return s.config.MaxSenderTxs
+	}
+
+	if ordering := s.config.Chain.Params.TxOrdering; ordering != nil {
+		return ordering.MaxSenderTxs
+	}
+
+	return 0
+}
+
 // setupConsensus sets up the consensus mechanism
 func (s *Server) setupConsensus() error {
 	engineName := s.config.Chain.Params.GetEngine()
@@ -585,6 +689,8 @@ func (s *Server) setupConsensus() error {
 			SecretsManager:        s.secretsManager,
 			BlockTime:             uint64(blockTime.Seconds()),
 			NumBlockConfirmations: s.config.NumBlockConfirmations,
+			StateStorage:          s.stateStorage,
+			StateSync:             s.config.StateSync,
 		},
 	)
 
@@ -689,6 +795,19 @@ func (j *jsonRPCHub) GetAccount(root types.Hash, addr types.Address) (*jsonrpc.A
 }
 
 // GetForksInTime returns the active forks at the given block height
+func (j *jsonRPCHub) GetTxStatus(hash types.Hash) (*jsonrpc.TxStatus, bool) {
+	status, ok := j.TxPool.GetTxStatus(hash)
+	if !ok {
+		return nil, false
+	}
+
+	return &jsonrpc.TxStatus{
+		State:      string(status.State),
+		Demotions:  status.Demotions,
+		DropReason: string(status.DropReason),
+	}, true
+}
+
 func (j *jsonRPCHub) GetForksInTime(blockNumber uint64) chain.ForksInTime {
 	return j.Executor.GetForksInTime(blockNumber)
 }
@@ -723,6 +842,61 @@ func (j *jsonRPCHub) GetCode(root types.Hash, addr types.Address) ([]byte, error
 	return code, nil
 }
 
+// GetProof returns the merkle proofs of the account and of the given storage slots at the state root
+func (j *jsonRPCHub) GetProof(
+	root types.Hash,
+	addr types.Address,
+	slots []types.Hash,
+) (*jsonrpc.AccountProof, error) {
+	snap, err := j.state.NewSnapshotAt(root)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get snapshot for root '%s': %w", root, err)
+	}
+
+	accountProof, err := snap.GetAccountProof(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := snap.GetAccount(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// the proof shows that the account doesn't exist, so the empty account is returned
+	if account == nil {
+		account = &state.Account{
+			Balance:  big.NewInt(0),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.EmptyCodeHash.Bytes(),
+		}
+	}
+
+	proof := &jsonrpc.AccountProof{
+		Balance:      new(big.Int).Set(account.Balance),
+		Nonce:        account.Nonce,
+		CodeHash:     types.BytesToHash(account.CodeHash),
+		StorageHash:  account.Root,
+		Proof:        accountProof,
+		StorageProof: make([]*jsonrpc.StorageProof, len(slots)),
+	}
+
+	for i, slot := range slots {
+		storageProof, err := snap.GetStorageProof(addr, account.Root, slot)
+		if err != nil {
+			return nil, err
+		}
+
+		proof.StorageProof[i] = &jsonrpc.StorageProof{
+			Key:   slot,
+			Value: snap.GetStorage(addr, account.Root, slot),
+			Proof: storageProof,
+		}
+	}
+
+	return proof, nil
+}
+
 func (j *jsonRPCHub) ApplyTxn(
 	header *types.Header,
 	txn *types.Transaction,
@@ -908,6 +1082,10 @@ func (s *Server) setupJSONRPC() error {
 		PriceLimit:               s.config.PriceLimit,
 		BatchLengthLimit:         s.config.JSONRPC.BatchLengthLimit,
 		BlockRangeLimit:          s.config.JSONRPC.BlockRangeLimit,
+		RateLimit:                s.config.JSONRPC.RateLimit,
+		Namespaces:               s.config.JSONRPC.Namespaces,
+		AllowedMethods:           s.config.JSONRPC.AllowedMethods,
+		DeniedMethods:            s.config.JSONRPC.DeniedMethods,
 	}
 
 	srv, err := jsonrpc.NewJSONRPC(s.logger, conf)
@@ -917,6 +1095,25 @@ func (s *Server) setupJSONRPC() error {
 
 	s.jsonrpcServer = srv
 
+	if s.config.JSONRPC.AdminAddr == nil {
+		return nil
+	}
+
+	// the admin listener is not rate limited and it only serves the admin namespaces
+	adminConf := *conf
+	adminConf.Addr = s.config.JSONRPC.AdminAddr
+	adminConf.RateLimit = nil
+	adminConf.Namespaces = s.config.JSONRPC.AdminNamespaces
+	adminConf.AllowedMethods = nil
+	adminConf.DeniedMethods = nil
+
+	adminSrv, err := jsonrpc.NewJSONRPC(s.logger.Named("admin"), &adminConf)
+	if err != nil {
+		return err
+	}
+
+	s.jsonrpcAdminServer = adminSrv
+
 	return nil
 }
 
@@ -953,6 +1150,8 @@ func (s *Server) JoinPeer(rawPeerMultiaddr string) error {
 
 // Close closes the Minimal server (blockchain, networking, consensus)
 func (s *Server) Close() {
+	headRoot := s.blockchain.Header().StateRoot
+
 	// Close the blockchain layer
 	if err := s.blockchain.Close(); err != nil {
 		s.logger.Error("failed to close blockchain", "err", err.Error())
@@ -968,6 +1167,17 @@ func (s *Server) Close() {
 		s.logger.Error("failed to close consensus", "err", err.Error())
 	}
 
+	if s.statePruningSub != nil {
+		s.statePruningSub.Close()
+	}
+
+	// Persist the flat snapshot of the head state
+	if s.stateSnapshot != nil {
+		if err := s.stateSnapshot.Close(headRoot); err != nil {
+			s.logger.Error("failed to persist the state snapshot", "err", err.Error())
+		}
+	}
+
 	// Close the state storage
 	if err := s.stateStorage.Close(); err != nil {
 		s.logger.Error("failed to close storage for trie", "err", err.Error())
@@ -0,0 +1,191 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/pebble"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+)
+
+// StorageBackend is the database engine of the blockchain and the trie stores
+type StorageBackend string
+
+const (
+	LevelDBBackend StorageBackend = "leveldb"
+	PebbleBackend  StorageBackend = "pebble"
+)
+
+const (
+	// storageBackendFile is the file of the data directory recording the storage backend it's written with
+	storageBackendFile = "storage_backend"
+	// LevelDBBackupSuffix is the suffix of the leveldb databases kept as a backup by the migration to pebble
+	LevelDBBackupSuffix = ".leveldb"
+)
+
+// StorageDatabases are the directories of the data directory holding the databases of the storage backend
+var StorageDatabases = []string{
+	"blockchain",
+	"trie",
+}
+
+var (
+	ErrUnknownStorageBackend  = errors.New("unknown storage backend")
+	ErrStorageBackendMismatch = errors.New("data directory is written with another storage backend")
+	ErrMigrationNotComplete   = errors.New("migration of the data directory to pebble isn't complete, run the migrate command again")
+)
+
+// ValidateStorageBackend returns an error if the storage backend isn't supported
+func ValidateStorageBackend(backend StorageBackend) error {
+	switch backend {
+	case LevelDBBackend, PebbleBackend:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownStorageBackend, backend)
+	}
+}
+
+// ReadStorageBackend returns the storage backend recorded in the data directory, it's empty if none is recorded
+func ReadStorageBackend(dataDir string) (StorageBackend, error) {
+	data, err := os.ReadFile(filepath.Join(dataDir, storageBackendFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	backend := StorageBackend(strings.TrimSpace(string(data)))
+	if err := ValidateStorageBackend(backend); err != nil {
+		return "", err
+	}
+
+	return backend, nil
+}
+
+// WriteStorageBackend records the storage backend in the data directory
+func WriteStorageBackend(dataDir string, backend StorageBackend) error {
+	return common.SaveFileSafe(filepath.Join(dataDir, storageBackendFile), []byte(backend), 0660)
+}
+
+// checkStorageBackend returns an error if the data directory is written with another storage backend
+// or is in the middle of the migration to pebble, it records the storage backend of a new data directory
+func checkStorageBackend(dataDir string, backend StorageBackend) error {
+	if backend == "" {
+		backend = LevelDBBackend
+	}
+
+	recorded, err := ReadStorageBackend(dataDir)
+	if err != nil {
+		return err
+	}
+
+	// the migration records pebble once all the databases are moved in place
+	if recorded != PebbleBackend {
+		for _, name := range StorageDatabases {
+			if _, err := os.Stat(filepath.Join(dataDir, name+LevelDBBackupSuffix)); err == nil {
+				return ErrMigrationNotComplete
+			}
+		}
+	}
+
+	if recorded == "" {
+		entries, err := os.ReadDir(filepath.Join(dataDir, "blockchain"))
+		if err != nil {
+			return err
+		}
+
+		// the data directories written before the storage backend was recorded are leveldb ones
+		recorded = backend
+		if len(entries) > 0 {
+			recorded = LevelDBBackend
+		}
+
+		if err := WriteStorageBackend(dataDir, recorded); err != nil {
+			return err
+		}
+	}
+
+	if recorded != backend {
+		return fmt.Errorf("%w: %s is configured but %s is recorded", ErrStorageBackendMismatch, backend, recorded)
+	}
+
+	return nil
+}
+
+// newStateStorage opens the trie store of the data directory
+func (s *Server) newStateStorage() (itrie.Storage, error) {
+	path := filepath.Join(s.config.DataDir, "trie")
+
+	switch s.config.StorageBackend {
+	case PebbleBackend:
+		return itrie.NewPebbleStorage(path, s.logger)
+	case LevelDBBackend, "":
+		return itrie.NewLevelDBStorage(path, s.logger)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownStorageBackend, s.config.StorageBackend)
+	}
+}
+
+// newBlockchainStorage opens the blockchain store of the data directory, it's kept in memory without one
+func (s *Server) newBlockchainStorage() (storage.Storage, error) {
+	if s.config.DataDir == "" {
+		return memory.NewMemoryStorage(nil)
+	}
+
+	var (
+		db   storage.Storage
+		err  error
+		path = filepath.Join(s.config.DataDir, "blockchain")
+	)
+
+	switch s.config.StorageBackend {
+	case PebbleBackend:
+		db, err = pebble.NewPebbleStorage(path, s.logger)
+	case LevelDBBackend, "":
+		db, err = leveldb.NewLevelDBStorage(path, s.logger)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownStorageBackend, s.config.StorageBackend)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.startFreezer(db); err != nil {
+		_ = db.Close()
+
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// startFreezer moves the blocks deeper than the freezer depth to the freezer of the data directory
+func (s *Server) startFreezer(db storage.Storage) error {
+	if s.config.FreezerDepth == 0 {
+		return nil
+	}
+
+	kv, ok := db.(*storage.KeyValueStorage)
+	if !ok {
+		return nil
+	}
+
+	freezer, err := storage.NewFreezer(filepath.Join(s.config.DataDir, "ancient"), s.logger)
+	if err != nil {
+		return fmt.Errorf("failed to open the freezer: %w", err)
+	}
+
+	kv.StartFreezer(freezer, s.config.FreezerDepth)
+
+	s.logger.Info("freezer started", "depth", s.config.FreezerDepth, "frozen blocks", freezer.Count())
+
+	return nil
+}
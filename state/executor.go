@@ -22,10 +22,13 @@ import (
 
 const (
 	SpuriousDragonMaxCodeSize = 24576
-	TxPoolMaxInitCodeSize     = 2 * SpuriousDragonMaxCodeSize
+	TxPoolMaxInitCodeSize     = runtime.MaxInitCodeSize
 
 	TxGas                 uint64 = 21000 // Per transaction not creating a contract
 	TxGasContractCreation uint64 = 53000 // Per transaction that creates a contract
+
+	TxAccessListAddressGas    uint64 = 2400 // Per address specified in EIP-2930 access list
+	TxAccessListStorageKeyGas uint64 = 1900 // Per storage key specified in EIP-2930 access list
 )
 
 // GetHashByNumber returns the hash function of a block number
@@ -334,8 +337,7 @@ var emptyFrom = types.Address{}
 func (t *Transition) Write(txn *types.Transaction) error {
 	var err error
 
-	if txn.From == emptyFrom &&
-		(txn.Type == types.LegacyTx || txn.Type == types.DynamicFeeTx) {
+	if txn.From == emptyFrom && txn.Type != types.StateTx {
 		// Decrypt the from address
 		signer := crypto.NewSigner(t.config, uint64(t.ctx.ChainID))
 
@@ -390,6 +392,38 @@ func (t *Transition) Write(txn *types.Transaction) error {
 	return nil
 }
 
+// WriteBundle writes the transactions as a single unit. If any of them can't be applied
+// or its execution fails, the transition is reverted to its state before the bundle
+func (t *Transition) WriteBundle(txns []*types.Transaction) error {
+	var (
+		snapshot = t.state.Snapshot()
+		receipts = len(t.receipts)
+		totalGas = t.totalGas
+		gasPool  = t.gasPool
+	)
+
+	for _, txn := range txns {
+		err := t.Write(txn)
+		if err == nil && *t.receipts[len(t.receipts)-1].Status == types.ReceiptFailed {
+			err = fmt.Errorf("%w: %s", ErrBundleTxFailed, txn.Hash)
+		}
+
+		if err != nil {
+			if revertErr := t.state.RevertToSnapshot(snapshot); revertErr != nil {
+				return revertErr
+			}
+
+			t.receipts = t.receipts[:receipts]
+			t.totalGas = totalGas
+			t.gasPool = gasPool
+
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Commit commits the final result
 func (t *Transition) Commit() (Snapshot, types.Hash, error) {
 	objs, err := t.state.Commit(t.config.EIP155)
@@ -458,7 +492,8 @@ func (t *Transition) subGasLimitPrice(msg *types.Transaction) error {
 
 	upfrontGasCost = upfrontGasCost.Mul(upfrontGasCost, factor)
 
-	if err := t.state.SubBalance(msg.From, upfrontGasCost); err != nil {
+	// the sponsor pays the gas of the sponsored transactions
+	if err := t.state.SubBalance(msg.GasPayer(), upfrontGasCost); err != nil {
 		if errors.Is(err, runtime.ErrNotEnoughFunds) {
 			return ErrNotEnoughFundsForGas
 		}
@@ -469,6 +504,29 @@ func (t *Transition) subGasLimitPrice(msg *types.Transaction) error {
 	return nil
 }
 
+// sponsorCheck makes sure the sponsored transactions are enabled
+// and the sponsored transaction is signed by its sponsor
+func (t *Transition) sponsorCheck(msg *types.Transaction) error {
+	if !msg.IsSponsored() {
+		return nil
+	}
+
+	if !t.config.SponsoredTx {
+		return ErrSponsoredTxNotEnabled
+	}
+
+	sponsor, err := crypto.NewSigner(t.config, uint64(t.ctx.ChainID)).Sponsor(msg)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSponsor, err)
+	}
+
+	if sponsor != msg.Sponsor {
+		return fmt.Errorf("%w: expected %s, but got %s", ErrInvalidSponsor, msg.Sponsor, sponsor)
+	}
+
+	return nil
+}
+
 func (t *Transition) nonceCheck(msg *types.Transaction) error {
 	nonce := t.state.GetNonce(msg.From)
 
@@ -540,6 +598,15 @@ var (
 	// ErrFeeCapTooLow is returned if the transaction fee cap is less than the
 	// the base fee of the block.
 	ErrFeeCapTooLow = errors.New("max fee per gas less than block base fee")
+
+	// ErrBundleTxFailed is returned if the execution of a transaction of the bundle fails
+	ErrBundleTxFailed = errors.New("bundle transaction execution failed")
+
+	// ErrInvalidSponsor is returned if the sponsored transaction is not signed by its sponsor
+	ErrInvalidSponsor = errors.New("invalid sponsor signature")
+
+	// ErrSponsoredTxNotEnabled is returned if the sponsored transaction precedes the sponsored transactions fork
+	ErrSponsoredTxNotEnabled = errors.New("sponsored transactions are not enabled")
 )
 
 type TransitionApplicationError struct {
@@ -571,6 +638,8 @@ func NewGasLimitReachedTransitionApplicationError(err error) *GasLimitReachedTra
 func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, error) {
 	var err error
 
+	t.captureTxStateStart(msg)
+
 	if msg.Type == types.StateTx {
 		err = checkAndProcessStateTx(msg)
 	} else {
@@ -591,11 +660,16 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	}
 
 	// 4. there is no overflow when calculating intrinsic gas
-	intrinsicGasCost, err := TransactionGasCost(msg, t.config.Homestead, t.config.Istanbul)
+	intrinsicGasCost, err := TransactionGasCost(msg, t.config.Homestead, t.config.Istanbul, t.config.Shanghai)
 	if err != nil {
 		return nil, NewTransitionApplicationError(err, false)
 	}
 
+	// 5. the init code of the contract creation doesn't exceed the limit
+	if t.config.Shanghai && msg.IsContractCreation() && len(msg.Input) > runtime.MaxInitCodeSize {
+		return nil, NewTransitionApplicationError(runtime.ErrMaxInitCodeSizeExceeded, false)
+	}
+
 	// the purchased gas is enough to cover intrinsic usage
 	gasLeft := msg.Gas - intrinsicGasCost
 	// because we are working with unsigned integers for gas, the `>` operator is used instead of the more intuitive `<`
@@ -610,6 +684,10 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	t.ctx.GasPrice = types.BytesToHash(gasPrice.Bytes())
 	t.ctx.Origin = msg.From
 
+	if t.config.Berlin {
+		t.prepareAccessList(msg)
+	}
+
 	var result *runtime.ExecutionResult
 	if msg.IsContractCreation() {
 		result = t.Create2(msg.From, msg.Input, value, gasLeft)
@@ -625,9 +703,9 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 		t.ctx.Tracer.TxEnd(result.GasLeft)
 	}
 
-	// Refund the sender
+	// Refund the sender (or the sponsor)
 	remaining := new(big.Int).Mul(new(big.Int).SetUint64(result.GasLeft), gasPrice)
-	t.state.AddBalance(msg.From, remaining)
+	t.state.AddBalance(msg.GasPayer(), remaining)
 
 	// Spec: https://eips.ethereum.org/EIPS/eip-1559#specification
 	// Define effective tip based on tx type.
@@ -655,9 +733,38 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	// return gas to the pool
 	t.addGasPool(result.GasLeft)
 
+	t.captureTxStateEnd()
+
 	return result, nil
 }
 
+// prepareAccessList warms up the sender, the recipient, the precompiles
+// and the access list of the transaction (EIP-2929 and EIP-2930)
+func (t *Transition) prepareAccessList(msg *types.Transaction) {
+	t.state.AddAddressToAccessList(msg.From)
+
+	// eip-3651, the coinbase is warm
+	if t.config.Shanghai {
+		t.state.AddAddressToAccessList(t.ctx.Coinbase)
+	}
+
+	if msg.To != nil {
+		t.state.AddAddressToAccessList(*msg.To)
+	}
+
+	for _, addr := range t.precompiles.Addresses(&t.config) {
+		t.state.AddAddressToAccessList(addr)
+	}
+
+	for _, tuple := range msg.AccessList {
+		t.state.AddAddressToAccessList(tuple.Address)
+
+		for _, key := range tuple.StorageKeys {
+			t.state.AddSlotToAccessList(tuple.Address, key)
+		}
+	}
+}
+
 func (t *Transition) Create2(
 	caller types.Address,
 	code []byte,
@@ -808,7 +915,7 @@ func (t *Transition) hasCodeOrNonce(addr types.Address) bool {
 	return codeHash != types.EmptyCodeHash && codeHash != types.ZeroHash
 }
 
-func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtime.ExecutionResult {
+func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) (result *runtime.ExecutionResult) {
 	gasLimit := c.Gas
 
 	if c.Depth > int(1024)+1 {
@@ -821,6 +928,11 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 	// Increment the nonce of the caller
 	t.state.IncrNonce(c.Caller)
 
+	// The address of the created contract is warm even if the creation fails
+	if t.config.Berlin {
+		t.state.AddAddressToAccessList(c.Address)
+	}
+
 	// Check if there is a collision and the address already exists
 	if t.hasCodeOrNonce(c.Address) {
 		return &runtime.ExecutionResult{
@@ -838,6 +950,10 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 		t.state.IncrNonce(c.Address)
 	}
 
+	if t.config.Cancun {
+		t.state.MarkContractCreated(c.Address)
+	}
+
 	// Transfer the value
 	if err := t.Transfer(c.Caller, c.Address, c.Value); err != nil {
 		return &runtime.ExecutionResult{
@@ -846,12 +962,14 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 		}
 	}
 
-	var result *runtime.ExecutionResult
-
-	t.captureCallStart(c, evm.CREATE)
+	if c.Type == runtime.Create2 {
+		t.captureCallStart(c, evm.CREATE2)
+	} else {
+		t.captureCallStart(c, evm.CREATE)
+	}
 
 	defer func() {
-		// pass result to be set later
+		// pass the returned result to be set later
 		t.captureCallEnd(c, result)
 	}()
 
@@ -1033,6 +1151,17 @@ func (t *Transition) GetNonce(addr types.Address) uint64 {
 }
 
 func (t *Transition) Selfdestruct(addr types.Address, beneficiary types.Address) {
+	// eip-6780, the account is deleted only if it has been created in the same transaction,
+	// otherwise only the balance is moved to the beneficiary
+	if t.config.Cancun && !t.state.IsContractCreated(addr) {
+		if addr != beneficiary {
+			t.state.AddBalance(beneficiary, t.state.GetBalance(addr))
+			t.state.SetBalance(addr, big.NewInt(0))
+		}
+
+		return
+	}
+
 	if !t.state.HasSuicided(addr) {
 		t.state.AddRefund(24000)
 	}
@@ -1042,7 +1171,7 @@ func (t *Transition) Selfdestruct(addr types.Address, beneficiary types.Address)
 }
 
 func (t *Transition) Callx(c *runtime.Contract, h runtime.Host) *runtime.ExecutionResult {
-	if c.Type == runtime.Create {
+	if c.Type == runtime.Create || c.Type == runtime.Create2 {
 		return t.applyCreate(c, h)
 	}
 
@@ -1094,7 +1223,31 @@ func (t *Transition) GetRefund() uint64 {
 	return t.state.GetRefund()
 }
 
-func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (uint64, error) {
+func (t *Transition) AddressInAccessList(addr types.Address) bool {
+	return t.state.AddressInAccessList(addr)
+}
+
+func (t *Transition) SlotInAccessList(addr types.Address, slot types.Hash) (bool, bool) {
+	return t.state.SlotInAccessList(addr, slot)
+}
+
+func (t *Transition) AddAddressToAccessList(addr types.Address) {
+	t.state.AddAddressToAccessList(addr)
+}
+
+func (t *Transition) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	t.state.AddSlotToAccessList(addr, slot)
+}
+
+func (t *Transition) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	return t.state.GetTransientState(addr, key)
+}
+
+func (t *Transition) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	t.state.SetTransientState(addr, key, value)
+}
+
+func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul, isShanghai bool) (uint64, error) {
 	cost := uint64(0)
 
 	// Contract creation is only paid on the homestead fork
@@ -1132,6 +1285,33 @@ func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (u
 		}
 
 		cost += zeros * 4
+
+		// eip-3860, the init code is charged per word
+		if msg.IsContractCreation() && isShanghai {
+			words := (uint64(len(payload)) + 31) / 32
+			if (math.MaxUint64-cost)/runtime.InitCodeWordGas < words {
+				return 0, ErrIntrinsicGasOverflow
+			}
+
+			cost += words * runtime.InitCodeWordGas
+		}
+	}
+
+	// Access list is the part of typed transactions only, which are enabled by the berlin fork
+	if len(msg.AccessList) > 0 {
+		addresses := uint64(len(msg.AccessList))
+		if (math.MaxUint64-cost)/TxAccessListAddressGas < addresses {
+			return 0, ErrIntrinsicGasOverflow
+		}
+
+		cost += addresses * TxAccessListAddressGas
+
+		storageKeys := uint64(msg.AccessList.StorageKeys())
+		if (math.MaxUint64-cost)/TxAccessListStorageKeyGas < storageKeys {
+			return 0, ErrIntrinsicGasOverflow
+		}
+
+		cost += storageKeys * TxAccessListStorageKeyGas
 	}
 
 	return cost, nil
@@ -1141,6 +1321,8 @@ func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (u
 // applying the message. The rules include these clauses:
 // 1. the nonce of the message caller is correct
 // 2. caller has enough balance to cover transaction fee(gaslimit * gasprice * val) or fee(gasfeecap * gasprice * val)
+// 3. the sponsored transactions are enabled, the sponsor signed the sponsored transaction
+// and pays its fee instead of the caller
 func checkAndProcessTx(msg *types.Transaction, t *Transition) error {
 	// 1. the nonce of the message caller is correct
 	if err := t.nonceCheck(msg); err != nil {
@@ -1152,7 +1334,12 @@ func checkAndProcessTx(msg *types.Transaction, t *Transition) error {
 		return NewTransitionApplicationError(err, true)
 	}
 
-	// 3. caller has enough balance to cover transaction
+	// 3. the sponsored transactions are enabled and the sponsor of the sponsored transaction signed it
+	if err := t.sponsorCheck(msg); err != nil {
+		return NewTransitionApplicationError(err, false)
+	}
+
+	// 4. caller (or sponsor) has enough balance to cover transaction
 	if err := t.subGasLimitPrice(msg); err != nil {
 		return NewTransitionApplicationError(err, true)
 	}
@@ -1198,10 +1385,18 @@ func (t *Transition) captureCallStart(c *runtime.Contract, callType runtime.Call
 		return
 	}
 
+	from, to := c.Caller, c.Address
+
+	// delegate calls and call codes are executed in the context of the caller contract,
+	// so they are reported as calls from the caller contract to the code address
+	if callType == runtime.DelegateCall || callType == runtime.CallCode {
+		from, to = c.Address, c.CodeAddress
+	}
+
 	t.ctx.Tracer.CallStart(
 		c.Depth,
-		c.Caller,
-		c.Address,
+		from,
+		to,
 		int(callType),
 		c.Gas,
 		c.Value,
@@ -1218,6 +1413,27 @@ func (t *Transition) captureCallEnd(c *runtime.Contract, result *runtime.Executi
 	t.ctx.Tracer.CallEnd(
 		c.Depth,
 		result.ReturnValue,
+		result.GasLeft,
 		result.Err,
 	)
 }
+
+// captureTxStateStart calls TxStateStart in Tracer if context has the state tracer
+func (t *Transition) captureTxStateStart(msg *types.Transaction) {
+	stateTracer, ok := t.ctx.Tracer.(tracer.StateTracer)
+	if !ok {
+		return
+	}
+
+	stateTracer.TxStateStart(t, msg.From, msg.To, t.ctx.Coinbase)
+}
+
+// captureTxStateEnd calls TxStateEnd in Tracer if context has the state tracer
+func (t *Transition) captureTxStateEnd() {
+	stateTracer, ok := t.ctx.Tracer.(tracer.StateTracer)
+	if !ok {
+		return
+	}
+
+	stateTracer.TxStateEnd(t)
+}
@@ -5,10 +5,12 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -154,3 +156,269 @@ func Test_Transition_checkDynamicFees(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionGasCost_AccessList(t *testing.T) {
+	t.Parallel()
+
+	to := types.StringToAddress("1")
+
+	tx := &types.Transaction{
+		Type: types.AccessListTx,
+		To:   &to,
+		AccessList: types.TxAccessList{
+			{
+				Address:     to,
+				StorageKeys: []types.Hash{types.StringToHash("1"), types.StringToHash("2")},
+			},
+			{
+				Address: types.StringToAddress("2"),
+			},
+		},
+	}
+
+	cost, err := TransactionGasCost(tx, true, true, true)
+	require.NoError(t, err)
+	require.Equal(t, TxGas+2*TxAccessListAddressGas+2*TxAccessListStorageKeyGas, cost)
+}
+
+func TestTransactionGasCost_InitCode(t *testing.T) {
+	t.Parallel()
+
+	// two words of the init code
+	tx := &types.Transaction{
+		Input: make([]byte, 33),
+	}
+
+	cost, err := TransactionGasCost(tx, true, true, false)
+	require.NoError(t, err)
+	require.Equal(t, TxGasContractCreation+33*4, cost)
+
+	cost, err = TransactionGasCost(tx, true, true, true)
+	require.NoError(t, err)
+	require.Equal(t, TxGasContractCreation+33*4+2*runtime.InitCodeWordGas, cost)
+}
+
+func TestSelfdestruct_Cancun(t *testing.T) {
+	t.Parallel()
+
+	var (
+		contract    = types.Address{0x1}
+		beneficiary = types.Address{0x2}
+	)
+
+	newTransition := func() *Transition {
+		state := newStateWithPreState(map[types.Address]*PreState{
+			contract: {
+				Nonce:   1,
+				Balance: 10,
+			},
+		})
+
+		return NewTransition(chain.ForksInTime{Cancun: true}, state, newTxn(state))
+	}
+
+	t.Run("existing contract keeps the account", func(t *testing.T) {
+		t.Parallel()
+
+		tt := newTransition()
+		tt.Selfdestruct(contract, beneficiary)
+
+		require.False(t, tt.state.HasSuicided(contract))
+		require.Equal(t, uint64(0), tt.state.GetBalance(contract).Uint64())
+		require.Equal(t, uint64(10), tt.state.GetBalance(beneficiary).Uint64())
+		require.Equal(t, uint64(0), tt.state.GetRefund())
+	})
+
+	t.Run("existing contract sending the balance to itself", func(t *testing.T) {
+		t.Parallel()
+
+		tt := newTransition()
+		tt.Selfdestruct(contract, contract)
+
+		require.False(t, tt.state.HasSuicided(contract))
+		require.Equal(t, uint64(10), tt.state.GetBalance(contract).Uint64())
+	})
+
+	t.Run("contract created in the same transaction", func(t *testing.T) {
+		t.Parallel()
+
+		tt := newTransition()
+		tt.state.MarkContractCreated(contract)
+		tt.Selfdestruct(contract, beneficiary)
+
+		require.True(t, tt.state.HasSuicided(contract))
+		require.Equal(t, uint64(0), tt.state.GetBalance(contract).Uint64())
+		require.Equal(t, uint64(10), tt.state.GetBalance(beneficiary).Uint64())
+	})
+}
+
+func TestTransition_WriteBundle(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender   = types.Address{0x1}
+		receiver = types.Address{0x2}
+	)
+
+	newTransition := func() *Transition {
+		state := newStateWithPreState(map[types.Address]*PreState{
+			sender: {
+				Balance: 1000000,
+			},
+		})
+
+		tt := NewTransition(chain.ForksInTime{Homestead: true}, state, newTxn(state))
+		tt.logger = hclog.NewNullLogger()
+		tt.ctx.BaseFee = big.NewInt(0)
+		tt.gasPool = 1000000
+
+		return tt
+	}
+
+	newTx := func(nonce uint64) *types.Transaction {
+		return &types.Transaction{
+			From:     sender,
+			To:       &receiver,
+			Nonce:    nonce,
+			Value:    big.NewInt(1),
+			Gas:      21000,
+			GasPrice: big.NewInt(1),
+		}
+	}
+
+	t.Run("all transactions are applied", func(t *testing.T) {
+		t.Parallel()
+
+		tt := newTransition()
+
+		require.NoError(t, tt.WriteBundle([]*types.Transaction{newTx(0), newTx(1)}))
+
+		require.Len(t, tt.Receipts(), 2)
+		require.Equal(t, uint64(2*21000), tt.TotalGas())
+		require.Equal(t, uint64(2), tt.state.GetNonce(sender))
+		require.Equal(t, uint64(2), tt.state.GetBalance(receiver).Uint64())
+	})
+
+	t.Run("failing transaction reverts the bundle", func(t *testing.T) {
+		t.Parallel()
+
+		tt := newTransition()
+
+		// the transaction before the bundle is kept
+		require.NoError(t, tt.Write(newTx(0)))
+
+		require.Error(t, tt.WriteBundle([]*types.Transaction{newTx(1), newTx(5)}))
+
+		require.Len(t, tt.Receipts(), 1)
+		require.Equal(t, uint64(21000), tt.TotalGas())
+		require.Equal(t, uint64(1000000-21000), tt.gasPool)
+		require.Equal(t, uint64(1), tt.state.GetNonce(sender))
+		require.Equal(t, uint64(1), tt.state.GetBalance(receiver).Uint64())
+	})
+}
+
+func TestTransition_SponsoredTx(t *testing.T) {
+	t.Parallel()
+
+	const chainID = 100
+
+	senderKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	sponsorKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	var (
+		sender   = crypto.PubKeyToAddress(&senderKey.PublicKey)
+		sponsor  = crypto.PubKeyToAddress(&sponsorKey.PublicKey)
+		receiver = types.Address{0x2}
+		forks    = chain.ForksInTime{Homestead: true, EIP155: true, Berlin: true, SponsoredTx: true}
+		signer   = crypto.NewLondonSigner(chainID, true, crypto.NewEIP155Signer(chainID, true))
+	)
+
+	newTransition := func(forks chain.ForksInTime) *Transition {
+		state := newStateWithPreState(map[types.Address]*PreState{
+			// the sender can't pay for the gas
+			sender: {
+				Balance: 1,
+			},
+			sponsor: {
+				Balance: 1000000,
+			},
+		})
+
+		tt := NewTransition(forks, state, newTxn(state))
+		tt.logger = hclog.NewNullLogger()
+		tt.ctx.BaseFee = big.NewInt(0)
+		tt.ctx.ChainID = chainID
+		tt.gasPool = 1000000
+
+		return tt
+	}
+
+	newSponsoredTx := func(t *testing.T, sponsorAddr types.Address) *types.Transaction {
+		t.Helper()
+
+		tx, err := signer.SignTx(&types.Transaction{
+			Type:     types.SponsoredTx,
+			ChainID:  big.NewInt(chainID),
+			To:       &receiver,
+			Value:    big.NewInt(1),
+			Gas:      21000,
+			GasPrice: big.NewInt(2),
+			Sponsor:  sponsorAddr,
+		}, senderKey)
+		require.NoError(t, err)
+
+		tx, err = signer.SignSponsorTx(tx, sponsorKey)
+		require.NoError(t, err)
+
+		return tx
+	}
+
+	t.Run("sponsor pays the gas", func(t *testing.T) {
+		t.Parallel()
+
+		tt := newTransition(forks)
+
+		require.NoError(t, tt.Write(newSponsoredTx(t, sponsor)))
+
+		require.Len(t, tt.Receipts(), 1)
+		require.Equal(t, uint64(1), tt.state.GetNonce(sender))
+		require.Equal(t, uint64(0), tt.state.GetBalance(sender).Uint64())
+		require.Equal(t, uint64(1), tt.state.GetBalance(receiver).Uint64())
+		require.Equal(t, uint64(1000000-2*21000), tt.state.GetBalance(sponsor).Uint64())
+	})
+
+	t.Run("not signed by the sponsor", func(t *testing.T) {
+		t.Parallel()
+
+		tt := newTransition(forks)
+
+		// the sender names an account which didn't sign the transaction as the sponsor
+		err := tt.Write(newSponsoredTx(t, receiver))
+		require.ErrorContains(t, err, ErrInvalidSponsor.Error())
+
+		require.Empty(t, tt.Receipts())
+		require.Equal(t, uint64(1000000), tt.state.GetBalance(sponsor).Uint64())
+	})
+
+	t.Run("sponsored transactions not enabled", func(t *testing.T) {
+		t.Parallel()
+
+		beforeFork := forks
+		beforeFork.SponsoredTx = false
+
+		tt := newTransition(beforeFork)
+
+		// the sender is recovered by the blockchain, the signer of the transition rejects the transaction too
+		tx := newSponsoredTx(t, sponsor)
+		tx.From = sender
+
+		err := tt.Write(tx)
+		require.ErrorContains(t, err, ErrSponsoredTxNotEnabled.Error())
+
+		require.Empty(t, tt.Receipts())
+		require.Equal(t, uint64(1000000), tt.state.GetBalance(sponsor).Uint64())
+	})
+}
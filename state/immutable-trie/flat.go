@@ -0,0 +1,463 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/umbracle/fastrlp"
+)
+
+const (
+	// maxDiffLayers is the number of the diff layers kept on top of the disk layer
+	maxDiffLayers = 128
+	// flatBatchSize is the max number of the entries written at once during the generation
+	flatBatchSize = 10000
+)
+
+var (
+	// snapAccountPrefix is the prefix of the flat accounts, keyed by the account hash
+	snapAccountPrefix = []byte("sa")
+	// snapStoragePrefix is the prefix of the flat storage slots, keyed by the account and the slot hashes
+	snapStoragePrefix = []byte("ss")
+	// snapRootKey is the key of the state root of the disk layer
+	snapRootKey = []byte("snapshot-root")
+)
+
+var (
+	errNoFlatStorage = errors.New("the trie storage doesn't support the flat snapshot")
+)
+
+// diffLayer holds the changes of the state committed on top of the parent state
+type diffLayer struct {
+	parent types.Hash
+	// accounts are the account RLPs by the account hash, nil if the account was deleted
+	accounts map[types.Hash][]byte
+	// wiped are the accounts whose storage was cleared before the slots were applied
+	wiped map[types.Hash]struct{}
+	// storage are the slot values by the account and the slot hashes, zero if the slot was deleted
+	storage map[types.Hash]map[types.Hash]types.Hash
+}
+
+// FlatTree is the flat snapshot of the state, it maps the account hashes to the accounts
+// and the account and slot hashes to the storage values, so the reads don't traverse the trie.
+//
+// The most recent committed states are kept in memory as diff layers on top of the disk layer,
+// once there are more than maxDiffLayers of them, the bottom ones are flattened into the disk layer.
+// The reads at the states which aren't covered by the tree fall back to the trie.
+type FlatTree struct {
+	storage flatStorage
+	logger  hclog.Logger
+
+	lock sync.RWMutex
+	// diskRoot is the state root of the disk layer
+	diskRoot types.Hash
+	// stale is set while the disk layer doesn't match diskRoot, i.e. it's being
+	// rebuilt from the trie or its write failed, it can't be read meanwhile
+	stale bool
+	// layers are the diff layers by the state root
+	layers map[types.Hash]*diffLayer
+
+	wg sync.WaitGroup
+}
+
+// NewFlatTree loads the flat snapshot from the storage, the storage has to support it
+func NewFlatTree(storage Storage, logger hclog.Logger) (*FlatTree, error) {
+	flat, ok := storage.(flatStorage)
+	if !ok {
+		return nil, errNoFlatStorage
+	}
+
+	t := &FlatTree{
+		storage: flat,
+		logger:  logger.Named("snapshot"),
+		layers:  make(map[types.Hash]*diffLayer),
+	}
+
+	if root, ok := flat.Get(snapRootKey); ok {
+		t.diskRoot = types.BytesToHash(root)
+	}
+
+	return t, nil
+}
+
+// Init makes the disk layer match the given state root, it's rebuilt
+// from the trie in the background if it was written for another state
+func (t *FlatTree) Init(root types.Hash, trieStorage Storage) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.diskRoot == root && !t.stale {
+		return
+	}
+
+	t.diskRoot = root
+	t.stale = true
+	t.layers = make(map[types.Hash]*diffLayer)
+
+	t.wg.Add(1)
+
+	go func() {
+		defer t.wg.Done()
+
+		start := time.Now()
+
+		accounts, err := t.generate(root, trieStorage)
+		if err != nil {
+			// the generation is retried on the next start, the reads fall back to the trie meanwhile
+			t.logger.Error("failed to generate the state snapshot", "root", root, "err", err)
+
+			return
+		}
+
+		t.lock.Lock()
+		t.stale = false
+		t.cap()
+		t.lock.Unlock()
+
+		t.logger.Info("state snapshot generated", "root", root, "accounts", accounts,
+			"duration", time.Since(start))
+	}()
+}
+
+// Close waits for the generation and flattens the diff layers of the given state,
+// so the snapshot doesn't have to be rebuilt on the next start
+func (t *FlatTree) Close(root types.Hash) error {
+	t.wg.Wait()
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.stale {
+		return nil
+	}
+
+	path, ok := t.path(root)
+	if !ok {
+		return nil
+	}
+
+	for i := len(path) - 1; i >= 0; i-- {
+		if err := t.flatten(path[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Update adds the diff layer of the state committed on top of the parent state,
+// it's ignored if the parent state isn't covered by the tree
+func (t *FlatTree) Update(
+	parent, root types.Hash,
+	accounts map[types.Hash][]byte,
+	wiped map[types.Hash]struct{},
+	storage map[types.Hash]map[types.Hash]types.Hash,
+) {
+	if parent == root {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.layers[root]; ok || root == t.diskRoot {
+		return
+	}
+
+	if _, ok := t.layers[parent]; !ok && parent != t.diskRoot {
+		return
+	}
+
+	t.layers[root] = &diffLayer{
+		parent:   parent,
+		accounts: accounts,
+		wiped:    wiped,
+		storage:  storage,
+	}
+
+	if !t.stale {
+		path, _ := t.path(root)
+		if len(path) > maxDiffLayers {
+			t.cap()
+		}
+	}
+}
+
+// Account returns the RLP of the account at the state, nil if there is no such account.
+// The second return value is false if the state isn't covered by the tree
+func (t *FlatTree) Account(root, accountHash types.Hash) ([]byte, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	for root != t.diskRoot {
+		layer, ok := t.layers[root]
+		if !ok {
+			return nil, false
+		}
+
+		if data, ok := layer.accounts[accountHash]; ok {
+			return data, true
+		}
+
+		root = layer.parent
+	}
+
+	if t.stale {
+		return nil, false
+	}
+
+	data, ok := t.storage.Get(flatAccountKey(accountHash))
+	if !ok || len(data) == 0 {
+		return nil, true
+	}
+
+	return data, true
+}
+
+// Storage returns the value of the storage slot at the state.
+// The second return value is false if the state isn't covered by the tree
+func (t *FlatTree) Storage(root, accountHash, slotHash types.Hash) (types.Hash, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	for root != t.diskRoot {
+		layer, ok := t.layers[root]
+		if !ok {
+			return types.Hash{}, false
+		}
+
+		if value, ok := layer.storage[accountHash][slotHash]; ok {
+			return value, true
+		}
+
+		if _, ok := layer.wiped[accountHash]; ok {
+			return types.Hash{}, true
+		}
+
+		root = layer.parent
+	}
+
+	if t.stale {
+		return types.Hash{}, false
+	}
+
+	data, _ := t.storage.Get(flatStorageKey(accountHash, slotHash))
+
+	return types.BytesToHash(data), true
+}
+
+// path returns the roots of the diff layers from the given state down to the disk layer [not thread-safe]
+func (t *FlatTree) path(root types.Hash) ([]types.Hash, bool) {
+	path := []types.Hash{}
+
+	for root != t.diskRoot {
+		layer, ok := t.layers[root]
+		if !ok {
+			return nil, false
+		}
+
+		path = append(path, root)
+		root = layer.parent
+	}
+
+	return path, true
+}
+
+// cap flattens the bottom diff layers of the longest branches into the disk layer,
+// so at most maxDiffLayers of them are left, the branches forked below are dropped [not thread-safe]
+func (t *FlatTree) cap() {
+	for {
+		var longest []types.Hash
+
+		for root := range t.layers {
+			if path, ok := t.path(root); ok && len(path) > len(longest) {
+				longest = path
+			}
+		}
+
+		if len(longest) <= maxDiffLayers {
+			return
+		}
+
+		if err := t.flatten(longest[len(longest)-1]); err != nil {
+			// the disk layer is rebuilt on the next start, the reads fall back to the trie meanwhile
+			t.logger.Error("failed to flatten the diff layer", "err", err)
+			t.stale = true
+
+			return
+		}
+	}
+}
+
+// flatten writes the diff layer sitting on the disk layer into it and drops
+// the layers which aren't built on top of the new disk layer [not thread-safe]
+func (t *FlatTree) flatten(root types.Hash) error {
+	layer := t.layers[root]
+
+	// the disk layer is invalidated until the diff is written completely
+	t.storage.Put(snapRootKey, types.ZeroHash.Bytes())
+
+	for accountHash := range layer.wiped {
+		if err := t.storage.DeletePrefix(flatStoragePrefix(accountHash)); err != nil {
+			return err
+		}
+	}
+
+	batch := t.storage.Batch()
+
+	for accountHash, data := range layer.accounts {
+		batch.Put(flatAccountKey(accountHash), data)
+	}
+
+	for accountHash, slots := range layer.storage {
+		for slotHash, value := range slots {
+			// the deleted slots are written empty
+			batch.Put(flatStorageKey(accountHash, slotHash), bytes.TrimLeft(value.Bytes(), "\x00"))
+		}
+	}
+
+	batch.Put(snapRootKey, root.Bytes())
+	batch.Write()
+
+	t.diskRoot = root
+	delete(t.layers, root)
+
+	for r := range t.layers {
+		if _, ok := t.path(r); !ok {
+			delete(t.layers, r)
+		}
+	}
+
+	return nil
+}
+
+// generate rebuilds the disk layer from the trie of the given state, it returns the number of the accounts
+func (t *FlatTree) generate(root types.Hash, trieStorage Storage) (int, error) {
+	t.storage.Put(snapRootKey, types.ZeroHash.Bytes())
+
+	if err := t.storage.DeletePrefix(snapAccountPrefix); err != nil {
+		return 0, err
+	}
+
+	if err := t.storage.DeletePrefix(snapStoragePrefix); err != nil {
+		return 0, err
+	}
+
+	var (
+		batch   = t.storage.Batch()
+		entries = 0
+	)
+
+	put := func(k, v []byte) {
+		batch.Put(k, v)
+
+		if entries++; entries%flatBatchSize == 0 {
+			batch.Write()
+			batch = t.storage.Batch()
+		}
+	}
+
+	accounts := 0
+
+	if root != types.EmptyRootHash {
+		if err := walkLeaves(trieStorage, root, func(accountKey, data []byte) error {
+			var account state.Account
+			if err := account.UnmarshalRlp(data); err != nil {
+				return err
+			}
+
+			accountHash := types.BytesToHash(accountKey)
+			put(flatAccountKey(accountHash), data)
+			accounts++
+
+			if account.Root == types.EmptyRootHash || account.Root == types.ZeroHash {
+				return nil
+			}
+
+			return walkLeaves(trieStorage, account.Root, func(slotKey, value []byte) error {
+				p := &fastrlp.Parser{}
+
+				v, err := p.Parse(value)
+				if err != nil {
+					return err
+				}
+
+				res, err := v.GetBytes(nil)
+				if err != nil {
+					return err
+				}
+
+				put(flatStorageKey(accountHash, types.BytesToHash(slotKey)), res)
+
+				return nil
+			})
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	batch.Put(snapRootKey, root.Bytes())
+	batch.Write()
+
+	return accounts, nil
+}
+
+func flatAccountKey(accountHash types.Hash) []byte {
+	return append(append([]byte{}, snapAccountPrefix...), accountHash.Bytes()...)
+}
+
+// flatStoragePrefix is the prefix of the flat storage slots of the account
+func flatStoragePrefix(accountHash types.Hash) []byte {
+	return append(append([]byte{}, snapStoragePrefix...), accountHash.Bytes()...)
+}
+
+func flatStorageKey(accountHash, slotHash types.Hash) []byte {
+	return append(flatStoragePrefix(accountHash), slotHash.Bytes()...)
+}
+
+// walkLeaves calls fn with the key and the value of each leaf of the stored trie
+func walkLeaves(storage Storage, root types.Hash, fn func(key, value []byte) error) error {
+	node, ok, err := GetNode(root.Bytes(), storage)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("%w at hash %s", errStateNotFound, root)
+	}
+
+	return walkNode(storage, node, []byte{}, fn)
+}
+
+func walkNode(storage Storage, node Node, path []byte, fn func(key, value []byte) error) error {
+	return visitNode(node, path, func(path, hash []byte) error {
+		child, ok, err := GetNode(hash, storage)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return fmt.Errorf("%w at hash %s", errStateNotFound, types.BytesToHash(hash))
+		}
+
+		return walkNode(storage, child, path, fn)
+	}, func(path, value []byte) error {
+		return fn(hexNibblesToBytes(path), value)
+	})
+}
+
+// hexNibblesToBytes packs the nibbles (without the terminator flag) into bytes
+func hexNibblesToBytes(nibbles []byte) []byte {
+	res := make([]byte, len(nibbles)/2)
+	for i := range res {
+		res[i] = nibbles[2*i]<<4 | nibbles[2*i+1]
+	}
+
+	return res
+}
@@ -0,0 +1,278 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	flatTestAccounts = []types.Address{types.StringToAddress("1"), types.StringToAddress("2"), types.StringToAddress("3")}
+	flatTestSlots    = []types.Hash{types.StringToHash("1"), types.StringToHash("2")}
+)
+
+// flatTestObjects returns the changes of the block, the accounts are updated, deleted and
+// recreated with the empty storage, so each kind of change is applied a few times
+func flatTestObjects(t *testing.T, snap state.Snapshot, number uint64) []*state.Object {
+	t.Helper()
+
+	objs := []*state.Object{}
+
+	for i, addr := range flatTestAccounts {
+		if (number+uint64(i))%7 == 0 {
+			objs = append(objs, &state.Object{Address: addr, Deleted: true})
+
+			continue
+		}
+
+		storageRoot := types.EmptyRootHash
+
+		account, err := snap.GetAccount(addr)
+		require.NoError(t, err)
+
+		// recreate the account without its storage
+		if account != nil && (number+uint64(i))%5 != 0 {
+			storageRoot = account.Root
+		}
+
+		obj := &state.Object{
+			Address:  addr,
+			Balance:  new(big.Int).SetUint64(number),
+			CodeHash: types.EmptyCodeHash,
+			Root:     storageRoot,
+		}
+
+		for j, slot := range flatTestSlots {
+			entry := &state.StorageObject{Key: slot.Bytes()}
+
+			if (number+uint64(j))%3 == 0 {
+				entry.Deleted = true
+			} else {
+				entry.Val = types.StringToHash(big.NewInt(int64(number)).String()).Bytes()
+			}
+
+			// leave some slots untouched
+			if (number+uint64(i+j))%4 != 0 {
+				obj.Storage = append(obj.Storage, entry)
+			}
+		}
+
+		objs = append(objs, obj)
+	}
+
+	return objs
+}
+
+// assertFlatReads checks the reads served by the flat snapshot match the ones of the trie
+func assertFlatReads(t *testing.T, flat, trie state.Snapshot) {
+	t.Helper()
+
+	for _, addr := range flatTestAccounts {
+		expected, err := trie.GetAccount(addr)
+		require.NoError(t, err)
+
+		account, err := flat.GetAccount(addr)
+		require.NoError(t, err)
+		assert.Equal(t, expected, account)
+
+		if expected == nil {
+			continue
+		}
+
+		for _, slot := range flatTestSlots {
+			assert.Equal(t, trie.GetStorage(addr, expected.Root, slot), flat.GetStorage(addr, expected.Root, slot))
+		}
+	}
+}
+
+func TestFlatTree_Reads(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+
+	tree, err := NewFlatTree(storage, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	tree.Init(types.EmptyRootHash, storage)
+	tree.wg.Wait()
+
+	st := NewState(storage)
+	st.SetFlatTree(tree)
+
+	trieState := NewState(storage)
+
+	var (
+		snap  = st.NewSnapshot()
+		roots = []types.Hash{types.EmptyRootHash}
+	)
+
+	for number := uint64(1); number <= maxDiffLayers+20; number++ {
+		var root []byte
+
+		snap, root = snap.Commit(flatTestObjects(t, snap, number))
+		roots = append(roots, types.BytesToHash(root))
+
+		trieSnap, err := trieState.NewSnapshotAt(types.BytesToHash(root))
+		require.NoError(t, err)
+
+		assertFlatReads(t, snap, trieSnap)
+	}
+
+	// the bottom layers are flattened into the disk layer
+	assert.Equal(t, roots[20], tree.diskRoot)
+	assert.Len(t, tree.layers, maxDiffLayers)
+
+	_, ok := tree.Account(roots[19], types.BytesToHash(hashit(flatTestAccounts[0].Bytes())))
+	assert.False(t, ok)
+
+	// the states covered by the tree are read from it
+	for _, root := range roots[20:] {
+		flatSnap, err := st.NewSnapshotAt(root)
+		require.NoError(t, err)
+
+		trieSnap, err := trieState.NewSnapshotAt(root)
+		require.NoError(t, err)
+
+		assertFlatReads(t, flatSnap, trieSnap)
+	}
+}
+
+func TestFlatTree_Generate(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	st := NewState(storage)
+	snap := st.NewSnapshot()
+
+	var root []byte
+
+	for number := uint64(1); number <= 10; number++ {
+		snap, root = snap.Commit(flatTestObjects(t, snap, number))
+	}
+
+	tree, err := NewFlatTree(storage, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	tree.Init(types.BytesToHash(root), storage)
+	tree.wg.Wait()
+
+	require.False(t, tree.stale)
+
+	flatState := NewState(storage)
+	flatState.SetFlatTree(tree)
+
+	flatSnap, err := flatState.NewSnapshotAt(types.BytesToHash(root))
+	require.NoError(t, err)
+
+	// make sure the reads don't fall back to the trie
+	for _, addr := range flatTestAccounts {
+		_, ok := tree.Account(types.BytesToHash(root), types.BytesToHash(hashit(addr.Bytes())))
+		assert.True(t, ok)
+	}
+
+	assertFlatReads(t, flatSnap, snap)
+}
+
+func TestFlatTree_Close(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+
+	tree, err := NewFlatTree(storage, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	tree.Init(types.EmptyRootHash, storage)
+	tree.wg.Wait()
+
+	st := NewState(storage)
+	st.SetFlatTree(tree)
+
+	snap := st.NewSnapshot()
+
+	var root []byte
+
+	for number := uint64(1); number <= 5; number++ {
+		snap, root = snap.Commit(flatTestObjects(t, snap, number))
+	}
+
+	require.NoError(t, tree.Close(types.BytesToHash(root)))
+	assert.Empty(t, tree.layers)
+
+	// the snapshot of the head state is loaded without the generation
+	tree, err = NewFlatTree(storage, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	tree.Init(types.BytesToHash(root), storage)
+	assert.False(t, tree.stale)
+
+	flatState := NewState(storage)
+	flatState.SetFlatTree(tree)
+
+	flatSnap, err := flatState.NewSnapshotAt(types.BytesToHash(root))
+	require.NoError(t, err)
+
+	assertFlatReads(t, flatSnap, snap)
+}
+
+func TestFlatTree_StorageWithoutTrie(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+
+	tree, err := NewFlatTree(storage, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	tree.Init(types.EmptyRootHash, storage)
+	tree.wg.Wait()
+
+	st := NewState(storage)
+	st.SetFlatTree(tree)
+
+	snap := st.NewSnapshot()
+
+	var root []byte
+
+	for number := uint64(1); number <= 3; number++ {
+		snap, root = snap.Commit(flatTestObjects(t, snap, number))
+	}
+
+	// the trie nodes are missing (e.g. pruned), the storage is read from the flat snapshot only
+	flatOnly := NewState(NewMemoryStorage())
+	flatOnly.SetFlatTree(tree)
+
+	flatSnap := &Snapshot{state: flatOnly, trie: flatOnly.newTrie(), root: types.BytesToHash(root)}
+
+	read := 0
+
+	for _, addr := range flatTestAccounts {
+		account, err := snap.GetAccount(addr)
+		require.NoError(t, err)
+
+		if account == nil {
+			continue
+		}
+
+		for _, slot := range flatTestSlots {
+			expected := snap.GetStorage(addr, account.Root, slot)
+			if expected != (types.Hash{}) {
+				read++
+			}
+
+			assert.Equal(t, expected, flatSnap.GetStorage(addr, account.Root, slot))
+		}
+	}
+
+	require.NotZero(t, read)
+}
+
+func TestNewFlatTree_NotSupported(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewFlatTree(&struct{ Storage }{NewMemoryStorage()}, hclog.NewNullLogger())
+	assert.ErrorIs(t, err, errNoFlatStorage)
+}
@@ -0,0 +1,125 @@
+package itrie
+
+import (
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/cockroachdb/pebble"
+	"github.com/hashicorp/go-hclog"
+
+	pebbledb "github.com/0xPolygon/polygon-edge/blockchain/storage/pebble"
+)
+
+// PebbleStorage is a k/v storage on disk using pebble
+type PebbleStorage struct {
+	db *pebble.DB
+}
+
+// PebbleBatch is a batch write for pebble
+type PebbleBatch struct {
+	batch *pebble.Batch
+}
+
+func (b *PebbleBatch) Put(k, v []byte) {
+	_ = b.batch.Set(k, v, nil)
+}
+
+func (b *PebbleBatch) Write() {
+	_ = b.batch.Commit(pebble.NoSync)
+}
+
+func NewPebbleStorage(path string, logger hclog.Logger) (Storage, error) {
+	db, err := pebbledb.Open(path, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PebbleStorage{db}, nil
+}
+
+func (p *PebbleStorage) SetCode(hash types.Hash, code []byte) {
+	p.Put(append(codePrefix, hash.Bytes()...), code)
+}
+
+func (p *PebbleStorage) GetCode(hash types.Hash) ([]byte, bool) {
+	return p.Get(append(codePrefix, hash.Bytes()...))
+}
+
+func (p *PebbleStorage) Batch() Batch {
+	return &PebbleBatch{batch: p.db.NewBatch()}
+}
+
+func (p *PebbleStorage) Put(k, v []byte) {
+	_ = p.db.Set(k, v, pebble.NoSync)
+}
+
+func (p *PebbleStorage) Get(k []byte) ([]byte, bool) {
+	data, closer, err := p.db.Get(k)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, false
+		} else {
+			panic(err) //nolint:gocritic
+		}
+	}
+
+	defer closer.Close()
+
+	// the returned slice is valid until the closer is closed
+	return append([]byte{}, data...), true
+}
+
+func (p *PebbleStorage) ForEachNode(fn func(key []byte) error) error {
+	iter, err := p.db.NewIter(nil)
+	if err != nil {
+		return err
+	}
+
+	defer iter.Close()
+
+	for iter.First(); iter.Valid(); iter.Next() {
+		if !isNodeKey(iter.Key()) {
+			continue
+		}
+
+		// the iterator reuses the key buffer
+		if err := fn(append([]byte{}, iter.Key()...)); err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
+func (p *PebbleStorage) DeleteNodes(keys [][]byte) error {
+	batch := p.db.NewBatch()
+	for _, key := range keys {
+		if err := batch.Delete(key, nil); err != nil {
+			return err
+		}
+	}
+
+	return batch.Commit(pebble.NoSync)
+}
+
+func (p *PebbleStorage) DeletePrefix(prefix []byte) error {
+	return p.db.DeleteRange(prefix, prefixUpperBound(prefix), pebble.NoSync)
+}
+
+func (p *PebbleStorage) Close() error {
+	return p.db.Close()
+}
+
+// prefixUpperBound returns the smallest key greater than all the keys with the prefix,
+// nil if there is no such key
+func prefixUpperBound(prefix []byte) []byte {
+	upper := append([]byte{}, prefix...)
+
+	for i := len(upper) - 1; i >= 0; i-- {
+		if upper[i]++; upper[i] != 0 {
+			return upper[:i+1]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package itrie
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixUpperBound(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		prefix   []byte
+		expected []byte
+	}{
+		{"plain", []byte{0x01, 0x02}, []byte{0x01, 0x03}},
+		{"carry", []byte{0x01, 0xff}, []byte{0x02}},
+		{"no upper bound", []byte{0xff, 0xff}, nil},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, prefixUpperBound(tc.prefix))
+		})
+	}
+}
+
+func TestPebbleStorage(t *testing.T) {
+	t.Parallel()
+
+	storage, err := NewPebbleStorage(t.TempDir(), hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	defer storage.Close()
+
+	pebble, ok := storage.(*PebbleStorage)
+	require.True(t, ok)
+
+	node := types.StringToHash("1").Bytes()
+
+	batch := storage.Batch()
+	batch.Put(node, []byte{0x1})
+	batch.Put([]byte("sa1"), []byte{0x2})
+	batch.Put([]byte("sb1"), []byte{0x3})
+	batch.Write()
+
+	storage.SetCode(types.StringToHash("2"), []byte{0x4})
+
+	// only the trie nodes are iterated
+	nodes := [][]byte{}
+	require.NoError(t, pebble.ForEachNode(func(key []byte) error {
+		nodes = append(nodes, key)
+
+		return nil
+	}))
+	assert.Equal(t, [][]byte{node}, nodes)
+
+	require.NoError(t, pebble.DeletePrefix([]byte("sa")))
+
+	_, ok = storage.Get([]byte("sa1"))
+	assert.False(t, ok)
+
+	value, ok := storage.Get([]byte("sb1"))
+	assert.True(t, ok)
+	assert.Equal(t, []byte{0x3}, value)
+
+	require.NoError(t, pebble.DeleteNodes(nodes))
+
+	_, ok = storage.Get(node)
+	assert.False(t, ok)
+
+	code, ok := storage.GetCode(types.StringToHash("2"))
+	assert.True(t, ok)
+	assert.Equal(t, []byte{0x4}, code)
+}
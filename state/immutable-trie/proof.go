@@ -0,0 +1,201 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/umbracle/fastrlp"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrProofNodeNotFound = errors.New("proof node not found")
+	ErrInvalidProofNode  = errors.New("invalid proof node")
+)
+
+// Prove returns the merkle proof of the key (EIP-1186), which is the list of the RLP encoded nodes
+// on the path from the root to the value. If the key is not in the trie, the returned nodes prove its absence
+func (t *Trie) Prove(key []byte, storage Storage) ([][]byte, error) {
+	txn := t.Txn(storage)
+
+	h, ok := hasherPool.Get().(*hasher)
+	if !ok {
+		return nil, errors.New("invalid type assertion")
+	}
+
+	defer func() {
+		h.ReleaseArenas(0)
+		hasherPool.Put(h)
+	}()
+
+	arena, _ := h.AcquireArena()
+
+	var (
+		proof [][]byte
+		node  = txn.root
+		path  = bytesToHexNibbles(key)
+	)
+
+	for node != nil {
+		if v, ok := node.(*ValueNode); ok {
+			if !v.hash {
+				// the value has been reached
+				break
+			}
+
+			nc, ok, err := GetNode(v.buf, storage)
+			if err != nil {
+				return nil, err
+			}
+
+			if !ok {
+				return nil, fmt.Errorf("trie node %s not found", hex.EncodeToHex(v.buf))
+			}
+
+			node = nc
+
+			continue
+		}
+
+		enc := txn.encodeNode(node, h, arena)
+		arena.Reset()
+
+		// the nodes shorter than a hash are embedded in the parent, the root is always included
+		if len(proof) == 0 || len(enc) >= 32 {
+			proof = append(proof, enc)
+		}
+
+		switch n := node.(type) {
+		case *ShortNode:
+			if len(path) < len(n.key) || !bytes.Equal(path[:len(n.key)], n.key) {
+				node = nil
+			} else {
+				node = n.child
+				path = path[len(n.key):]
+			}
+
+		case *FullNode:
+			if len(path) == 0 {
+				node = nil
+			} else {
+				node = n.getEdge(path[0])
+				path = path[1:]
+			}
+
+		default:
+			return nil, fmt.Errorf("unknown node type %v", n)
+		}
+	}
+
+	return proof, nil
+}
+
+// encodeNode returns the RLP encoding of the node in which the children
+// are either referenced by their hash or embedded, as it is done for hashing
+func (t *Txn) encodeNode(node Node, h *hasher, a *fastrlp.Arena) []byte {
+	val := a.NewArray()
+
+	switch n := node.(type) {
+	case *ShortNode:
+		val.Set(a.NewBytes(encodeCompact(n.key)))
+		val.Set(t.hash(n.child, h, a, 1))
+
+	case *FullNode:
+		for _, child := range n.children {
+			if child == nil {
+				val.Set(a.NewNull())
+			} else {
+				val.Set(t.hash(child, h, a, 1))
+			}
+		}
+
+		if n.value == nil {
+			val.Set(a.NewNull())
+		} else {
+			val.Set(t.hash(n.value, h, a, 1))
+		}
+	}
+
+	return val.MarshalTo(nil)
+}
+
+// VerifyProof checks the merkle proof of the key against the root
+// and returns the value of the key, which is nil if the proof shows that the key is not in the trie
+func VerifyProof(root types.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	if root == types.EmptyRootHash && len(proof) == 0 {
+		return nil, nil
+	}
+
+	nodes := make(map[types.Hash][]byte, len(proof))
+	for _, node := range proof {
+		nodes[types.BytesToHash(crypto.Keccak256(node))] = node
+	}
+
+	p := &fastrlp.Parser{}
+	path := bytesToHexNibbles(key)
+	hash := root
+
+	for {
+		data, ok := nodes[hash]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrProofNodeNotFound, hash)
+		}
+
+		v, err := p.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+
+		// walk the node and its embedded children until a reference to the next proof node is found
+		for next := false; !next; {
+			switch v.Elems() {
+			case 2:
+				nodeKey := decodeCompact(v.Get(0).Raw())
+				if len(path) < len(nodeKey) || !bytes.Equal(path[:len(nodeKey)], nodeKey) {
+					return nil, nil
+				}
+
+				path = path[len(nodeKey):]
+
+				if hasTerminator(nodeKey) {
+					return append([]byte{}, v.Get(1).Raw()...), nil
+				}
+
+				v = v.Get(1)
+
+			case 17:
+				if len(path) == 0 {
+					return nil, ErrInvalidProofNode
+				}
+
+				idx := path[0]
+				path = path[1:]
+
+				v = v.Get(int(idx))
+				if v.Type() == fastrlp.TypeBytes && len(v.Raw()) == 0 {
+					return nil, nil
+				}
+
+				if idx == 16 {
+					return append([]byte{}, v.Raw()...), nil
+				}
+
+			default:
+				return nil, ErrInvalidProofNode
+			}
+
+			if v.Type() == fastrlp.TypeBytes {
+				if len(v.Raw()) != types.HashLength {
+					return nil, ErrInvalidProofNode
+				}
+
+				hash = types.BytesToHash(v.Raw())
+				next = true
+			}
+		}
+	}
+}
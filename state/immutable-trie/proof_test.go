@@ -0,0 +1,194 @@
+package itrie
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// buildProofTrie writes the key values into the storage and returns the committed trie and its root
+func buildProofTrie(t *testing.T, storage Storage, kv map[string][]byte) (*Trie, types.Hash) {
+	t.Helper()
+
+	batch := storage.Batch()
+
+	txn := NewTrie().Txn(storage)
+	txn.batch = batch
+
+	for k, v := range kv {
+		txn.Insert([]byte(k), v)
+	}
+
+	root, err := txn.Hash()
+	require.NoError(t, err)
+
+	batch.Write()
+
+	return txn.Commit(), types.BytesToHash(root)
+}
+
+func TestTrie_Prove(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		size int
+	}{
+		{"single key", 1},
+		{"embedded nodes", 5},
+		{"many keys", 500},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			kv := make(map[string][]byte, c.size)
+
+			for i := 0; i < c.size; i++ {
+				key := crypto.Keccak256([]byte(fmt.Sprintf("key-%d", i)))
+				// short values make the nodes be embedded into their parents
+				kv[string(key)] = []byte{byte(i%250) + 1}
+			}
+
+			storage := NewMemoryStorage()
+			trie, root := buildProofTrie(t, storage, kv)
+
+			// the trie loaded from the storage is made of hash references
+			node, ok, err := GetNode(root.Bytes(), storage)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			for _, tr := range []*Trie{trie, {root: node}} {
+				for k, v := range kv {
+					proof, err := tr.Prove([]byte(k), storage)
+					require.NoError(t, err)
+					require.NotEmpty(t, proof)
+
+					value, err := VerifyProof(root, []byte(k), proof)
+					require.NoError(t, err)
+					require.Equal(t, v, value)
+				}
+
+				// proof of absence
+				missing := crypto.Keccak256([]byte("missing"))
+
+				proof, err := tr.Prove(missing, storage)
+				require.NoError(t, err)
+
+				value, err := VerifyProof(root, missing, proof)
+				require.NoError(t, err)
+				require.Nil(t, value)
+			}
+		})
+	}
+}
+
+func TestTrie_ProveEmpty(t *testing.T) {
+	t.Parallel()
+
+	key := crypto.Keccak256([]byte("key"))
+
+	proof, err := NewTrie().Prove(key, NewMemoryStorage())
+	require.NoError(t, err)
+	require.Empty(t, proof)
+
+	value, err := VerifyProof(types.EmptyRootHash, key, proof)
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
+
+func TestVerifyProof_Invalid(t *testing.T) {
+	t.Parallel()
+
+	kv := make(map[string][]byte)
+
+	for i := 0; i < 100; i++ {
+		kv[string(crypto.Keccak256([]byte{byte(i)}))] = crypto.Keccak256([]byte{byte(i), 1})
+	}
+
+	storage := NewMemoryStorage()
+	trie, root := buildProofTrie(t, storage, kv)
+
+	key := crypto.Keccak256([]byte{1})
+
+	proof, err := trie.Prove(key, storage)
+	require.NoError(t, err)
+	require.Greater(t, len(proof), 1)
+
+	// wrong root
+	_, err = VerifyProof(types.StringToHash("0x1"), key, proof)
+	require.ErrorIs(t, err, ErrProofNodeNotFound)
+
+	// missing node
+	_, err = VerifyProof(root, key, proof[:len(proof)-1])
+	require.ErrorIs(t, err, ErrProofNodeNotFound)
+
+	// tampered node
+	tampered := make([][]byte, len(proof))
+	copy(tampered, proof)
+
+	last := append([]byte{}, proof[len(proof)-1]...)
+	last[len(last)-1]++
+	tampered[len(tampered)-1] = last
+
+	_, err = VerifyProof(root, key, tampered)
+	require.ErrorIs(t, err, ErrProofNodeNotFound)
+}
+
+func TestSnapshot_GetProof(t *testing.T) {
+	t.Parallel()
+
+	addr := types.StringToAddress("0x1")
+	slot := types.StringToHash("0x2")
+
+	snap, root := NewState(NewMemoryStorage()).NewSnapshot().Commit([]*state.Object{
+		{
+			Address:  addr,
+			Balance:  big.NewInt(10),
+			Nonce:    1,
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+			Storage: []*state.StorageObject{
+				{Key: slot.Bytes(), Val: types.StringToHash("0x3").Bytes()},
+			},
+		},
+	})
+
+	proof, err := snap.GetAccountProof(addr)
+	require.NoError(t, err)
+
+	data, err := VerifyProof(types.BytesToHash(root), crypto.Keccak256(addr.Bytes()), proof)
+	require.NoError(t, err)
+
+	var account state.Account
+	require.NoError(t, account.UnmarshalRlp(data))
+	require.Equal(t, uint64(1), account.Nonce)
+	require.Equal(t, uint64(10), account.Balance.Uint64())
+
+	// the value is stored RLP encoded
+	proof, err = snap.GetStorageProof(addr, account.Root, slot)
+	require.NoError(t, err)
+
+	data, err = VerifyProof(account.Root, crypto.Keccak256(slot.Bytes()), proof)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x3}, data)
+
+	// the account which doesn't exist
+	missing := types.StringToAddress("0x2")
+
+	proof, err = snap.GetAccountProof(missing)
+	require.NoError(t, err)
+
+	data, err = VerifyProof(types.BytesToHash(root), crypto.Keccak256(missing.Bytes()), proof)
+	require.NoError(t, err)
+	require.Nil(t, data)
+}
@@ -0,0 +1,404 @@
+package itrie
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+// pruneBatchSize is the max number of the trie nodes removed at once
+const pruneBatchSize = 1024
+
+var (
+	errNotPrunable = errors.New("the trie storage doesn't support pruning")
+)
+
+// PrunerConfig is the configuration of the state pruning
+type PrunerConfig struct {
+	// Retain is the number of the most recent blocks whose state is kept
+	Retain uint64
+	// CheckpointInterval is the interval of the blocks whose state is kept forever, disabled if 0
+	CheckpointInterval uint64
+}
+
+// retainedRoot is the state root of a block in the retained window
+type retainedRoot struct {
+	number uint64
+	root   types.Hash
+}
+
+// Pruner is the trie storage removing the nodes which aren't reachable from the state roots
+// of the most recent blocks and the checkpoints. Once in Retain blocks, the reachable nodes
+// are marked and the rest are swept from the storage in the background.
+//
+// The nodes written during the running and the previous prune cycle are never removed,
+// so the states committed by the block import which aren't retained yet are kept.
+type Pruner struct {
+	prunableStorage
+
+	logger hclog.Logger
+	config PrunerConfig
+
+	lock sync.Mutex
+	// window holds the state roots of the most recent blocks, sorted by number
+	window []retainedRoot
+	// checkpoints are the state roots kept forever
+	checkpoints map[types.Hash]struct{}
+	// released are the state roots which left the window, the queries at them are refused
+	released map[types.Hash]struct{}
+	// written and prevWritten are the node keys written since the start of the running and the previous cycle
+	written     map[string]struct{}
+	prevWritten map[string]struct{}
+	// lastPruned is the block number the last prune cycle started at
+	lastPruned uint64
+
+	running atomic.Bool
+	wg      sync.WaitGroup
+	// swept is called after the nodes are removed
+	swept func()
+}
+
+// NewPruner wraps the storage with the pruner, the storage has to support the pruning
+func NewPruner(storage Storage, config PrunerConfig, logger hclog.Logger) (*Pruner, error) {
+	prunable, ok := storage.(prunableStorage)
+	if !ok {
+		return nil, errNotPrunable
+	}
+
+	if config.Retain == 0 {
+		return nil, errors.New("the number of the retained states must be positive")
+	}
+
+	return &Pruner{
+		prunableStorage: prunable,
+		logger:          logger.Named("pruner"),
+		config:          config,
+		checkpoints:     make(map[types.Hash]struct{}),
+		released:        make(map[types.Hash]struct{}),
+		written:         make(map[string]struct{}),
+		prevWritten:     make(map[string]struct{}),
+	}, nil
+}
+
+func (p *Pruner) Put(k, v []byte) {
+	p.lock.Lock()
+	p.written[string(k)] = struct{}{}
+	p.lock.Unlock()
+
+	p.prunableStorage.Put(k, v)
+}
+
+func (p *Pruner) Batch() Batch {
+	return &prunerBatch{Batch: p.prunableStorage.Batch(), pruner: p}
+}
+
+// Close waits for the running prune cycle and closes the storage
+func (p *Pruner) Close() error {
+	p.wg.Wait()
+
+	return p.prunableStorage.Close()
+}
+
+// Init adds the state roots of the checkpoints and of the most recent blocks up to the head,
+// getRoot returns the state root of the canonical block with the given number
+func (p *Pruner) Init(head uint64, getRoot func(number uint64) (types.Hash, bool)) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	first := uint64(0)
+	if head >= p.config.Retain {
+		first = head - p.config.Retain + 1
+	}
+
+	if p.config.CheckpointInterval > 0 {
+		for number := uint64(0); number < first; number += p.config.CheckpointInterval {
+			if root, ok := getRoot(number); ok {
+				p.checkpoints[root] = struct{}{}
+			}
+		}
+	}
+
+	for number := first; number <= head; number++ {
+		if root, ok := getRoot(number); ok {
+			p.addRoot(number, root)
+		}
+	}
+
+	// the first cycle starts once the window is filled with the imported blocks
+	p.lastPruned = head
+}
+
+// AddRoot adds the state root of the block to the retained window,
+// the roots of the blocks with the same or higher number (reorg) are replaced.
+// A prune cycle is started once in Retain blocks
+func (p *Pruner) AddRoot(number uint64, root types.Hash) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.addRoot(number, root)
+
+	if number >= p.lastPruned+p.config.Retain && p.running.CompareAndSwap(false, true) {
+		p.lastPruned = number
+
+		p.wg.Add(1)
+
+		go func() {
+			defer p.wg.Done()
+			defer p.running.Store(false)
+
+			if err := p.prune(); err != nil {
+				p.logger.Error("failed to prune the state", "err", err)
+			}
+		}()
+	}
+}
+
+// addRoot adds the state root to the window and releases the ones which left it [not thread-safe]
+func (p *Pruner) addRoot(number uint64, root types.Hash) {
+	dropped := []retainedRoot{}
+
+	// remove the replaced roots
+	for len(p.window) > 0 && p.window[len(p.window)-1].number >= number {
+		dropped = append(dropped, p.window[len(p.window)-1])
+		p.window = p.window[:len(p.window)-1]
+	}
+
+	p.window = append(p.window, retainedRoot{number: number, root: root})
+	delete(p.released, root)
+
+	if p.config.CheckpointInterval > 0 && number%p.config.CheckpointInterval == 0 {
+		p.checkpoints[root] = struct{}{}
+	}
+
+	// slide the window
+	if uint64(len(p.window)) > p.config.Retain {
+		n := uint64(len(p.window)) - p.config.Retain
+		dropped = append(dropped, p.window[:n]...)
+		p.window = append([]retainedRoot{}, p.window[n:]...)
+	}
+
+	for _, r := range dropped {
+		if !p.isRetained(r.root) {
+			p.released[r.root] = struct{}{}
+		}
+	}
+}
+
+// isReleased returns true if the state root left the retained window
+func (p *Pruner) isReleased(root types.Hash) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	_, ok := p.released[root]
+
+	return ok
+}
+
+// isRetained returns true if the state root is in the window or is a checkpoint [not thread-safe]
+func (p *Pruner) isRetained(root types.Hash) bool {
+	if _, ok := p.checkpoints[root]; ok {
+		return true
+	}
+
+	for _, r := range p.window {
+		if r.root == root {
+			return true
+		}
+	}
+
+	return false
+}
+
+// prune marks the nodes reachable from the retained roots and removes the rest
+func (p *Pruner) prune() error {
+	start := time.Now()
+
+	// start a new cycle, the nodes written since the previous one are protected
+	p.lock.Lock()
+
+	p.prevWritten, p.written = p.written, make(map[string]struct{})
+
+	roots := make([]types.Hash, 0, len(p.window)+len(p.checkpoints))
+	for _, r := range p.window {
+		roots = append(roots, r.root)
+	}
+
+	for root := range p.checkpoints {
+		roots = append(roots, root)
+	}
+
+	released := make([]types.Hash, 0, len(p.released))
+	for root := range p.released {
+		released = append(released, root)
+	}
+
+	p.lock.Unlock()
+
+	marked := make(map[types.Hash]struct{})
+
+	for _, root := range roots {
+		if err := p.mark(root.Bytes(), true, marked); err != nil {
+			return fmt.Errorf("failed to mark the nodes of the state %s: %w", root, err)
+		}
+	}
+
+	removed := 0
+	batch := make([][]byte, 0, pruneBatchSize)
+
+	if err := p.ForEachNode(func(key []byte) error {
+		if _, ok := marked[types.BytesToHash(key)]; ok {
+			return nil
+		}
+
+		if batch = append(batch, key); len(batch) < pruneBatchSize {
+			return nil
+		}
+
+		n, err := p.sweep(batch)
+		removed += n
+		batch = batch[:0]
+
+		return err
+	}); err != nil {
+		return err
+	}
+
+	n, err := p.sweep(batch)
+	removed += n
+
+	if err != nil {
+		return err
+	}
+
+	// the states of the released roots are gone, their queries fail as missing ones
+	p.lock.Lock()
+
+	for _, root := range released {
+		delete(p.released, root)
+	}
+
+	p.lock.Unlock()
+
+	if p.swept != nil {
+		p.swept()
+	}
+
+	p.logger.Info("state pruned", "retained", len(roots), "marked", len(marked), "removed", removed,
+		"duration", time.Since(start))
+
+	return nil
+}
+
+// sweep removes the nodes which weren't written since the start of the previous cycle
+func (p *Pruner) sweep(keys [][]byte) (int, error) {
+	// the nodes written concurrently are either recorded before
+	// the check or rewritten after the removal
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	unused := make([][]byte, 0, len(keys))
+
+	for _, key := range keys {
+		if _, ok := p.written[string(key)]; ok {
+			continue
+		}
+
+		if _, ok := p.prevWritten[string(key)]; ok {
+			continue
+		}
+
+		unused = append(unused, key)
+	}
+
+	if len(unused) == 0 {
+		return 0, nil
+	}
+
+	return len(unused), p.DeleteNodes(unused)
+}
+
+// mark marks the node with the given hash and its descendants, the storage
+// tries of the accounts are marked as well if it's the node of the account trie
+func (p *Pruner) mark(hash []byte, accounts bool, marked map[types.Hash]struct{}) error {
+	if _, ok := marked[types.BytesToHash(hash)]; ok {
+		return nil
+	}
+
+	node, ok, err := GetNode(hash, p)
+	if err != nil {
+		return err
+	}
+
+	// the node was already removed, i.e. the state root of a block
+	// imported before the pruning was enabled
+	if !ok {
+		return nil
+	}
+
+	marked[types.BytesToHash(hash)] = struct{}{}
+
+	return p.markNode(node, accounts, marked)
+}
+
+func (p *Pruner) markNode(node Node, accounts bool, marked map[types.Hash]struct{}) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+
+	case *ValueNode:
+		if n.hash {
+			return p.mark(n.buf, accounts, marked)
+		}
+
+		if !accounts {
+			return nil
+		}
+
+		var account state.Account
+		if err := account.UnmarshalRlp(n.buf); err != nil {
+			return err
+		}
+
+		if account.Root == types.EmptyRootHash || account.Root == types.ZeroHash {
+			return nil
+		}
+
+		return p.mark(account.Root.Bytes(), false, marked)
+
+	case *ShortNode:
+		return p.markNode(n.child, accounts, marked)
+
+	case *FullNode:
+		for _, child := range n.children {
+			if err := p.markNode(child, accounts, marked); err != nil {
+				return err
+			}
+		}
+
+		return p.markNode(n.value, accounts, marked)
+
+	default:
+		return fmt.Errorf("unknown node type %T", n)
+	}
+}
+
+// prunerBatch records the keys of the nodes written by the batch
+type prunerBatch struct {
+	Batch
+
+	pruner *Pruner
+}
+
+func (b *prunerBatch) Put(k, v []byte) {
+	b.pruner.lock.Lock()
+	b.pruner.written[string(k)] = struct{}{}
+	b.pruner.lock.Unlock()
+
+	b.Batch.Put(k, v)
+}
@@ -0,0 +1,153 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// commitStates commits a state per block, the balance and the storage slot
+// of the account are set to the block number
+func commitStates(t *testing.T, st *State, pruner *Pruner, blocks uint64) []types.Hash {
+	t.Helper()
+
+	var (
+		addr  = types.StringToAddress("1")
+		snap  = st.NewSnapshot()
+		roots = []types.Hash{types.EmptyRootHash}
+	)
+
+	for i := uint64(1); i <= blocks; i++ {
+		storageRoot := types.EmptyRootHash
+
+		account, err := snap.GetAccount(addr)
+		require.NoError(t, err)
+
+		if account != nil {
+			storageRoot = account.Root
+		}
+
+		var root []byte
+
+		snap, root = snap.Commit([]*state.Object{
+			{
+				Address:  addr,
+				Balance:  new(big.Int).SetUint64(i),
+				CodeHash: types.EmptyCodeHash,
+				Root:     storageRoot,
+				Storage: []*state.StorageObject{
+					{Key: types.StringToHash("1").Bytes(), Val: types.StringToHash(big.NewInt(int64(i)).String()).Bytes()},
+				},
+			},
+		})
+
+		roots = append(roots, types.BytesToHash(root))
+
+		pruner.AddRoot(i, types.BytesToHash(root))
+		pruner.wg.Wait()
+	}
+
+	return roots
+}
+
+func TestPruner(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		config   PrunerConfig
+		retained []uint64
+		pruned   []uint64
+	}{
+		{
+			name:     "recent blocks",
+			config:   PrunerConfig{Retain: 2},
+			retained: []uint64{5, 6},
+			pruned:   []uint64{1, 2, 3, 4},
+		},
+		{
+			name:     "checkpoints",
+			config:   PrunerConfig{Retain: 2, CheckpointInterval: 4},
+			retained: []uint64{4, 5, 6},
+			pruned:   []uint64{1, 2, 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			storage := NewMemoryStorage()
+
+			pruner, err := NewPruner(storage, tc.config, hclog.NewNullLogger())
+			require.NoError(t, err)
+
+			st := NewState(pruner)
+			roots := commitStates(t, st, pruner, 6)
+
+			// the nodes written since the start of the previous cycle are protected
+			require.NoError(t, pruner.prune())
+			require.NoError(t, pruner.prune())
+
+			for _, number := range tc.retained {
+				snap, err := st.NewSnapshotAt(roots[number])
+				require.NoError(t, err)
+
+				account, err := snap.GetAccount(types.StringToAddress("1"))
+				require.NoError(t, err)
+				assert.Equal(t, new(big.Int).SetUint64(number), account.Balance)
+				assert.Equal(
+					t,
+					types.StringToHash(big.NewInt(int64(number)).String()),
+					snap.GetStorage(types.StringToAddress("1"), account.Root, types.StringToHash("1")),
+				)
+			}
+
+			for _, number := range tc.pruned {
+				_, ok := storage.Get(roots[number].Bytes())
+				assert.False(t, ok)
+
+				_, err := st.NewSnapshotAt(roots[number])
+				assert.ErrorIs(t, err, state.ErrStatePruned)
+			}
+		})
+	}
+}
+
+func TestPruner_ReleasedRoot(t *testing.T) {
+	t.Parallel()
+
+	pruner, err := NewPruner(NewMemoryStorage(), PrunerConfig{Retain: 2}, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	// the released roots are refused before they're swept
+	pruner.running.Store(true)
+
+	st := NewState(pruner)
+	roots := commitStates(t, st, pruner, 3)
+
+	_, err = st.NewSnapshotAt(roots[1])
+	assert.ErrorIs(t, err, state.ErrStatePruned)
+
+	_, err = st.NewSnapshotAt(roots[3])
+	assert.NoError(t, err)
+
+	// the reorged root is retained again
+	pruner.AddRoot(1, roots[1])
+	assert.False(t, pruner.isReleased(roots[1]))
+	assert.True(t, pruner.isReleased(roots[3]))
+}
+
+func TestNewPruner_NotPrunable(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewPruner(&struct{ Storage }{NewMemoryStorage()}, PrunerConfig{Retain: 1}, hclog.NewNullLogger())
+	assert.ErrorIs(t, err, errNotPrunable)
+}
@@ -2,6 +2,7 @@ package itrie
 
 import (
 	"bytes"
+	"sync"
 
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/state"
@@ -12,11 +13,23 @@ import (
 type Snapshot struct {
 	state *State
 	trie  *Trie
+	root  types.Hash
+
+	// flatStorageRoots caches the storage roots of the accounts read from the flat snapshot,
+	// so the account isn't decoded again on every storage read
+	flatStorageRoots sync.Map
 }
 
 var emptyStateHash = types.StringToHash("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
 
 func (s *Snapshot) GetStorage(addr types.Address, root types.Hash, rawkey types.Hash) types.Hash {
+	key := crypto.Keccak256(rawkey.Bytes())
+
+	// the storage trie is opened only if the flat snapshot doesn't cover the state
+	if value, ok := s.getFlatStorage(addr, root, key); ok {
+		return value
+	}
+
 	var (
 		err  error
 		trie *Trie
@@ -31,8 +44,6 @@ func (s *Snapshot) GetStorage(addr types.Address, root types.Hash, rawkey types.
 		}
 	}
 
-	key := crypto.Keccak256(rawkey.Bytes())
-
 	val, ok := trie.Get(key, s.state.storage)
 	if !ok {
 		return types.Hash{}
@@ -56,8 +67,12 @@ func (s *Snapshot) GetStorage(addr types.Address, root types.Hash, rawkey types.
 func (s *Snapshot) GetAccount(addr types.Address) (*state.Account, error) {
 	key := crypto.Keccak256(addr.Bytes())
 
-	data, ok := s.trie.Get(key, s.state.storage)
+	data, ok := s.getFlatAccount(key)
 	if !ok {
+		data, ok = s.trie.Get(key, s.state.storage)
+	}
+
+	if !ok || data == nil {
 		return nil, nil
 	}
 
@@ -69,6 +84,67 @@ func (s *Snapshot) GetAccount(addr types.Address) (*state.Account, error) {
 	return &account, nil
 }
 
+// getFlatAccount returns the account RLP from the flat snapshot, nil if there is no such account.
+// The second return value is false if the flat snapshot doesn't cover the state
+func (s *Snapshot) getFlatAccount(accountHash []byte) ([]byte, bool) {
+	if s.state.flat == nil {
+		return nil, false
+	}
+
+	return s.state.flat.Account(s.root, types.BytesToHash(accountHash))
+}
+
+// getFlatStorage returns the storage value from the flat snapshot if it covers the state
+// and the storage root is the one of the account at the state
+func (s *Snapshot) getFlatStorage(addr types.Address, root types.Hash, slotHash []byte) (types.Hash, bool) {
+	if s.state.flat == nil {
+		return types.Hash{}, false
+	}
+
+	accountHash := types.BytesToHash(crypto.Keccak256(addr.Bytes()))
+
+	if accountRoot, ok := s.getFlatStorageRoot(addr, accountHash); !ok || accountRoot != root {
+		return types.Hash{}, false
+	}
+
+	return s.state.flat.Storage(s.root, accountHash, types.BytesToHash(slotHash))
+}
+
+// getFlatStorageRoot returns the storage root of the account from the flat snapshot,
+// the account is decoded once per snapshot
+func (s *Snapshot) getFlatStorageRoot(addr types.Address, accountHash types.Hash) (types.Hash, bool) {
+	if root, ok := s.flatStorageRoots.Load(addr); ok {
+		return root.(types.Hash), true //nolint:forcetypeassert
+	}
+
+	data, ok := s.state.flat.Account(s.root, accountHash)
+	if !ok || data == nil {
+		return types.Hash{}, false
+	}
+
+	var account state.Account
+	if err := account.UnmarshalRlp(data); err != nil {
+		return types.Hash{}, false
+	}
+
+	s.flatStorageRoots.Store(addr, account.Root)
+
+	return account.Root, true
+}
+
+func (s *Snapshot) GetAccountProof(addr types.Address) ([][]byte, error) {
+	return s.trie.Prove(crypto.Keccak256(addr.Bytes()), s.state.storage)
+}
+
+func (s *Snapshot) GetStorageProof(addr types.Address, root types.Hash, rawkey types.Hash) ([][]byte, error) {
+	trie, err := s.state.newTrieAt(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return trie.Prove(crypto.Keccak256(rawkey.Bytes()), s.state.storage)
+}
+
 func (s *Snapshot) GetCode(hash types.Hash) ([]byte, bool) {
 	return s.state.GetCode(hash)
 }
@@ -82,7 +158,11 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 	arena := stateArenaPool.Get()
 	defer stateArenaPool.Put(arena)
 
+	diff := s.newFlatDiff()
+
 	for _, obj := range objs {
+		diff.addObject(obj)
+
 		if obj.Deleted {
 			tt.Delete(hashit(obj.Address.Bytes()))
 		} else {
@@ -110,6 +190,8 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 						vv := arena.NewBytes(bytes.TrimLeft(entry.Val, "\x00"))
 						localTxn.Insert(k, vv.MarshalTo(nil))
 					}
+
+					diff.addSlot(obj.Address, k, entry)
 				}
 
 				accountStateRoot, _ := localTxn.Hash()
@@ -129,6 +211,7 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 			data := vv.MarshalTo(nil)
 
 			tt.Insert(hashit(obj.Address.Bytes()), data)
+			diff.setAccount(obj.Address, data)
 			arena.Reset()
 		}
 	}
@@ -142,5 +225,89 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte) {
 
 	s.state.AddState(types.BytesToHash(root), nTrie)
 
-	return &Snapshot{trie: nTrie, state: s.state}, root
+	if diff != nil && !diff.failed {
+		s.state.flat.Update(s.root, types.BytesToHash(root), diff.accounts, diff.wiped, diff.storage)
+	}
+
+	return &Snapshot{trie: nTrie, state: s.state, root: types.BytesToHash(root)}, root
+}
+
+// flatDiff collects the changes of the committed objects for the flat snapshot
+type flatDiff struct {
+	parent   *Snapshot
+	accounts map[types.Hash][]byte
+	wiped    map[types.Hash]struct{}
+	storage  map[types.Hash]map[types.Hash]types.Hash
+	// failed is set if the parent account couldn't be read, the diff is dropped then
+	failed bool
+}
+
+// newFlatDiff returns nil if the flat snapshot is disabled, the methods of the nil diff are no-op
+func (s *Snapshot) newFlatDiff() *flatDiff {
+	if s.state.flat == nil {
+		return nil
+	}
+
+	return &flatDiff{
+		parent:   s,
+		accounts: make(map[types.Hash][]byte),
+		wiped:    make(map[types.Hash]struct{}),
+		storage:  make(map[types.Hash]map[types.Hash]types.Hash),
+	}
+}
+
+// addObject records the deleted account and wipes the storage of the account
+// which was deleted or recreated, i.e. whose storage root isn't the one of the parent state
+func (d *flatDiff) addObject(obj *state.Object) {
+	if d == nil {
+		return
+	}
+
+	accountHash := types.BytesToHash(hashit(obj.Address.Bytes()))
+
+	if obj.Deleted {
+		d.accounts[accountHash] = nil
+		d.wiped[accountHash] = struct{}{}
+
+		return
+	}
+
+	parent, err := d.parent.GetAccount(obj.Address)
+	if err != nil {
+		d.failed = true
+
+		return
+	}
+
+	if parent != nil && parent.Root != obj.Root {
+		d.wiped[accountHash] = struct{}{}
+	}
+}
+
+func (d *flatDiff) setAccount(addr types.Address, data []byte) {
+	if d == nil {
+		return
+	}
+
+	d.accounts[types.BytesToHash(hashit(addr.Bytes()))] = data
+}
+
+func (d *flatDiff) addSlot(addr types.Address, slotHash []byte, entry *state.StorageObject) {
+	if d == nil {
+		return
+	}
+
+	accountHash := types.BytesToHash(hashit(addr.Bytes()))
+
+	slots, ok := d.storage[accountHash]
+	if !ok {
+		slots = make(map[types.Hash]types.Hash)
+		d.storage[accountHash] = slots
+	}
+
+	if entry.Deleted {
+		slots[types.BytesToHash(slotHash)] = types.Hash{}
+	} else {
+		slots[types.BytesToHash(slotHash)] = types.BytesToHash(bytes.TrimLeft(entry.Val, "\x00"))
+	}
 }
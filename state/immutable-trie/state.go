@@ -1,6 +1,7 @@
 package itrie
 
 import (
+	"errors"
 	"fmt"
 
 	lru "github.com/hashicorp/golang-lru"
@@ -9,9 +10,15 @@ import (
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
+var errStateNotFound = errors.New("state not found")
+
 type State struct {
 	storage Storage
 	cache   *lru.Cache
+	// pruner is set if the storage is pruned
+	pruner *Pruner
+	// flat is set if the reads are served by the flat snapshot
+	flat *FlatTree
 }
 
 func NewState(storage Storage) *State {
@@ -22,20 +29,39 @@ func NewState(storage Storage) *State {
 		cache:   cache,
 	}
 
+	if pruner, ok := storage.(*Pruner); ok {
+		s.pruner = pruner
+		// the cached tries may reference the removed nodes
+		pruner.swept = cache.Purge
+	}
+
 	return s
 }
 
+// SetFlatTree makes the snapshots read the accounts and the storage from the flat snapshot when it covers them
+func (s *State) SetFlatTree(flat *FlatTree) {
+	s.flat = flat
+}
+
 func (s *State) NewSnapshot() state.Snapshot {
-	return &Snapshot{state: s, trie: s.newTrie()}
+	return &Snapshot{state: s, trie: s.newTrie(), root: types.EmptyRootHash}
 }
 
 func (s *State) NewSnapshotAt(root types.Hash) (state.Snapshot, error) {
+	if s.pruner != nil && s.pruner.isReleased(root) {
+		return nil, fmt.Errorf("%w: %s", state.ErrStatePruned, root)
+	}
+
 	t, err := s.newTrieAt(root)
 	if err != nil {
+		if s.pruner != nil && errors.Is(err, errStateNotFound) {
+			return nil, fmt.Errorf("%w: %s", state.ErrStatePruned, root)
+		}
+
 		return nil, err
 	}
 
-	return &Snapshot{state: s, trie: t}, nil
+	return &Snapshot{state: s, trie: t, root: root}, nil
 }
 
 func (s *State) newTrie() *Trie {
@@ -77,7 +103,7 @@ func (s *State) newTrieAt(root types.Hash) (*Trie, error) {
 	}
 
 	if !ok {
-		return nil, fmt.Errorf("state not found at hash %s", root)
+		return nil, fmt.Errorf("%w at hash %s", errStateNotFound, root)
 	}
 
 	t := &Trie{
@@ -2,12 +2,14 @@ package itrie
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
 	"github.com/umbracle/fastrlp"
 )
 
@@ -34,6 +36,30 @@ type Storage interface {
 	Close() error
 }
 
+// prunableStorage is the storage whose trie nodes can be iterated and removed
+type prunableStorage interface {
+	Storage
+
+	// ForEachNode calls fn with the key of each stored trie node, the iteration stops on the first error
+	ForEachNode(fn func(key []byte) error) error
+	// DeleteNodes removes the trie nodes with the given keys
+	DeleteNodes(keys [][]byte) error
+}
+
+// flatStorage is the storage of the flat state snapshot
+type flatStorage interface {
+	Storage
+
+	// DeletePrefix removes the entries whose keys start with the prefix
+	DeletePrefix(prefix []byte) error
+}
+
+// isNodeKey returns true if the key is the one of a trie node, the nodes are stored by hash
+// while the rest of the entries (i.e. code) have a prefix
+func isNodeKey(key []byte) bool {
+	return len(key) == types.HashLength
+}
+
 // KVStorage is a k/v storage on memory using leveldb
 type KVStorage struct {
 	db *leveldb.DB
@@ -82,6 +108,49 @@ func (kv *KVStorage) Get(k []byte) ([]byte, bool) {
 	return data, true
 }
 
+func (kv *KVStorage) ForEachNode(fn func(key []byte) error) error {
+	iter := kv.db.NewIterator(nil, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		if !isNodeKey(iter.Key()) {
+			continue
+		}
+
+		// the iterator reuses the key buffer
+		if err := fn(append([]byte{}, iter.Key()...)); err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
+func (kv *KVStorage) DeleteNodes(keys [][]byte) error {
+	batch := &leveldb.Batch{}
+	for _, key := range keys {
+		batch.Delete(key)
+	}
+
+	return kv.db.Write(batch, nil)
+}
+
+func (kv *KVStorage) DeletePrefix(prefix []byte) error {
+	iter := kv.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	batch := &leveldb.Batch{}
+	for iter.Next() {
+		batch.Delete(iter.Key())
+	}
+
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	return kv.db.Write(batch, nil)
+}
+
 func (kv *KVStorage) Close() error {
 	return kv.db.Close()
 }
@@ -152,6 +221,61 @@ func (m *memStorage) Batch() Batch {
 	return &memBatch{db: &m.db, l: new(sync.Mutex)}
 }
 
+func (m *memStorage) ForEachNode(fn func(key []byte) error) error {
+	m.l.Lock()
+
+	keys := make([][]byte, 0, len(m.db))
+
+	for k := range m.db {
+		key, err := hex.DecodeHex(k)
+		if err != nil {
+			m.l.Unlock()
+
+			return err
+		}
+
+		if isNodeKey(key) {
+			keys = append(keys, key)
+		}
+	}
+
+	m.l.Unlock()
+
+	for _, key := range keys {
+		if err := fn(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *memStorage) DeleteNodes(keys [][]byte) error {
+	m.l.Lock()
+	defer m.l.Unlock()
+
+	for _, key := range keys {
+		delete(m.db, hex.EncodeToHex(key))
+	}
+
+	return nil
+}
+
+func (m *memStorage) DeletePrefix(prefix []byte) error {
+	m.l.Lock()
+	defer m.l.Unlock()
+
+	hexPrefix := hex.EncodeToHex(prefix)
+
+	for k := range m.db {
+		if strings.HasPrefix(k, hexPrefix) {
+			delete(m.db, k)
+		}
+	}
+
+	return nil
+}
+
 func (m *memStorage) Close() error {
 	return nil
 }
@@ -175,6 +299,13 @@ func GetNode(root []byte, storage Storage) (Node, bool, error) {
 		return nil, false, nil
 	}
 
+	n, err := decodeStoredNode(data, storage)
+
+	return n, err == nil, err
+}
+
+// decodeStoredNode decodes the RLP encoded node as it is kept in the storage
+func decodeStoredNode(data []byte, storage Storage) (Node, error) {
 	// NOTE. We dont need to make copies of the bytes because the nodes
 	// take the reference from data itself which is a safe copy.
 	p := parserPool.Get()
@@ -182,16 +313,14 @@ func GetNode(root []byte, storage Storage) (Node, bool, error) {
 
 	v, err := p.Parse(data)
 	if err != nil {
-		return nil, false, err
+		return nil, err
 	}
 
 	if v.Type() != fastrlp.TypeArray {
-		return nil, false, fmt.Errorf("storage item should be an array")
+		return nil, fmt.Errorf("storage item should be an array")
 	}
 
-	n, err := decodeNode(v, storage)
-
-	return n, err == nil, err
+	return decodeNode(v, storage)
 }
 
 func decodeNode(v *fastrlp.Value, s Storage) (Node, error) {
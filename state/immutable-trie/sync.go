@@ -0,0 +1,265 @@
+package itrie
+
+import (
+	"bytes"
+	"container/heap"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrUnexpectedSyncNode = errors.New("unexpected trie node")
+	ErrInvalidSyncNode    = errors.New("trie node doesn't match its hash")
+	ErrTrieSyncIncomplete = errors.New("trie sync is incomplete")
+
+	errRangeFull = errors.New("range is full")
+)
+
+// SyncNode is a stored trie node along with its path (nibbles) from the root
+type SyncNode struct {
+	Path []byte
+	Data []byte
+}
+
+// GetNodeRange returns up to limit stored nodes of the trie with the given root, in the order of their paths,
+// starting from the given path. The nodes in this order are always preceded by their parents
+func GetNodeRange(storage Storage, root types.Hash, from []byte, limit int) ([]SyncNode, error) {
+	r := &rangeReader{
+		storage: storage,
+		from:    from,
+		limit:   limit,
+	}
+
+	if err := r.read([]byte{}, root.Bytes()); err != nil && !errors.Is(err, errRangeFull) {
+		return nil, err
+	}
+
+	return r.nodes, nil
+}
+
+type rangeReader struct {
+	storage Storage
+	from    []byte
+	limit   int
+	nodes   []SyncNode
+}
+
+func (r *rangeReader) read(path, hash []byte) error {
+	// the whole subtree is before the range
+	if bytes.Compare(path, r.from) < 0 && !bytes.HasPrefix(r.from, path) {
+		return nil
+	}
+
+	data, ok := r.storage.Get(hash)
+	if !ok || len(data) == 0 {
+		return fmt.Errorf("%w at hash %s", errStateNotFound, types.BytesToHash(hash))
+	}
+
+	node, err := decodeStoredNode(data, r.storage)
+	if err != nil {
+		return err
+	}
+
+	if bytes.Compare(path, r.from) >= 0 {
+		r.nodes = append(r.nodes, SyncNode{Path: path, Data: data})
+
+		if len(r.nodes) >= r.limit {
+			return errRangeFull
+		}
+	}
+
+	return visitNode(node, path, r.read, nil)
+}
+
+// TrieSync verifies and stores the nodes of a trie downloaded by the ranges of GetNodeRange.
+// Every node has to match the hash its parent references it by, so the whole trie is proven by the root hash
+type TrieSync struct {
+	storage Storage
+	root    types.Hash
+
+	// pending are the hashes of the referenced nodes which aren't downloaded yet, by their paths
+	pending map[string]types.Hash
+
+	// queue are the paths of the pending nodes, the processed ones are removed once they reach its top
+	queue pathQueue
+
+	// rootData is written last, so the root is in the storage only once the trie is complete
+	rootData []byte
+
+	// leaf is called with the key and the value of each leaf of the trie
+	leaf func(key, value []byte) error
+}
+
+// NewTrieSync creates the sync of the trie with the given root, the leaf callback is optional
+func NewTrieSync(storage Storage, root types.Hash, leaf func(key, value []byte) error) *TrieSync {
+	return &TrieSync{
+		storage: storage,
+		root:    root,
+		pending: map[string]types.Hash{"": root},
+		queue:   pathQueue{""},
+		leaf:    leaf,
+	}
+}
+
+// Next returns the path the next range of the nodes should start from,
+// false once all the nodes are downloaded
+func (s *TrieSync) Next() ([]byte, bool) {
+	for s.queue.Len() > 0 {
+		from := s.queue[0]
+		if _, ok := s.pending[from]; ok {
+			return []byte(from), true
+		}
+
+		heap.Pop(&s.queue)
+	}
+
+	return nil, false
+}
+
+// Process verifies the downloaded nodes and writes them to the storage
+func (s *TrieSync) Process(nodes []SyncNode) error {
+	batch := s.storage.Batch()
+
+	for _, n := range nodes {
+		expected, ok := s.pending[string(n.Path)]
+		if !ok {
+			return fmt.Errorf("%w at path %x", ErrUnexpectedSyncNode, n.Path)
+		}
+
+		if types.BytesToHash(crypto.Keccak256(n.Data)) != expected {
+			return fmt.Errorf("%w at path %x", ErrInvalidSyncNode, n.Path)
+		}
+
+		node, err := decodeStoredNode(n.Data, s.storage)
+		if err != nil {
+			return err
+		}
+
+		delete(s.pending, string(n.Path))
+
+		if err := visitNode(node, n.Path, s.addPending, s.addLeaf); err != nil {
+			return err
+		}
+
+		if len(n.Path) == 0 {
+			s.rootData = n.Data
+		} else {
+			batch.Put(expected.Bytes(), n.Data)
+		}
+	}
+
+	batch.Write()
+
+	return nil
+}
+
+// Commit writes the root node, once all the nodes of the trie are processed
+func (s *TrieSync) Commit() error {
+	if len(s.pending) != 0 || s.rootData == nil {
+		return fmt.Errorf("%w, %d nodes are pending", ErrTrieSyncIncomplete, len(s.pending))
+	}
+
+	s.storage.Put(s.root.Bytes(), s.rootData)
+
+	return nil
+}
+
+func (s *TrieSync) addPending(path, hash []byte) error {
+	if _, ok := s.pending[string(path)]; !ok {
+		heap.Push(&s.queue, string(path))
+	}
+
+	s.pending[string(path)] = types.BytesToHash(hash)
+
+	return nil
+}
+
+func (s *TrieSync) addLeaf(path, value []byte) error {
+	if s.leaf == nil {
+		return nil
+	}
+
+	return s.leaf(hexNibblesToBytes(path), value)
+}
+
+// pathQueue is a min-heap of the node paths
+type pathQueue []string
+
+/* Queue methods required by the heap interface */
+
+func (q *pathQueue) Len() int {
+	return len(*q)
+}
+
+func (q *pathQueue) Swap(i, j int) {
+	(*q)[i], (*q)[j] = (*q)[j], (*q)[i]
+}
+
+func (q *pathQueue) Less(i, j int) bool {
+	return (*q)[i] < (*q)[j]
+}
+
+func (q *pathQueue) Push(x interface{}) {
+	path, ok := x.(string)
+	if !ok {
+		return
+	}
+
+	*q = append(*q, path)
+}
+
+func (q *pathQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[0 : n-1]
+
+	return item
+}
+
+// visitNode calls onHash with the path and the hash of each stored node the node references
+// and onLeaf with the path and the value of each leaf embedded in it
+func visitNode(node Node, path []byte, onHash, onLeaf func(path, value []byte) error) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+
+	case *ValueNode:
+		if n.hash {
+			return onHash(path, n.buf)
+		}
+
+		if onLeaf == nil {
+			return nil
+		}
+
+		return onLeaf(path, n.buf)
+
+	case *ShortNode:
+		key := n.key
+		if hasTerminator(key) {
+			key = key[:len(key)-1]
+		}
+
+		return visitNode(n.child, append(append([]byte{}, path...), key...), onHash, onLeaf)
+
+	case *FullNode:
+		if err := visitNode(n.value, path, onHash, onLeaf); err != nil {
+			return err
+		}
+
+		for i, child := range n.children {
+			if err := visitNode(child, append(append([]byte{}, path...), byte(i)), onHash, onLeaf); err != nil {
+				return err
+			}
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("unknown node type %T", n)
+	}
+}
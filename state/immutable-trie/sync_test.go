@@ -0,0 +1,156 @@
+package itrie
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+)
+
+func syncTestKV(size int) map[string][]byte {
+	kv := make(map[string][]byte, size)
+
+	for i := 0; i < size; i++ {
+		key := crypto.Keccak256([]byte(fmt.Sprintf("key-%d", i)))
+		// every third value is short enough to embed its leaf into the parent
+		if i%3 == 0 {
+			kv[string(key)] = []byte{byte(i%250) + 1}
+		} else {
+			kv[string(key)] = bytes.Repeat([]byte{byte(i % 250)}, 40)
+		}
+	}
+
+	return kv
+}
+
+func TestGetNodeRange(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+	_, root := buildProofTrie(t, storage, syncTestKV(300))
+
+	all, err := GetNodeRange(storage, root, nil, 100000)
+	require.NoError(t, err)
+	require.NotEmpty(t, all)
+
+	assert.Empty(t, all[0].Path)
+	assert.True(t, sort.SliceIsSorted(all, func(i, j int) bool {
+		return bytes.Compare(all[i].Path, all[j].Path) < 0
+	}))
+
+	// the ranges starting from the paths of the nodes are the suffixes of the whole range
+	for _, i := range []int{1, len(all) / 2, len(all) - 1} {
+		nodes, err := GetNodeRange(storage, root, all[i].Path, 10)
+		require.NoError(t, err)
+
+		end := i + 10
+		if end > len(all) {
+			end = len(all)
+		}
+
+		assert.Equal(t, all[i:end], nodes)
+	}
+
+	_, err = GetNodeRange(NewMemoryStorage(), root, nil, 10)
+	require.ErrorIs(t, err, errStateNotFound)
+}
+
+func TestTrieSync(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name  string
+		size  int
+		limit int
+	}{
+		{"single key", 1, 1},
+		{"embedded nodes", 5, 2},
+		{"many keys", 500, 7},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			kv := syncTestKV(c.size)
+
+			src := NewMemoryStorage()
+			_, root := buildProofTrie(t, src, kv)
+
+			leaves := map[string][]byte{}
+
+			dst := NewMemoryStorage()
+			sync := NewTrieSync(dst, root, func(key, value []byte) error {
+				leaves[string(key)] = append([]byte{}, value...)
+
+				return nil
+			})
+
+			for from, ok := sync.Next(); ok; from, ok = sync.Next() {
+				nodes, err := GetNodeRange(src, root, from, c.limit)
+				require.NoError(t, err)
+				require.NotEmpty(t, nodes)
+
+				require.NoError(t, sync.Process(nodes))
+
+				// the root is written only once the trie is complete
+				_, ok := dst.Get(root.Bytes())
+				require.False(t, ok)
+			}
+
+			require.NoError(t, sync.Commit())
+			assert.Equal(t, kv, leaves)
+
+			synced := map[string][]byte{}
+			require.NoError(t, walkLeaves(dst, root, func(key, value []byte) error {
+				synced[string(key)] = append([]byte{}, value...)
+
+				return nil
+			}))
+			assert.Equal(t, kv, synced)
+		})
+	}
+}
+
+func TestTrieSync_InvalidNodes(t *testing.T) {
+	t.Parallel()
+
+	src := NewMemoryStorage()
+	_, root := buildProofTrie(t, src, syncTestKV(100))
+
+	nodes, err := GetNodeRange(src, root, nil, 3)
+	require.NoError(t, err)
+	require.Len(t, nodes, 3)
+
+	t.Run("modified node", func(t *testing.T) {
+		t.Parallel()
+
+		data := append([]byte{}, nodes[0].Data...)
+		data[len(data)-1]++
+
+		sync := NewTrieSync(NewMemoryStorage(), root, nil)
+		require.ErrorIs(t, sync.Process([]SyncNode{{Path: nodes[0].Path, Data: data}}), ErrInvalidSyncNode)
+	})
+
+	t.Run("unreferenced node", func(t *testing.T) {
+		t.Parallel()
+
+		sync := NewTrieSync(NewMemoryStorage(), root, nil)
+		require.ErrorIs(t, sync.Process(nodes[1:]), ErrUnexpectedSyncNode)
+	})
+
+	t.Run("incomplete trie", func(t *testing.T) {
+		t.Parallel()
+
+		sync := NewTrieSync(NewMemoryStorage(), root, nil)
+		require.NoError(t, sync.Process(nodes))
+		require.ErrorIs(t, sync.Commit(), ErrTrieSyncIncomplete)
+	})
+}
@@ -1,6 +1,7 @@
 package evm
 
 import (
+	"bytes"
 	"math/big"
 	"testing"
 
@@ -106,6 +107,8 @@ type mockHostForInstructions struct {
 	nonce       uint64
 	code        []byte
 	callxResult *runtime.ExecutionResult
+	accessList  map[types.Address]map[types.Hash]bool
+	transient   map[types.Hash]types.Hash
 }
 
 func (m *mockHostForInstructions) GetNonce(types.Address) uint64 {
@@ -120,6 +123,53 @@ func (m *mockHostForInstructions) GetCode(addr types.Address) []byte {
 	return m.code
 }
 
+func (m *mockHostForInstructions) GetStorage(types.Address, types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
+func (m *mockHostForInstructions) GetBalance(types.Address) *big.Int {
+	return big.NewInt(0)
+}
+
+func (m *mockHostForInstructions) AddressInAccessList(addr types.Address) bool {
+	_, ok := m.accessList[addr]
+
+	return ok
+}
+
+func (m *mockHostForInstructions) SlotInAccessList(addr types.Address, slot types.Hash) (bool, bool) {
+	slots, addrOk := m.accessList[addr]
+
+	return addrOk, slots[slot]
+}
+
+func (m *mockHostForInstructions) AddAddressToAccessList(addr types.Address) {
+	if m.accessList == nil {
+		m.accessList = map[types.Address]map[types.Hash]bool{}
+	}
+
+	if _, ok := m.accessList[addr]; !ok {
+		m.accessList[addr] = map[types.Hash]bool{}
+	}
+}
+
+func (m *mockHostForInstructions) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	m.AddAddressToAccessList(addr)
+	m.accessList[addr][slot] = true
+}
+
+func (m *mockHostForInstructions) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	return m.transient[key]
+}
+
+func (m *mockHostForInstructions) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	if m.transient == nil {
+		m.transient = map[types.Hash]types.Hash{}
+	}
+
+	m.transient[key] = value
+}
+
 var (
 	addr1 = types.StringToAddress("1")
 )
@@ -701,6 +751,8 @@ func Test_opCall(t *testing.T) {
 				callxResult: &runtime.ExecutionResult{
 					ReturnValue: []byte{0x03},
 				},
+				// the called address is warm, so the call costs less than the available gas
+				accessList: map[types.Address]map[types.Hash]bool{types.ZeroAddress: {}},
 			},
 		},
 	}
@@ -728,3 +780,330 @@ func Test_opCall(t *testing.T) {
 		})
 	}
 }
+
+func TestAccessListGas(t *testing.T) {
+	t.Parallel()
+
+	berlinForks := chain.ForksInTime{EIP150: true, Istanbul: true, Berlin: true}
+
+	tests := []struct {
+		name    string
+		op      instruction
+		stack   []*big.Int
+		gas     []uint64
+		warmUp  func(host *mockHostForInstructions)
+		checkFn func(t *testing.T, host *mockHostForInstructions)
+	}{
+		{
+			name:  "SLOAD charges cold access once",
+			op:    opSload,
+			stack: []*big.Int{big.NewInt(1)},
+			gas:   []uint64{coldSloadCost, warmStorageReadCost},
+			checkFn: func(t *testing.T, host *mockHostForInstructions) {
+				t.Helper()
+
+				_, slotOk := host.SlotInAccessList(addr1, types.BytesToHash([]byte{1}))
+				assert.True(t, slotOk)
+			},
+		},
+		{
+			name:  "BALANCE charges cold access once",
+			op:    opBalance,
+			stack: []*big.Int{big.NewInt(2)},
+			gas:   []uint64{coldAccountAccessCost, warmStorageReadCost},
+			checkFn: func(t *testing.T, host *mockHostForInstructions) {
+				t.Helper()
+
+				assert.True(t, host.AddressInAccessList(types.BytesToAddress([]byte{2})))
+			},
+		},
+		{
+			name:  "BALANCE of the address in the access list is warm",
+			op:    opBalance,
+			stack: []*big.Int{big.NewInt(3)},
+			gas:   []uint64{warmStorageReadCost},
+			warmUp: func(host *mockHostForInstructions) {
+				host.AddAddressToAccessList(types.BytesToAddress([]byte{3}))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			host := &mockHostForInstructions{}
+			if tt.warmUp != nil {
+				tt.warmUp(host)
+			}
+
+			for _, expectedGas := range tt.gas {
+				s, closeFn := getState()
+
+				s.msg = &runtime.Contract{Address: addr1}
+				s.config = &berlinForks
+				s.host = host
+				s.gas = 10000
+
+				for _, v := range tt.stack {
+					s.push(new(big.Int).Set(v))
+				}
+
+				tt.op(s)
+
+				assert.NoError(t, s.err)
+				assert.Equal(t, 10000-expectedGas, s.gas)
+
+				closeFn()
+			}
+
+			if tt.checkFn != nil {
+				tt.checkFn(t, host)
+			}
+		})
+	}
+}
+
+func TestPush0(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pushes zero in shanghai", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{Shanghai: true}
+
+		// the reused stack item must be reset
+		s.push(big.NewInt(5))
+		s.pop()
+
+		opPush0(s)
+
+		assert.NoError(t, s.err)
+		assert.Equal(t, 1, s.sp)
+		assert.Equal(t, uint64(0), s.pop().Uint64())
+	})
+
+	t.Run("not found before shanghai", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{London: true}
+
+		opPush0(s)
+
+		assert.ErrorIs(t, s.err, errOpCodeNotFound)
+		assert.Equal(t, 0, s.sp)
+	})
+}
+
+func TestTransientStorage(t *testing.T) {
+	t.Parallel()
+
+	cancunForks := chain.ForksInTime{Cancun: true}
+
+	t.Run("TSTORE and TLOAD", func(t *testing.T) {
+		t.Parallel()
+
+		host := &mockHostForInstructions{}
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.msg = &runtime.Contract{Address: addr1}
+		s.config = &cancunForks
+		s.host = host
+
+		s.push(big.NewInt(10)) // value
+		s.push(big.NewInt(1))  // key
+		opTstore(s)
+
+		assert.NoError(t, s.err)
+		assert.Equal(t, types.BytesToHash([]byte{10}), host.transient[types.BytesToHash([]byte{1})])
+
+		s.push(big.NewInt(1)) // key
+		opTload(s)
+
+		assert.NoError(t, s.err)
+		assert.Equal(t, uint64(10), s.pop().Uint64())
+	})
+
+	t.Run("TSTORE in static call", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.msg = &runtime.Contract{Address: addr1, Static: true}
+		s.config = &cancunForks
+		s.host = &mockHostForInstructions{}
+
+		s.push(big.NewInt(10))
+		s.push(big.NewInt(1))
+		opTstore(s)
+
+		assert.ErrorIs(t, s.err, errWriteProtection)
+	})
+
+	t.Run("TLOAD before cancun", func(t *testing.T) {
+		t.Parallel()
+
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.msg = &runtime.Contract{Address: addr1}
+		s.config = &chain.ForksInTime{Shanghai: true}
+		s.host = &mockHostForInstructions{}
+
+		s.push(big.NewInt(1))
+		opTload(s)
+
+		assert.ErrorIs(t, s.err, errOpCodeNotFound)
+	})
+}
+
+func TestMCopy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		dst         int64
+		src         int64
+		length      int64
+		memory      []byte
+		expected    []byte
+		expectedGas uint64
+	}{
+		{
+			name:        "copies forward",
+			dst:         0,
+			src:         32,
+			length:      32,
+			memory:      append(make([]byte, 32), bytes.Repeat([]byte{1}, 32)...),
+			expected:    bytes.Repeat([]byte{1}, 64),
+			expectedGas: copyGas,
+		},
+		{
+			name:        "copies overlapping area",
+			dst:         1,
+			src:         0,
+			length:      4,
+			memory:      append([]byte{1, 2, 3, 4, 5}, make([]byte, 27)...),
+			expected:    append([]byte{1, 1, 2, 3, 4}, make([]byte, 27)...),
+			expectedGas: copyGas,
+		},
+		{
+			name:   "expands memory for the destination",
+			dst:    32,
+			src:    0,
+			length: 32,
+			memory: bytes.Repeat([]byte{1}, 32),
+			// memory expansion from one to two words and one copied word
+			expected:    bytes.Repeat([]byte{1}, 64),
+			expectedGas: 3 + copyGas,
+		},
+		{
+			name:        "zero length",
+			dst:         1000,
+			src:         0,
+			length:      0,
+			memory:      make([]byte, 32),
+			expected:    make([]byte, 32),
+			expectedGas: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, closeFn := getState()
+			defer closeFn()
+
+			s.config = &chain.ForksInTime{Cancun: true}
+			s.gas = 1000
+			s.memory = append(s.memory[:0], tt.memory...)
+			s.lastGasCost = uint64(3 * (len(tt.memory) / 32))
+
+			s.push(big.NewInt(tt.length))
+			s.push(big.NewInt(tt.src))
+			s.push(big.NewInt(tt.dst))
+
+			opMCopy(s)
+
+			assert.NoError(t, s.err)
+			assert.Equal(t, tt.expected, s.memory)
+			assert.Equal(t, 1000-tt.expectedGas, s.gas)
+		})
+	}
+}
+
+func TestCreateInitCodeLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		length uint64
+		gas    uint64
+		err    error
+	}{
+		{
+			name:   "init code exceeding the limit",
+			length: runtime.MaxInitCodeSize + 1,
+			gas:    1000000,
+			err:    runtime.ErrMaxInitCodeSizeExceeded,
+		},
+		{
+			// two words of the init code and the memory expansion of two words
+			name:   "init code is charged per word",
+			length: 33,
+			gas:    2*runtime.InitCodeWordGas + 6,
+		},
+		{
+			name:   "not enough gas for the init code",
+			length: 33,
+			gas:    2*runtime.InitCodeWordGas + 5,
+			err:    errOutOfGas,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, closeFn := getState()
+			defer closeFn()
+
+			s.msg = &runtime.Contract{Address: addr1}
+			s.config = &chain.ForksInTime{Shanghai: true}
+			s.host = &mockHostForInstructions{
+				callxResult: &runtime.ExecutionResult{},
+			}
+			s.gas = tt.gas
+
+			s.push(new(big.Int).SetUint64(tt.length)) // length
+			s.push(big.NewInt(0))                     // offset
+			s.push(big.NewInt(0))                     // value
+
+			opCreate(CREATE)(s)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, s.err, tt.err)
+				assert.True(t, s.stop)
+			} else {
+				assert.NoError(t, s.err)
+				assert.Equal(t, uint64(0), s.gas)
+			}
+		})
+	}
+}
@@ -78,6 +78,12 @@ type Host interface {
 	Transfer(from types.Address, to types.Address, amount *big.Int) error
 	GetTracer() VMTracer
 	GetRefund() uint64
+	AddressInAccessList(addr types.Address) bool
+	SlotInAccessList(addr types.Address, slot types.Hash) (addressOk bool, slotOk bool)
+	AddAddressToAccessList(addr types.Address)
+	AddSlotToAccessList(addr types.Address, slot types.Hash)
+	GetTransientStorage(addr types.Address, key types.Hash) types.Hash
+	SetTransientStorage(addr types.Address, key types.Hash, value types.Hash)
 }
 
 type VMTracer interface {
@@ -134,6 +140,7 @@ var (
 	ErrNotEnoughFunds           = errors.New("not enough funds")
 	ErrInsufficientBalance      = errors.New("insufficient balance for transfer")
 	ErrMaxCodeSizeExceeded      = errors.New("max code size exceeded")
+	ErrMaxInitCodeSizeExceeded  = errors.New("max initcode size exceeded")
 	ErrContractAddressCollision = errors.New("contract address collision")
 	ErrDepth                    = errors.New("max call depth exceeded")
 	ErrExecutionReverted        = errors.New("execution reverted")
@@ -165,6 +172,14 @@ func (e *StackOverflowError) Error() string {
 	return fmt.Sprintf("stack limit reached %d (%d)", e.StackLen, e.Limit)
 }
 
+// eip-3860, the limit and the cost of the init code of the contract creation
+const (
+	// MaxInitCodeSize is twice the max code size of EIP-170
+	MaxInitCodeSize = 2 * 24576
+	// InitCodeWordGas is the gas per word of the init code
+	InitCodeWordGas uint64 = 2
+)
+
 type CallType int
 
 const (
@@ -0,0 +1,267 @@
+package accesslisttracer
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Result is the access list collected by the tracer and the gas used by the traced transaction
+type Result struct {
+	AccessList types.TxAccessList
+	GasUsed    uint64
+	Err        error
+}
+
+// AccessListTracer is the tracer which collects the addresses and the storage slots accessed by a transaction.
+// The sender, the recipient and the precompiles are not added to the list since they are always warm
+type AccessListTracer struct {
+	cancelLock sync.RWMutex
+	reason     error
+	interrupt  bool
+
+	initial     types.TxAccessList
+	precompiles []types.Address
+	excluded    map[types.Address]struct{}
+
+	// list keeps the order in which the addresses have been accessed
+	list  types.TxAccessList
+	index map[types.Address]int
+	slots map[types.Address]map[types.Hash]struct{}
+
+	gasLimit uint64
+	gasUsed  uint64
+	err      error
+}
+
+// NewAccessListTracer creates the tracer, the initial access list is always included in the result
+func NewAccessListTracer(precompiles []types.Address, initial types.TxAccessList) *AccessListTracer {
+	t := &AccessListTracer{
+		cancelLock:  sync.RWMutex{},
+		initial:     initial,
+		precompiles: precompiles,
+	}
+
+	t.resetExcluded()
+	t.reset()
+
+	return t
+}
+
+// resetExcluded sets the excluded addresses to the precompiles
+func (t *AccessListTracer) resetExcluded() {
+	t.excluded = make(map[types.Address]struct{}, len(t.precompiles)+2)
+
+	for _, addr := range t.precompiles {
+		t.excluded[addr] = struct{}{}
+	}
+}
+
+// reset sets the collected list to the initial one
+func (t *AccessListTracer) reset() {
+	t.list = make(types.TxAccessList, 0, len(t.initial))
+	t.index = make(map[types.Address]int)
+	t.slots = make(map[types.Address]map[types.Hash]struct{})
+
+	for _, tuple := range t.initial {
+		t.addAddress(tuple.Address)
+
+		for _, slot := range tuple.StorageKeys {
+			t.addSlot(tuple.Address, slot)
+		}
+	}
+}
+
+func (t *AccessListTracer) Cancel(err error) {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	t.reason = err
+	t.interrupt = true
+}
+
+func (t *AccessListTracer) cancelled() bool {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.interrupt
+}
+
+func (t *AccessListTracer) cancelReason() error {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.reason
+}
+
+func (t *AccessListTracer) Clear() {
+	t.cancelLock.Lock()
+	t.reason = nil
+	t.interrupt = false
+	t.cancelLock.Unlock()
+
+	t.gasLimit = 0
+	t.gasUsed = 0
+	t.err = nil
+
+	t.resetExcluded()
+	t.reset()
+}
+
+func (t *AccessListTracer) TxStart(gasLimit uint64) {
+	t.gasLimit = gasLimit
+}
+
+func (t *AccessListTracer) TxEnd(gasLeft uint64) {
+	t.gasUsed = t.gasLimit - gasLeft
+}
+
+func (t *AccessListTracer) TxStateStart(
+	host tracer.StateHost,
+	from types.Address,
+	to *types.Address,
+	coinbase types.Address,
+) {
+	t.excluded[from] = struct{}{}
+
+	if to != nil {
+		t.excluded[*to] = struct{}{}
+	} else {
+		t.excluded[crypto.CreateAddress(from, host.GetNonce(from))] = struct{}{}
+	}
+
+	// the initial list is rebuilt without the addresses which are warm by default
+	t.reset()
+}
+
+func (t *AccessListTracer) TxStateEnd(host tracer.StateHost) {
+}
+
+func (t *AccessListTracer) CallStart(
+	depth int,
+	from, to types.Address,
+	callType int,
+	gas uint64,
+	value *big.Int,
+	input []byte,
+) {
+}
+
+func (t *AccessListTracer) CallEnd(
+	depth int,
+	output []byte,
+	gasLeft uint64,
+	err error,
+) {
+	if depth == 1 {
+		t.err = err
+	}
+}
+
+func (t *AccessListTracer) CaptureState(
+	memory []byte,
+	stack []*big.Int,
+	opCode int,
+	contractAddress types.Address,
+	sp int,
+	host tracer.RuntimeHost,
+	state tracer.VMState,
+) {
+	if t.cancelled() {
+		state.Halt()
+
+		return
+	}
+
+	switch opCode {
+	case evm.SLOAD, evm.SSTORE:
+		if sp < 1 {
+			return
+		}
+
+		t.addSlot(contractAddress, types.BytesToHash(stack[sp-1].Bytes()))
+
+	case evm.BALANCE, evm.EXTCODESIZE, evm.EXTCODECOPY, evm.EXTCODEHASH, evm.SELFDESTRUCT:
+		if sp < 1 {
+			return
+		}
+
+		t.addAddress(types.BytesToAddress(stack[sp-1].Bytes()))
+
+	case evm.CALL, evm.CALLCODE, evm.DELEGATECALL, evm.STATICCALL:
+		if sp < 2 {
+			return
+		}
+
+		t.addAddress(types.BytesToAddress(stack[sp-2].Bytes()))
+	}
+}
+
+func (t *AccessListTracer) ExecuteState(
+	contractAddress types.Address,
+	ip uint64,
+	opCode string,
+	availableGas uint64,
+	cost uint64,
+	lastReturnData []byte,
+	depth int,
+	err error,
+	host tracer.RuntimeHost,
+) {
+}
+
+func (t *AccessListTracer) GetResult() (interface{}, error) {
+	if reason := t.cancelReason(); reason != nil {
+		return nil, reason
+	}
+
+	return &Result{
+		AccessList: t.list.Copy(),
+		GasUsed:    t.gasUsed,
+		Err:        t.err,
+	}, nil
+}
+
+// addAddress adds the address to the list unless it's always warm
+func (t *AccessListTracer) addAddress(addr types.Address) {
+	if _, ok := t.excluded[addr]; ok {
+		return
+	}
+
+	t.tupleIndex(addr)
+}
+
+// addSlot adds the slot and its address to the list,
+// the slots of the excluded addresses are added as well since they are not warm by default
+func (t *AccessListTracer) addSlot(addr types.Address, slot types.Hash) {
+	idx := t.tupleIndex(addr)
+
+	if _, ok := t.slots[addr][slot]; ok {
+		return
+	}
+
+	t.slots[addr][slot] = struct{}{}
+	t.list[idx].StorageKeys = append(t.list[idx].StorageKeys, slot)
+}
+
+// tupleIndex returns the index of the address in the list, the address is appended if it's not in the list yet
+func (t *AccessListTracer) tupleIndex(addr types.Address) int {
+	if idx, ok := t.index[addr]; ok {
+		return idx
+	}
+
+	idx := len(t.list)
+
+	t.index[addr] = idx
+	t.slots[addr] = make(map[types.Hash]struct{})
+	t.list = append(t.list, types.AccessTuple{
+		Address:     addr,
+		StorageKeys: []types.Hash{},
+	})
+
+	return idx
+}
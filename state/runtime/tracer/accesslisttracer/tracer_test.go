@@ -0,0 +1,169 @@
+package accesslisttracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	testFrom       = types.StringToAddress("1")
+	testTo         = types.StringToAddress("2")
+	testInner      = types.StringToAddress("3")
+	testPrecompile = types.StringToAddress("4")
+	testOther      = types.StringToAddress("5")
+
+	testSlot1 = types.StringToHash("1")
+	testSlot2 = types.StringToHash("2")
+)
+
+type mockState struct {
+	halted bool
+}
+
+func (m *mockState) Halt() {
+	m.halted = true
+}
+
+type mockStateHost struct {
+	nonce uint64
+}
+
+func (m *mockStateHost) AccountExists(addr types.Address) bool {
+	return true
+}
+
+func (m *mockStateHost) GetBalance(addr types.Address) *big.Int {
+	return big.NewInt(0)
+}
+
+func (m *mockStateHost) GetNonce(addr types.Address) uint64 {
+	return m.nonce
+}
+
+func (m *mockStateHost) GetCode(addr types.Address) []byte {
+	return nil
+}
+
+func (m *mockStateHost) GetStorage(addr types.Address, slot types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
+func toBig(b []byte) *big.Int {
+	return new(big.Int).SetBytes(b)
+}
+
+func getResult(t *testing.T, tracer *AccessListTracer) *Result {
+	t.Helper()
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	result, ok := res.(*Result)
+	require.True(t, ok)
+
+	return result
+}
+
+func TestAccessListTracer(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewAccessListTracer(
+		[]types.Address{testPrecompile},
+		types.TxAccessList{
+			{Address: testTo},
+			{Address: testOther, StorageKeys: []types.Hash{testSlot1}},
+		},
+	)
+
+	tracer.TxStateStart(&mockStateHost{}, testFrom, &testTo, types.ZeroAddress)
+	tracer.TxStart(50000)
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 50000, big.NewInt(0), nil)
+
+	// the slot of the recipient is added although the recipient is warm
+	tracer.CaptureState(nil, []*big.Int{toBig(testSlot1.Bytes())}, evm.SLOAD, testTo, 1, nil, &mockState{})
+	tracer.CaptureState(nil, []*big.Int{toBig(testSlot1.Bytes())}, evm.SSTORE, testTo, 1, nil, &mockState{})
+	tracer.CaptureState(nil, []*big.Int{toBig(testFrom.Bytes())}, evm.BALANCE, testTo, 1, nil, &mockState{})
+	tracer.CaptureState(nil, []*big.Int{toBig(testPrecompile.Bytes())}, evm.EXTCODESIZE, testTo, 1, nil, &mockState{})
+	tracer.CaptureState(nil, []*big.Int{big.NewInt(0), toBig(testInner.Bytes()), big.NewInt(100)},
+		evm.STATICCALL, testTo, 3, nil, &mockState{})
+	tracer.CaptureState(nil, []*big.Int{toBig(testSlot2.Bytes())}, evm.SLOAD, testInner, 1, nil, &mockState{})
+
+	tracer.CallEnd(1, nil, 10000, runtime.ErrExecutionReverted)
+	tracer.TxEnd(10000)
+
+	assert.Equal(t, &Result{
+		AccessList: types.TxAccessList{
+			{Address: testOther, StorageKeys: []types.Hash{testSlot1}},
+			{Address: testTo, StorageKeys: []types.Hash{testSlot1}},
+			{Address: testInner, StorageKeys: []types.Hash{testSlot2}},
+		},
+		GasUsed: 40000,
+		Err:     runtime.ErrExecutionReverted,
+	}, getResult(t, tracer))
+}
+
+func TestAccessListTracerContractCreation(t *testing.T) {
+	t.Parallel()
+
+	created := crypto.CreateAddress(testFrom, 3)
+
+	tracer := NewAccessListTracer(nil, nil)
+
+	tracer.TxStateStart(&mockStateHost{nonce: 3}, testFrom, nil, types.ZeroAddress)
+	tracer.CaptureState(nil, []*big.Int{toBig(created.Bytes())}, evm.EXTCODEHASH, created, 1, nil, &mockState{})
+	tracer.CaptureState(nil, []*big.Int{toBig(testOther.Bytes())}, evm.SELFDESTRUCT, created, 1, nil, &mockState{})
+
+	assert.Equal(t, types.TxAccessList{
+		{Address: testOther, StorageKeys: []types.Hash{}},
+	}, getResult(t, tracer).AccessList)
+}
+
+func TestAccessListTracerCancel(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("timeout")
+
+	tracer := NewAccessListTracer(nil, nil)
+	tracer.Cancel(err)
+
+	state := &mockState{}
+	tracer.CaptureState(nil, nil, evm.ADD, testTo, 0, nil, state)
+
+	assert.True(t, state.halted)
+
+	res, resErr := tracer.GetResult()
+
+	assert.Nil(t, res)
+	assert.Equal(t, err, resErr)
+}
+
+func TestAccessListTracerClear(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewAccessListTracer(nil, nil)
+
+	tracer.TxStateStart(&mockStateHost{}, testFrom, &testTo, types.ZeroAddress)
+	tracer.CaptureState(nil, []*big.Int{toBig(testInner.Bytes())}, evm.BALANCE, testTo, 1, nil, &mockState{})
+	tracer.Cancel(errors.New("timeout"))
+
+	tracer.Clear()
+
+	assert.False(t, tracer.cancelled())
+	assert.Empty(t, tracer.list)
+
+	// the addresses excluded for the previous transaction are not excluded anymore
+	tracer.CaptureState(nil, []*big.Int{toBig(testTo.Bytes())}, evm.BALANCE, testInner, 1, nil, &mockState{})
+
+	assert.Equal(t, types.TxAccessList{
+		{Address: testTo, StorageKeys: []types.Hash{}},
+	}, getResult(t, tracer).AccessList)
+}
@@ -0,0 +1,312 @@
+package calltracer
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// maxLogDataSize is the upper bound of the captured log data,
+// memory expansion above this size can't be paid with any realistic gas limit
+const maxLogDataSize = 1 << 24
+
+type Config struct {
+	OnlyTopCall bool `json:"onlyTopCall"` // trace only the top-level call
+	WithLog     bool `json:"withLog"`     // capture the logs emitted by each call
+}
+
+// CallLog is a log emitted within a call frame
+type CallLog struct {
+	Address  types.Address `json:"address"`
+	Topics   []types.Hash  `json:"topics"`
+	Data     string        `json:"data"`
+	Position string        `json:"position"`
+}
+
+// CallFrame is a single call in the call tree, in the format of the callTracer in Geth
+type CallFrame struct {
+	Type         string         `json:"type"`
+	From         types.Address  `json:"from"`
+	Gas          string         `json:"gas"`
+	GasUsed      string         `json:"gasUsed"`
+	To           *types.Address `json:"to,omitempty"`
+	Input        string         `json:"input"`
+	Output       string         `json:"output,omitempty"`
+	Error        string         `json:"error,omitempty"`
+	RevertReason string         `json:"revertReason,omitempty"`
+	Calls        []*CallFrame   `json:"calls,omitempty"`
+	Logs         []*CallLog     `json:"logs,omitempty"`
+	Value        string         `json:"value,omitempty"`
+
+	gas    uint64
+	failed bool
+}
+
+// CallTracer is the tracer which collects the call tree of a transaction
+type CallTracer struct {
+	Config Config
+
+	cancelLock sync.RWMutex
+	reason     error
+	interrupt  bool
+
+	// callStack holds the frames of the calls which are currently being executed
+	callStack []*CallFrame
+	root      *CallFrame
+	gasLimit  uint64
+}
+
+func NewCallTracer(config Config) *CallTracer {
+	return &CallTracer{
+		Config:     config,
+		cancelLock: sync.RWMutex{},
+	}
+}
+
+func (t *CallTracer) Cancel(err error) {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	t.reason = err
+	t.interrupt = true
+}
+
+func (t *CallTracer) cancelled() bool {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.interrupt
+}
+
+func (t *CallTracer) cancelReason() error {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.reason
+}
+
+func (t *CallTracer) Clear() {
+	t.cancelLock.Lock()
+	t.reason = nil
+	t.interrupt = false
+	t.cancelLock.Unlock()
+
+	t.callStack = t.callStack[:0]
+	t.root = nil
+	t.gasLimit = 0
+}
+
+func (t *CallTracer) TxStart(gasLimit uint64) {
+	t.gasLimit = gasLimit
+}
+
+func (t *CallTracer) TxEnd(gasLeft uint64) {
+	if t.root == nil {
+		return
+	}
+
+	// the top-level call reports the gas of the whole transaction
+	t.root.Gas = hex.EncodeUint64(t.gasLimit)
+	t.root.GasUsed = hex.EncodeUint64(t.gasLimit - gasLeft)
+}
+
+func (t *CallTracer) CallStart(
+	depth int,
+	from, to types.Address,
+	callType int,
+	gas uint64,
+	value *big.Int,
+	input []byte,
+) {
+	if depth > 1 && t.Config.OnlyTopCall {
+		return
+	}
+
+	frame := &CallFrame{
+		Type:  callTypeToString(callType),
+		From:  from,
+		To:    to.Ptr(),
+		Input: hex.EncodeToHex(input),
+		Gas:   hex.EncodeUint64(gas),
+		gas:   gas,
+	}
+
+	// delegate and static calls can't transfer the value
+	if value != nil && callType != int(runtime.DelegateCall) && callType != int(runtime.StaticCall) {
+		frame.Value = hex.EncodeBig(value)
+	}
+
+	if len(t.callStack) > 0 {
+		parent := t.callStack[len(t.callStack)-1]
+		parent.Calls = append(parent.Calls, frame)
+	} else {
+		t.root = frame
+	}
+
+	t.callStack = append(t.callStack, frame)
+}
+
+func (t *CallTracer) CallEnd(
+	depth int,
+	output []byte,
+	gasLeft uint64,
+	err error,
+) {
+	if (depth > 1 && t.Config.OnlyTopCall) || len(t.callStack) == 0 {
+		return
+	}
+
+	frame := t.callStack[len(t.callStack)-1]
+	t.callStack = t.callStack[:len(t.callStack)-1]
+
+	frame.GasUsed = hex.EncodeUint64(frame.gas - gasLeft)
+
+	if err == nil {
+		if len(output) > 0 {
+			frame.Output = hex.EncodeToHex(output)
+		}
+
+		return
+	}
+
+	frame.failed = true
+	frame.Error = err.Error()
+
+	// the address is not returned if contract creation fails
+	if frame.Type == evm.OpCode(evm.CREATE).String() || frame.Type == evm.OpCode(evm.CREATE2).String() {
+		frame.To = nil
+	}
+
+	if errors.Is(err, runtime.ErrExecutionReverted) && len(output) > 0 {
+		frame.Output = hex.EncodeToHex(output)
+
+		if reason, unpackErr := abi.UnpackRevertError(output); unpackErr == nil {
+			frame.RevertReason = reason
+		}
+	}
+}
+
+func (t *CallTracer) CaptureState(
+	memory []byte,
+	stack []*big.Int,
+	opCode int,
+	contractAddress types.Address,
+	sp int,
+	host tracer.RuntimeHost,
+	state tracer.VMState,
+) {
+	if t.cancelled() {
+		state.Halt()
+
+		return
+	}
+
+	if !t.Config.WithLog || opCode < evm.LOG0 || opCode > evm.LOG4 || len(t.callStack) == 0 {
+		return
+	}
+
+	topicsCount := opCode - evm.LOG0
+	if sp < 2+topicsCount {
+		return
+	}
+
+	offset := stack[sp-1]
+	size := stack[sp-2]
+
+	topics := make([]types.Hash, topicsCount)
+	for i := 0; i < topicsCount; i++ {
+		topics[i] = types.BytesToHash(stack[sp-3-i].Bytes())
+	}
+
+	frame := t.callStack[len(t.callStack)-1]
+
+	frame.Logs = append(frame.Logs, &CallLog{
+		Address:  contractAddress,
+		Topics:   topics,
+		Data:     hex.EncodeToHex(memorySlice(memory, offset, size)),
+		Position: hex.EncodeUint64(uint64(len(frame.Calls))),
+	})
+}
+
+func (t *CallTracer) ExecuteState(
+	contractAddress types.Address,
+	ip uint64,
+	opCode string,
+	availableGas uint64,
+	cost uint64,
+	lastReturnData []byte,
+	depth int,
+	err error,
+	host tracer.RuntimeHost,
+) {
+}
+
+func (t *CallTracer) GetResult() (interface{}, error) {
+	if reason := t.cancelReason(); reason != nil {
+		return nil, reason
+	}
+
+	if t.root == nil {
+		return nil, nil
+	}
+
+	clearFailedLogs(t.root, false)
+
+	return t.root, nil
+}
+
+// clearFailedLogs removes the logs of the failed calls and their descendants
+// since such logs are reverted with the state
+func clearFailedLogs(frame *CallFrame, parentFailed bool) {
+	failed := frame.failed || parentFailed
+	if failed {
+		frame.Logs = nil
+	}
+
+	for _, child := range frame.Calls {
+		clearFailedLogs(child, failed)
+	}
+}
+
+// memorySlice returns a copy of the given memory range, padded with zeros if it exceeds the memory
+func memorySlice(memory []byte, offset, size *big.Int) []byte {
+	if !offset.IsUint64() || !size.IsUint64() {
+		return nil
+	}
+
+	o, s := offset.Uint64(), size.Uint64()
+	if s > maxLogDataSize {
+		return nil
+	}
+
+	res := make([]byte, s)
+
+	if o < uint64(len(memory)) {
+		copy(res, memory[o:])
+	}
+
+	return res
+}
+
+func callTypeToString(callType int) string {
+	switch runtime.CallType(callType) {
+	case runtime.Call:
+		return evm.OpCode(evm.CALL).String()
+	case runtime.CallCode:
+		return evm.OpCode(evm.CALLCODE).String()
+	case runtime.DelegateCall:
+		return evm.OpCode(evm.DELEGATECALL).String()
+	case runtime.StaticCall:
+		return evm.OpCode(evm.STATICCALL).String()
+	}
+
+	return evm.OpCode(callType).String()
+}
@@ -0,0 +1,230 @@
+package calltracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	testFrom  = types.StringToAddress("1")
+	testTo    = types.StringToAddress("2")
+	testInner = types.StringToAddress("3")
+)
+
+type mockState struct {
+	halted bool
+}
+
+func (m *mockState) Halt() {
+	m.halted = true
+}
+
+func TestCallTracerCancel(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("timeout")
+
+	tracer := NewCallTracer(Config{})
+
+	assert.False(t, tracer.cancelled())
+
+	tracer.Cancel(err)
+
+	assert.True(t, tracer.cancelled())
+
+	state := &mockState{}
+	tracer.CaptureState(nil, nil, evm.ADD, testTo, 0, nil, state)
+
+	assert.True(t, state.halted)
+
+	res, resErr := tracer.GetResult()
+
+	assert.Nil(t, res)
+	assert.Equal(t, err, resErr)
+}
+
+func TestCallTracerCancelConcurrently(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("timeout")
+	tracer := NewCallTracer(Config{})
+
+	done := make(chan struct{})
+
+	// the timeout goroutine cancels the tracer while the result is read
+	go func() {
+		defer close(done)
+
+		tracer.Cancel(err)
+	}()
+
+	_, _ = tracer.GetResult()
+
+	<-done
+
+	_, resErr := tracer.GetResult()
+	assert.Equal(t, err, resErr)
+}
+
+func TestCallTracerNestedCalls(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewCallTracer(Config{})
+
+	tracer.TxStart(100000)
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 79000, big.NewInt(10), []byte{0x1})
+	tracer.CallStart(2, testTo, testInner, int(runtime.StaticCall), 5000, big.NewInt(0), []byte{0x2})
+	tracer.CallEnd(2, []byte{0x3}, 4000, nil)
+	tracer.CallStart(2, testTo, testInner, int(runtime.DelegateCall), 5000, big.NewInt(10), nil)
+	tracer.CallEnd(2, nil, 0, runtime.ErrOutOfGas)
+	tracer.CallEnd(1, []byte{0x4}, 50000, nil)
+	tracer.TxEnd(71000)
+
+	res, err := tracer.GetResult()
+	assert.NoError(t, err)
+
+	assert.Equal(t, &CallFrame{
+		Type:    "CALL",
+		From:    testFrom,
+		To:      &testTo,
+		Gas:     hex.EncodeUint64(100000),
+		GasUsed: hex.EncodeUint64(29000),
+		Input:   "0x01",
+		Output:  "0x04",
+		Value:   "0xa",
+		gas:     79000,
+		Calls: []*CallFrame{
+			{
+				Type:    "STATICCALL",
+				From:    testTo,
+				To:      &testInner,
+				Gas:     hex.EncodeUint64(5000),
+				GasUsed: hex.EncodeUint64(1000),
+				Input:   "0x02",
+				Output:  "0x03",
+				gas:     5000,
+			},
+			{
+				Type:    "DELEGATECALL",
+				From:    testTo,
+				To:      &testInner,
+				Gas:     hex.EncodeUint64(5000),
+				GasUsed: hex.EncodeUint64(5000),
+				Input:   "0x",
+				Error:   runtime.ErrOutOfGas.Error(),
+				gas:     5000,
+				failed:  true,
+			},
+		},
+	}, res)
+}
+
+func TestCallTracerOnlyTopCall(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewCallTracer(Config{OnlyTopCall: true})
+
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 1000, big.NewInt(0), nil)
+	tracer.CallStart(2, testTo, testInner, int(runtime.Call), 500, big.NewInt(0), nil)
+	tracer.CallEnd(2, nil, 500, nil)
+	tracer.CallEnd(1, nil, 200, nil)
+
+	res, err := tracer.GetResult()
+	assert.NoError(t, err)
+
+	frame, ok := res.(*CallFrame)
+	assert.True(t, ok)
+	assert.Empty(t, frame.Calls)
+	assert.Equal(t, hex.EncodeUint64(800), frame.GasUsed)
+}
+
+func TestCallTracerFailedCreate(t *testing.T) {
+	t.Parallel()
+
+	// abi encoded Error("failed")
+	revertOutput := hex.MustDecodeHex(
+		"0x08c379a0" +
+			"0000000000000000000000000000000000000000000000000000000000000020" +
+			"0000000000000000000000000000000000000000000000000000000000000006" +
+			"6661696c65640000000000000000000000000000000000000000000000000000",
+	)
+
+	tracer := NewCallTracer(Config{})
+
+	tracer.CallStart(1, testFrom, testTo, evm.CREATE, 1000, big.NewInt(0), nil)
+	tracer.CallEnd(1, revertOutput, 300, runtime.ErrExecutionReverted)
+
+	res, err := tracer.GetResult()
+	assert.NoError(t, err)
+
+	frame, ok := res.(*CallFrame)
+	assert.True(t, ok)
+	assert.Equal(t, "CREATE", frame.Type)
+	assert.Nil(t, frame.To)
+	assert.Equal(t, runtime.ErrExecutionReverted.Error(), frame.Error)
+	assert.Equal(t, "failed", frame.RevertReason)
+	assert.Equal(t, hex.EncodeToHex(revertOutput), frame.Output)
+}
+
+func TestCallTracerWithLog(t *testing.T) {
+	t.Parallel()
+
+	var (
+		memory = []byte{0x1, 0x2, 0x3, 0x4}
+		topic  = types.StringToHash("5")
+		// LOG1 stack: offset, size, topic
+		stack = []*big.Int{new(big.Int).SetBytes(topic.Bytes()), big.NewInt(2), big.NewInt(1)}
+	)
+
+	tracer := NewCallTracer(Config{WithLog: true})
+
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 1000, big.NewInt(0), nil)
+	tracer.CaptureState(memory, stack, evm.LOG1, testTo, len(stack), nil, &mockState{})
+	tracer.CallStart(2, testTo, testInner, int(runtime.Call), 500, big.NewInt(0), nil)
+	tracer.CaptureState(memory, stack, evm.LOG1, testInner, len(stack), nil, &mockState{})
+	tracer.CallEnd(2, nil, 0, runtime.ErrOutOfGas)
+	tracer.CallEnd(1, nil, 200, nil)
+
+	res, err := tracer.GetResult()
+	assert.NoError(t, err)
+
+	frame, ok := res.(*CallFrame)
+	assert.True(t, ok)
+	assert.Equal(t, []*CallLog{
+		{
+			Address:  testTo,
+			Topics:   []types.Hash{topic},
+			Data:     "0x0203",
+			Position: "0x0",
+		},
+	}, frame.Logs)
+
+	// logs of the failed call are dropped
+	assert.Len(t, frame.Calls, 1)
+	assert.Empty(t, frame.Calls[0].Logs)
+}
+
+func TestCallTracerClear(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewCallTracer(Config{})
+
+	tracer.TxStart(1000)
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 1000, big.NewInt(0), nil)
+	tracer.Cancel(errors.New("timeout"))
+
+	tracer.Clear()
+
+	assert.False(t, tracer.cancelled())
+	assert.Empty(t, tracer.callStack)
+	assert.Nil(t, tracer.root)
+	assert.Zero(t, tracer.gasLimit)
+}
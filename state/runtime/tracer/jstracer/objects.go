@@ -0,0 +1,291 @@
+package jstracer
+
+import (
+	"math/big"
+
+	"github.com/dop251/goja"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// The objects passed to the script follow the API of the JS tracers in Geth,
+// big numbers and byte arrays are represented as hex strings
+
+type jsFunc = func(call goja.FunctionCall) goja.Value
+
+// setFuncs sets the given functions as the properties of the object
+func setFuncs(obj *goja.Object, funcs map[string]jsFunc) {
+	for name, fn := range funcs {
+		// Set fails only for the frozen objects
+		_ = obj.Set(name, fn)
+	}
+}
+
+// currentStep returns the step being executed, it throws if the log is accessed out of the step
+func (t *JSTracer) currentStep() *step {
+	if t.current == nil {
+		panic(t.vm.NewTypeError("log can be accessed only in step or fault"))
+	}
+
+	return t.current
+}
+
+// currentCall returns the call being executed
+func (t *JSTracer) currentCall() *callFrame {
+	if len(t.callStack) == 0 {
+		return &callFrame{value: big.NewInt(0)}
+	}
+
+	return t.callStack[len(t.callStack)-1]
+}
+
+// newLogObject creates the log object passed to step and fault, which reads the current step
+func (t *JSTracer) newLogObject() *goja.Object {
+	vm := t.vm
+
+	op := vm.NewObject()
+	setFuncs(op, map[string]jsFunc{
+		"toNumber": func(goja.FunctionCall) goja.Value {
+			return vm.ToValue(t.currentStep().op)
+		},
+		"toString": func(goja.FunctionCall) goja.Value {
+			return vm.ToValue(evm.OpCode(t.currentStep().op).String())
+		},
+		"isPush": func(goja.FunctionCall) goja.Value {
+			code := t.currentStep().op
+
+			return vm.ToValue(code >= evm.PUSH0 && code <= evm.PUSH32)
+		},
+	})
+
+	stack := vm.NewObject()
+	setFuncs(stack, map[string]jsFunc{
+		"peek": func(call goja.FunctionCall) goja.Value {
+			current := t.currentStep()
+
+			idx := call.Argument(0).ToInteger()
+			if idx < 0 || idx >= int64(len(current.stack)) {
+				panic(vm.NewTypeError("stack index %d out of range, stack length %d", idx, len(current.stack)))
+			}
+
+			return vm.ToValue(hex.EncodeBig(current.stackItem(int(idx))))
+		},
+		"length": func(goja.FunctionCall) goja.Value {
+			return vm.ToValue(len(t.currentStep().stack))
+		},
+	})
+
+	memory := vm.NewObject()
+	setFuncs(memory, map[string]jsFunc{
+		"slice": func(call goja.FunctionCall) goja.Value {
+			current := t.currentStep()
+
+			start, end := call.Argument(0).ToInteger(), call.Argument(1).ToInteger()
+			if start < 0 || start > end || end > int64(len(current.memory)) {
+				panic(vm.NewTypeError("memory slice [%d:%d] out of range, memory length %d",
+					start, end, len(current.memory)))
+			}
+
+			return vm.ToValue(hex.EncodeToHex(current.memorySlice(uint64(start), uint64(end))))
+		},
+		"getUint": func(call goja.FunctionCall) goja.Value {
+			current := t.currentStep()
+
+			offset := call.Argument(0).ToInteger()
+			if offset < 0 || offset+32 > int64(len(current.memory)) {
+				panic(vm.NewTypeError("memory offset %d out of range, memory length %d",
+					offset, len(current.memory)))
+			}
+
+			return vm.ToValue(hex.EncodeBig(new(big.Int).SetBytes(current.memorySlice(uint64(offset), uint64(offset+32)))))
+		},
+		"length": func(goja.FunctionCall) goja.Value {
+			return vm.ToValue(len(t.currentStep().memory))
+		},
+	})
+
+	contract := vm.NewObject()
+	setFuncs(contract, map[string]jsFunc{
+		"getAddress": func(goja.FunctionCall) goja.Value {
+			return vm.ToValue(t.currentStep().contract.String())
+		},
+		"getCaller": func(goja.FunctionCall) goja.Value {
+			return vm.ToValue(t.currentCall().from.String())
+		},
+		"getValue": func(goja.FunctionCall) goja.Value {
+			return vm.ToValue(hex.EncodeBig(t.currentCall().value))
+		},
+		"getInput": func(goja.FunctionCall) goja.Value {
+			return vm.ToValue(hex.EncodeToHex(t.currentCall().input))
+		},
+	})
+
+	log := vm.NewObject()
+	_ = log.Set("op", op)
+	_ = log.Set("stack", stack)
+	_ = log.Set("memory", memory)
+	_ = log.Set("contract", contract)
+
+	setFuncs(log, map[string]jsFunc{
+		"getPC": func(goja.FunctionCall) goja.Value {
+			return vm.ToValue(t.currentStep().pc)
+		},
+		"getGas": func(goja.FunctionCall) goja.Value {
+			return vm.ToValue(t.currentStep().gas)
+		},
+		"getCost": func(goja.FunctionCall) goja.Value {
+			return vm.ToValue(t.currentStep().cost)
+		},
+		"getDepth": func(goja.FunctionCall) goja.Value {
+			return vm.ToValue(t.currentStep().depth)
+		},
+		"getRefund": func(goja.FunctionCall) goja.Value {
+			current := t.currentStep()
+			if current.host == nil {
+				return vm.ToValue(0)
+			}
+
+			return vm.ToValue(current.host.GetRefund())
+		},
+		"getReturnData": func(goja.FunctionCall) goja.Value {
+			return vm.ToValue(hex.EncodeToHex(t.currentStep().returnData))
+		},
+		"getError": func(goja.FunctionCall) goja.Value {
+			if err := t.currentStep().err; err != nil {
+				return vm.ToValue(err.Error())
+			}
+
+			return goja.Undefined()
+		},
+	})
+
+	return log
+}
+
+// newDBObject creates the db object giving the access to the world state
+func (t *JSTracer) newDBObject() *goja.Object {
+	vm := t.vm
+
+	// toAddress converts the argument into the address, it throws if the state is not accessible
+	toAddress := func(arg goja.Value) types.Address {
+		if t.host == nil {
+			panic(vm.NewTypeError("state is not accessible"))
+		}
+
+		raw, err := hex.DecodeHex(arg.String())
+		if err != nil || len(raw) != types.AddressLength {
+			panic(vm.NewTypeError("invalid address %s", arg.String()))
+		}
+
+		return types.BytesToAddress(raw)
+	}
+
+	db := vm.NewObject()
+	setFuncs(db, map[string]jsFunc{
+		"getBalance": func(call goja.FunctionCall) goja.Value {
+			return vm.ToValue(hex.EncodeBig(t.host.GetBalance(toAddress(call.Argument(0)))))
+		},
+		"getNonce": func(call goja.FunctionCall) goja.Value {
+			return vm.ToValue(t.host.GetNonce(toAddress(call.Argument(0))))
+		},
+		"getCode": func(call goja.FunctionCall) goja.Value {
+			return vm.ToValue(hex.EncodeToHex(t.host.GetCode(toAddress(call.Argument(0)))))
+		},
+		"getState": func(call goja.FunctionCall) goja.Value {
+			addr := toAddress(call.Argument(0))
+
+			slot, err := hex.DecodeHex(call.Argument(1).String())
+			if err != nil || len(slot) > types.HashLength {
+				panic(vm.NewTypeError("invalid slot %s", call.Argument(1).String()))
+			}
+
+			return vm.ToValue(t.host.GetStorage(addr, types.BytesToHash(slot)).String())
+		},
+		"exists": func(call goja.FunctionCall) goja.Value {
+			return vm.ToValue(t.host.AccountExists(toAddress(call.Argument(0))))
+		},
+	})
+
+	return db
+}
+
+// newFrameObject creates the object passed to enter
+func (t *JSTracer) newFrameObject(frame *callFrame) *goja.Object {
+	vm := t.vm
+
+	obj := vm.NewObject()
+	setFuncs(obj, map[string]jsFunc{
+		"getType": func(goja.FunctionCall) goja.Value {
+			return vm.ToValue(frame.callType)
+		},
+		"getFrom": func(goja.FunctionCall) goja.Value {
+			return vm.ToValue(frame.from.String())
+		},
+		"getTo": func(goja.FunctionCall) goja.Value {
+			return vm.ToValue(frame.to.String())
+		},
+		"getInput": func(goja.FunctionCall) goja.Value {
+			return vm.ToValue(hex.EncodeToHex(frame.input))
+		},
+		"getGas": func(goja.FunctionCall) goja.Value {
+			return vm.ToValue(frame.gas)
+		},
+		"getValue": func(goja.FunctionCall) goja.Value {
+			return vm.ToValue(hex.EncodeBig(frame.value))
+		},
+	})
+
+	return obj
+}
+
+// newFrameResultObject creates the object passed to exit
+func (t *JSTracer) newFrameResultObject(gasUsed uint64, output []byte, err error) *goja.Object {
+	vm := t.vm
+
+	obj := vm.NewObject()
+	setFuncs(obj, map[string]jsFunc{
+		"getGasUsed": func(goja.FunctionCall) goja.Value {
+			return vm.ToValue(gasUsed)
+		},
+		"getOutput": func(goja.FunctionCall) goja.Value {
+			return vm.ToValue(hex.EncodeToHex(output))
+		},
+		"getError": func(goja.FunctionCall) goja.Value {
+			if err != nil {
+				return vm.ToValue(err.Error())
+			}
+
+			return goja.Undefined()
+		},
+	})
+
+	return obj
+}
+
+// newContextObject creates the ctx object passed to result, describing the traced transaction
+func (t *JSTracer) newContextObject() *goja.Object {
+	vm := t.vm
+
+	ctx := vm.NewObject()
+	_ = ctx.Set("gas", t.gasLimit)
+	_ = ctx.Set("gasUsed", t.gasUsed)
+
+	if t.topCall == nil {
+		return ctx
+	}
+
+	_ = ctx.Set("type", t.topCall.callType)
+	_ = ctx.Set("from", t.topCall.from.String())
+	_ = ctx.Set("to", t.topCall.to.String())
+	_ = ctx.Set("input", hex.EncodeToHex(t.topCall.input))
+	_ = ctx.Set("value", hex.EncodeBig(t.topCall.value))
+	_ = ctx.Set("output", hex.EncodeToHex(t.output))
+
+	if t.callErr != nil {
+		_ = ctx.Set("error", t.callErr.Error())
+	}
+
+	return ctx
+}
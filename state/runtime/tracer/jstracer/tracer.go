@@ -0,0 +1,567 @@
+package jstracer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/dop251/goja"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// ErrInvalidTracer is an error returned when the given code doesn't evaluate to a tracer object
+	ErrInvalidTracer = errors.New("invalid js tracer")
+	// ErrMissingResult is an error returned when the tracer object doesn't define the result function
+	ErrMissingResult = errors.New("js tracer must define the result function")
+)
+
+// maxStackInputs is the max number of the stack items read by an opcode other than SWAP
+const maxStackInputs = 7
+
+// step is the state of the opcode being executed. The stack and memory aren't copied,
+// only the stack items and the memory bytes which the opcode can overwrite are,
+// so the log object reads the state before the opcode once it's executed
+type step struct {
+	op       int
+	contract types.Address
+	host     tracer.RuntimeHost
+
+	// stack is the live stack, inputs are the copies of its top items the opcode can modify
+	stack  []*big.Int
+	inputs []*big.Int
+	// memory is the live memory, overwritten is the copy of the region written by the opcode
+	memory            []byte
+	overwritten       []byte
+	overwrittenOffset uint64
+
+	pc         uint64
+	gas        uint64
+	cost       uint64
+	depth      int
+	returnData []byte
+	err        error
+}
+
+func newStep(op int, contract types.Address, stack []*big.Int, memory []byte, host tracer.RuntimeHost) *step {
+	s := &step{
+		op:       op,
+		contract: contract,
+		host:     host,
+		stack:    stack,
+		memory:   memory,
+	}
+
+	inputs := maxStackInputs
+	if op >= evm.SWAP1 && op <= evm.SWAP16 {
+		inputs = op - evm.SWAP1 + 2
+	}
+
+	if inputs > len(stack) {
+		inputs = len(stack)
+	}
+
+	s.inputs = make([]*big.Int, inputs)
+	for i := range s.inputs {
+		s.inputs[i] = new(big.Int).Set(stack[len(stack)-i-1])
+	}
+
+	s.captureOverwrittenMemory()
+
+	return s
+}
+
+// captureOverwrittenMemory copies the region of the memory written by the opcode
+func (s *step) captureOverwrittenMemory() {
+	var offset, size *big.Int
+
+	switch s.op {
+	case evm.MSTORE:
+		offset, size = s.input(0), big.NewInt(32)
+	case evm.MSTORE8:
+		offset, size = s.input(0), big.NewInt(1)
+	case evm.MCOPY, evm.CALLDATACOPY, evm.CODECOPY, evm.RETURNDATACOPY:
+		offset, size = s.input(0), s.input(2)
+	case evm.EXTCODECOPY:
+		offset, size = s.input(1), s.input(3)
+	case evm.CALL, evm.CALLCODE:
+		offset, size = s.input(5), s.input(6)
+	case evm.DELEGATECALL, evm.STATICCALL:
+		offset, size = s.input(4), s.input(5)
+	default:
+		return
+	}
+
+	// the opcode fails if the stack is too short
+	if offset == nil || size == nil || size.Sign() == 0 {
+		return
+	}
+
+	// the memory beyond the current length is written only after the expansion
+	if !offset.IsUint64() || offset.Uint64() >= uint64(len(s.memory)) {
+		return
+	}
+
+	start, end := offset.Uint64(), uint64(len(s.memory))
+	if size.IsUint64() && size.Uint64() < end-start {
+		end = start + size.Uint64()
+	}
+
+	s.overwritten = append([]byte{}, s.memory[start:end]...)
+	s.overwrittenOffset = start
+}
+
+// input returns the copy of the stack item at the given index from the top, nil if it isn't copied
+func (s *step) input(idx int) *big.Int {
+	if idx >= len(s.inputs) {
+		return nil
+	}
+
+	return s.inputs[idx]
+}
+
+// stackItem returns the stack item at the given index from the top
+func (s *step) stackItem(idx int) *big.Int {
+	if item := s.input(idx); item != nil {
+		return item
+	}
+
+	// the items below the inputs of the opcode aren't modified by it
+	return s.stack[len(s.stack)-idx-1]
+}
+
+// memorySlice returns the copy of the memory region before the opcode
+func (s *step) memorySlice(start, end uint64) []byte {
+	data := make([]byte, end-start)
+	copy(data, s.memory[start:end])
+
+	if len(s.overwritten) == 0 {
+		return data
+	}
+
+	from, to := start, end
+	if from < s.overwrittenOffset {
+		from = s.overwrittenOffset
+	}
+
+	if overwrittenEnd := s.overwrittenOffset + uint64(len(s.overwritten)); to > overwrittenEnd {
+		to = overwrittenEnd
+	}
+
+	if from < to {
+		copy(data[from-start:], s.overwritten[from-s.overwrittenOffset:to-s.overwrittenOffset])
+	}
+
+	return data
+}
+
+// callFrame is the call being traced
+type callFrame struct {
+	callType string
+	from     types.Address
+	to       types.Address
+	input    []byte
+	gas      uint64
+	value    *big.Int
+}
+
+// JSTracer is the tracer which runs the tracer object written in JavaScript, in the format of Geth.
+// The tracer object must define result(ctx, db) and can define step(log, db), fault(log, db),
+// enter(frame), exit(frameResult) and setup(config)
+type JSTracer struct {
+	program *goja.Program
+	config  json.RawMessage
+
+	cancelLock sync.RWMutex
+	reason     error
+	interrupt  bool
+	// cancelErr is the reason of the cancellation, which isn't reset by Clear
+	cancelErr error
+
+	// vm runs all the scripts of the tracer, so the cancellation interrupts any of them
+	vm *goja.Runtime
+	// obj is the tracer object evaluated for the current transaction, nil until it's evaluated
+	obj    *goja.Object
+	log    *goja.Object
+	db     *goja.Object
+	step   goja.Callable
+	fault  goja.Callable
+	result goja.Callable
+	enter  goja.Callable
+	exit   goja.Callable
+
+	host      tracer.StateHost
+	current   *step
+	callStack []*callFrame
+
+	// data of the top-level call passed to the result function
+	topCall  *callFrame
+	gasLimit uint64
+	gasUsed  uint64
+	output   []byte
+	callErr  error
+}
+
+// NewJSTracer compiles the given code, the tracer object is evaluated by Setup
+// or once the tracing starts, so the scripts run only after the timeout of the tracing is set
+func NewJSTracer(code string, config json.RawMessage) (*JSTracer, error) {
+	// the code is wrapped so that the object literal is evaluated as an expression
+	program, err := goja.Compile("tracer", "("+code+")", false)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTracer, err)
+	}
+
+	t := &JSTracer{
+		program:    program,
+		config:     config,
+		cancelLock: sync.RWMutex{},
+		vm:         goja.New(),
+	}
+
+	t.log = t.newLogObject()
+	t.db = t.newDBObject()
+
+	return t, nil
+}
+
+// Setup evaluates the tracer object and calls its setup function
+func (t *JSTracer) Setup() error {
+	value, err := t.vm.RunProgram(t.program)
+	if err != nil {
+		return t.scriptError(err)
+	}
+
+	obj, ok := value.(*goja.Object)
+	if !ok {
+		return fmt.Errorf("%w: code must evaluate to an object", ErrInvalidTracer)
+	}
+
+	result, ok := goja.AssertFunction(obj.Get("result"))
+	if !ok {
+		return ErrMissingResult
+	}
+
+	if setup, ok := goja.AssertFunction(obj.Get("setup")); ok {
+		config := goja.Undefined()
+
+		if len(t.config) > 0 {
+			var decoded interface{}
+
+			if err := json.Unmarshal(t.config, &decoded); err != nil {
+				return fmt.Errorf("invalid tracer config: %w", err)
+			}
+
+			config = t.vm.ToValue(decoded)
+		}
+
+		if _, err := setup(obj, config); err != nil {
+			return t.scriptError(err)
+		}
+	}
+
+	t.obj = obj
+	t.result = result
+	t.step, _ = goja.AssertFunction(obj.Get("step"))
+	t.fault, _ = goja.AssertFunction(obj.Get("fault"))
+	t.enter, _ = goja.AssertFunction(obj.Get("enter"))
+	t.exit, _ = goja.AssertFunction(obj.Get("exit"))
+
+	return nil
+}
+
+// scriptError returns the reason of the cancellation if the script has been interrupted by it
+func (t *JSTracer) scriptError(err error) error {
+	if reason := t.cancelReason(); reason != nil {
+		return reason
+	}
+
+	return fmt.Errorf("%w: %v", ErrInvalidTracer, err)
+}
+
+// ready evaluates the tracer object for the transaction if it's not evaluated yet,
+// it returns false if the tracing is stopped
+func (t *JSTracer) ready() bool {
+	if t.cancelled() {
+		return false
+	}
+
+	if t.obj != nil {
+		return true
+	}
+
+	if err := t.Setup(); err != nil {
+		t.abort(err)
+
+		return false
+	}
+
+	return true
+}
+
+func (t *JSTracer) Cancel(err error) {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	t.reason = err
+	t.interrupt = true
+	t.cancelErr = err
+
+	// stop the script if it's running, the following scripts are stopped as soon as they start
+	t.vm.Interrupt(err)
+}
+
+func (t *JSTracer) cancelled() bool {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.interrupt
+}
+
+func (t *JSTracer) cancelReason() error {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.reason
+}
+
+// abort stops the tracing due to the error thrown by the script
+func (t *JSTracer) abort(err error) {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	if t.reason == nil {
+		t.reason = err
+	}
+
+	t.interrupt = true
+}
+
+// Clear resets the tracer for the next transaction, the tracer object is evaluated again
+// in the same runtime, so the cancellation applies to all transactions traced by the tracer
+func (t *JSTracer) Clear() {
+	t.cancelLock.Lock()
+	t.reason = t.cancelErr
+	t.interrupt = t.cancelErr != nil
+	t.cancelLock.Unlock()
+
+	t.host = nil
+	t.current = nil
+	t.callStack = t.callStack[:0]
+	t.topCall = nil
+	t.gasLimit = 0
+	t.gasUsed = 0
+	t.output = nil
+	t.callErr = nil
+	t.obj = nil
+}
+
+func (t *JSTracer) TxStart(gasLimit uint64) {
+	t.gasLimit = gasLimit
+
+	t.ready()
+}
+
+func (t *JSTracer) TxEnd(gasLeft uint64) {
+	t.gasUsed = t.gasLimit - gasLeft
+}
+
+func (t *JSTracer) TxStateStart(
+	host tracer.StateHost,
+	from types.Address,
+	to *types.Address,
+	coinbase types.Address,
+) {
+	t.host = host
+}
+
+func (t *JSTracer) TxStateEnd(host tracer.StateHost) {
+}
+
+func (t *JSTracer) CallStart(
+	depth int,
+	from, to types.Address,
+	callType int,
+	gas uint64,
+	value *big.Int,
+	input []byte,
+) {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	frame := &callFrame{
+		callType: callTypeToString(callType),
+		from:     from,
+		to:       to,
+		input:    input,
+		gas:      gas,
+		value:    new(big.Int).Set(value),
+	}
+
+	t.callStack = append(t.callStack, frame)
+
+	if t.topCall == nil {
+		t.topCall = frame
+
+		return
+	}
+
+	if !t.ready() || t.enter == nil {
+		return
+	}
+
+	if _, err := t.enter(t.obj, t.newFrameObject(frame)); err != nil {
+		t.abort(err)
+	}
+}
+
+func (t *JSTracer) CallEnd(
+	depth int,
+	output []byte,
+	gasLeft uint64,
+	err error,
+) {
+	if len(t.callStack) == 0 {
+		return
+	}
+
+	frame := t.callStack[len(t.callStack)-1]
+	t.callStack = t.callStack[:len(t.callStack)-1]
+
+	if len(t.callStack) == 0 {
+		t.output = output
+		t.callErr = err
+
+		return
+	}
+
+	if !t.ready() || t.exit == nil {
+		return
+	}
+
+	if _, jsErr := t.exit(t.obj, t.newFrameResultObject(frame.gas-gasLeft, output, err)); jsErr != nil {
+		t.abort(jsErr)
+	}
+}
+
+func (t *JSTracer) CaptureState(
+	memory []byte,
+	stack []*big.Int,
+	opCode int,
+	contractAddress types.Address,
+	sp int,
+	host tracer.RuntimeHost,
+	state tracer.VMState,
+) {
+	if !t.ready() {
+		state.Halt()
+
+		return
+	}
+
+	if t.step == nil && t.fault == nil {
+		return
+	}
+
+	t.current = newStep(opCode, contractAddress, stack[:sp], memory, host)
+}
+
+func (t *JSTracer) ExecuteState(
+	contractAddress types.Address,
+	ip uint64,
+	opCode string,
+	availableGas uint64,
+	cost uint64,
+	lastReturnData []byte,
+	depth int,
+	err error,
+	host tracer.RuntimeHost,
+) {
+	current := t.current
+	if current == nil || t.cancelled() {
+		return
+	}
+
+	t.current = nil
+
+	current.pc = ip
+	current.gas = availableGas
+	current.cost = cost
+	current.depth = depth
+	current.returnData = lastReturnData
+	current.err = err
+
+	// the revert is the result of the opcode rather than a failure of the execution
+	fn := t.step
+	if err != nil && !errors.Is(err, runtime.ErrExecutionReverted) {
+		fn = t.fault
+	}
+
+	if fn == nil {
+		return
+	}
+
+	// the log object reads the current step
+	t.current = current
+	defer func() {
+		t.current = nil
+	}()
+
+	if _, jsErr := fn(t.obj, t.log, t.db); jsErr != nil {
+		t.abort(jsErr)
+	}
+}
+
+func (t *JSTracer) GetResult() (interface{}, error) {
+	if !t.ready() {
+		return nil, t.cancelReason()
+	}
+
+	res, err := t.result(t.obj, t.newContextObject(), t.db)
+	if err != nil {
+		// the script has been interrupted by the cancellation
+		if reason := t.cancelReason(); reason != nil {
+			return nil, reason
+		}
+
+		return nil, err
+	}
+
+	stringify, ok := goja.AssertFunction(t.vm.Get("JSON").ToObject(t.vm).Get("stringify"))
+	if !ok {
+		return nil, fmt.Errorf("%w: JSON.stringify is not available", ErrInvalidTracer)
+	}
+
+	encoded, err := stringify(goja.Undefined(), res)
+	if err != nil {
+		return nil, err
+	}
+
+	// undefined result can't be encoded
+	if goja.IsUndefined(encoded) {
+		return nil, nil
+	}
+
+	return json.RawMessage(encoded.String()), nil
+}
+
+func callTypeToString(callType int) string {
+	switch runtime.CallType(callType) {
+	case runtime.Call:
+		return evm.OpCode(evm.CALL).String()
+	case runtime.CallCode:
+		return evm.OpCode(evm.CALLCODE).String()
+	case runtime.DelegateCall:
+		return evm.OpCode(evm.DELEGATECALL).String()
+	case runtime.StaticCall:
+		return evm.OpCode(evm.STATICCALL).String()
+	}
+
+	return evm.OpCode(callType).String()
+}
@@ -0,0 +1,530 @@
+package jstracer
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	testFrom  = types.StringToAddress("1")
+	testTo    = types.StringToAddress("2")
+	testInner = types.StringToAddress("3")
+)
+
+type mockState struct {
+	halted bool
+}
+
+func (m *mockState) Halt() {
+	m.halted = true
+}
+
+type mockRuntimeHost struct {
+	refund uint64
+}
+
+func (m *mockRuntimeHost) GetRefund() uint64 {
+	return m.refund
+}
+
+func (m *mockRuntimeHost) GetStorage(addr types.Address, slot types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
+type mockStateHost struct {
+	balances map[types.Address]*big.Int
+}
+
+func (m *mockStateHost) AccountExists(addr types.Address) bool {
+	_, ok := m.balances[addr]
+
+	return ok
+}
+
+func (m *mockStateHost) GetBalance(addr types.Address) *big.Int {
+	if balance, ok := m.balances[addr]; ok {
+		return balance
+	}
+
+	return big.NewInt(0)
+}
+
+func (m *mockStateHost) GetNonce(addr types.Address) uint64 {
+	return 1
+}
+
+func (m *mockStateHost) GetCode(addr types.Address) []byte {
+	return []byte{0x1}
+}
+
+func (m *mockStateHost) GetStorage(addr types.Address, slot types.Hash) types.Hash {
+	return types.StringToHash("a")
+}
+
+// executeOp runs the opcode through the tracer in the same way as EVM
+func executeOp(tracer *JSTracer, op int, stack []*big.Int, memory []byte, gas, cost uint64, err error) {
+	tracer.CaptureState(memory, stack, op, testTo, len(stack), &mockRuntimeHost{refund: 5}, &mockState{})
+	tracer.ExecuteState(testTo, 0, evm.OpCode(op).String(), gas, cost, nil, 1, err, &mockRuntimeHost{})
+}
+
+func getResult(t *testing.T, tracer *JSTracer) interface{} {
+	t.Helper()
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	raw, ok := res.(json.RawMessage)
+	require.True(t, ok)
+
+	var decoded interface{}
+
+	require.NoError(t, json.Unmarshal(raw, &decoded))
+
+	return decoded
+}
+
+func TestNewJSTracer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		code string
+		err  error
+	}{
+		{
+			name: "should create tracer",
+			code: `{result: function() { return {}; }}`,
+			err:  nil,
+		},
+		{
+			name: "should return error for syntax error",
+			code: `{result: function() {`,
+			err:  ErrInvalidTracer,
+		},
+		{
+			name: "should return error if code is not an object",
+			code: `1 + 1`,
+			err:  ErrInvalidTracer,
+		},
+		{
+			name: "should return error if result is missing",
+			code: `{step: function() {}}`,
+			err:  ErrMissingResult,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			tracer, err := NewJSTracer(test.code, nil)
+			if err == nil {
+				// the tracer object is evaluated by the setup
+				err = tracer.Setup()
+			}
+
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, tracer)
+			}
+		})
+	}
+}
+
+func TestJSTracerStep(t *testing.T) {
+	t.Parallel()
+
+	tracer, err := NewJSTracer(`{
+		ops: [],
+		step: function(log, db) {
+			this.ops.push({
+				op: log.op.toString(),
+				isPush: log.op.isPush(),
+				pc: log.getPC(),
+				gas: log.getGas(),
+				cost: log.getCost(),
+				depth: log.getDepth(),
+				refund: log.getRefund(),
+				stackLength: log.stack.length(),
+				top: log.stack.length() > 0 ? log.stack.peek(0) : null,
+				memory: log.memory.slice(0, log.memory.length()),
+				address: log.contract.getAddress(),
+				caller: log.contract.getCaller(),
+				value: log.contract.getValue()
+			});
+		},
+		fault: function(log, db) {
+			this.ops.push({op: log.op.toString(), error: log.getError()});
+		},
+		result: function(ctx, db) {
+			return {ops: this.ops, type: ctx.type, gasUsed: ctx.gasUsed, output: ctx.output};
+		}
+	}`, nil)
+	require.NoError(t, err)
+
+	tracer.TxStart(1000)
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 1000, big.NewInt(10), nil)
+	executeOp(tracer, evm.PUSH1, nil, nil, 1000, 3, nil)
+	executeOp(tracer, evm.ADD, []*big.Int{big.NewInt(1), big.NewInt(2)}, []byte{0xa}, 997, 3, nil)
+	executeOp(tracer, evm.POP, nil, nil, 994, 2, runtime.ErrOutOfGas)
+	tracer.CallEnd(1, []byte{0x1}, 0, runtime.ErrOutOfGas)
+	tracer.TxEnd(0)
+
+	assert.Equal(t, map[string]interface{}{
+		"ops": []interface{}{
+			map[string]interface{}{
+				"op":          "PUSH1",
+				"isPush":      true,
+				"pc":          float64(0),
+				"gas":         float64(1000),
+				"cost":        float64(3),
+				"depth":       float64(1),
+				"refund":      float64(5),
+				"stackLength": float64(0),
+				"top":         nil,
+				"memory":      "0x",
+				"address":     testTo.String(),
+				"caller":      testFrom.String(),
+				"value":       "0xa",
+			},
+			map[string]interface{}{
+				"op":          "ADD",
+				"isPush":      false,
+				"pc":          float64(0),
+				"gas":         float64(997),
+				"cost":        float64(3),
+				"depth":       float64(1),
+				"refund":      float64(5),
+				"stackLength": float64(2),
+				"top":         "0x2",
+				"memory":      "0x0a",
+				"address":     testTo.String(),
+				"caller":      testFrom.String(),
+				"value":       "0xa",
+			},
+			map[string]interface{}{
+				"op":    "POP",
+				"error": runtime.ErrOutOfGas.Error(),
+			},
+		},
+		"type":    "CALL",
+		"gasUsed": float64(1000),
+		"output":  "0x01",
+	}, getResult(t, tracer))
+}
+
+func TestJSTracerEnterExit(t *testing.T) {
+	t.Parallel()
+
+	tracer, err := NewJSTracer(`{
+		calls: [],
+		enter: function(frame) {
+			this.calls.push({type: frame.getType(), from: frame.getFrom(), to: frame.getTo(), gas: frame.getGas()});
+		},
+		exit: function(res) {
+			this.calls.push({gasUsed: res.getGasUsed(), output: res.getOutput(), error: res.getError()});
+		},
+		result: function() {
+			return this.calls;
+		}
+	}`, nil)
+	require.NoError(t, err)
+
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 1000, big.NewInt(0), nil)
+	tracer.CallStart(2, testTo, testInner, int(runtime.StaticCall), 500, big.NewInt(0), nil)
+	tracer.CallEnd(2, []byte{0x2}, 100, nil)
+	tracer.CallStart(2, testTo, testInner, evm.CREATE2, 300, big.NewInt(0), nil)
+	tracer.CallEnd(2, nil, 0, runtime.ErrExecutionReverted)
+	tracer.CallEnd(1, nil, 0, nil)
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"type": "STATICCALL",
+			"from": testTo.String(),
+			"to":   testInner.String(),
+			"gas":  float64(500),
+		},
+		map[string]interface{}{
+			"gasUsed": float64(400),
+			"output":  "0x02",
+		},
+		map[string]interface{}{
+			"type": "CREATE2",
+			"from": testTo.String(),
+			"to":   testInner.String(),
+			"gas":  float64(300),
+		},
+		map[string]interface{}{
+			"gasUsed": float64(300),
+			"output":  "0x",
+			"error":   runtime.ErrExecutionReverted.Error(),
+		},
+	}, getResult(t, tracer))
+}
+
+func TestJSTracerDB(t *testing.T) {
+	t.Parallel()
+
+	tracer, err := NewJSTracer(`{
+		result: function(ctx, db) {
+			return {
+				balance: db.getBalance(ctx.from),
+				nonce: db.getNonce(ctx.from),
+				code: db.getCode(ctx.to),
+				state: db.getState(ctx.to, "0x01"),
+				exists: db.exists(ctx.to)
+			};
+		}
+	}`, nil)
+	require.NoError(t, err)
+
+	host := &mockStateHost{
+		balances: map[types.Address]*big.Int{
+			testFrom: big.NewInt(100),
+		},
+	}
+
+	tracer.TxStateStart(host, testFrom, &testTo, types.ZeroAddress)
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 1000, big.NewInt(0), nil)
+	tracer.CallEnd(1, nil, 0, nil)
+	tracer.TxStateEnd(host)
+
+	assert.Equal(t, map[string]interface{}{
+		"balance": "0x64",
+		"nonce":   float64(1),
+		"code":    "0x01",
+		"state":   types.StringToHash("a").String(),
+		"exists":  false,
+	}, getResult(t, tracer))
+}
+
+func TestJSTracerSetup(t *testing.T) {
+	t.Parallel()
+
+	tracer, err := NewJSTracer(`{
+		setup: function(config) {
+			this.limit = config.limit;
+		},
+		result: function() {
+			return this.limit;
+		}
+	}`, json.RawMessage(`{"limit": 10}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, float64(10), getResult(t, tracer))
+}
+
+func TestJSTracerScriptError(t *testing.T) {
+	t.Parallel()
+
+	tracer, err := NewJSTracer(`{
+		step: function(log) {
+			log.stack.peek(10);
+		},
+		result: function() {
+			return {};
+		}
+	}`, nil)
+	require.NoError(t, err)
+
+	executeOp(tracer, evm.ADD, nil, nil, 1000, 3, nil)
+
+	// the tracing is stopped once the script throws
+	state := &mockState{}
+	tracer.CaptureState(nil, nil, evm.ADD, testTo, 0, nil, state)
+
+	assert.True(t, state.halted)
+
+	res, err := tracer.GetResult()
+
+	assert.Nil(t, res)
+	assert.Error(t, err)
+}
+
+func TestJSTracerCancel(t *testing.T) {
+	t.Parallel()
+
+	cancelErr := errors.New("timeout")
+
+	tracer, err := NewJSTracer(`{
+		result: function() {
+			while (true) {}
+		}
+	}`, nil)
+	require.NoError(t, err)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		tracer.Cancel(cancelErr)
+	}()
+
+	// the running script is interrupted by the cancellation
+	res, err := tracer.GetResult()
+
+	assert.Nil(t, res)
+	assert.Equal(t, cancelErr, err)
+
+	state := &mockState{}
+	tracer.CaptureState(nil, nil, evm.ADD, testTo, 0, nil, state)
+
+	assert.True(t, state.halted)
+}
+
+func TestJSTracerClear(t *testing.T) {
+	t.Parallel()
+
+	tracer, err := NewJSTracer(`{
+		count: 0,
+		step: function(log) {
+			this.count++;
+			if (this.count > 1) {
+				throw new Error("too many steps");
+			}
+		},
+		result: function() {
+			return this.count;
+		}
+	}`, nil)
+	require.NoError(t, err)
+
+	executeOp(tracer, evm.ADD, nil, nil, 1000, 3, nil)
+	executeOp(tracer, evm.ADD, nil, nil, 1000, 3, nil)
+
+	_, err = tracer.GetResult()
+	require.Error(t, err)
+
+	tracer.Clear()
+
+	assert.False(t, tracer.cancelled())
+	assert.Empty(t, tracer.callStack)
+	assert.Nil(t, tracer.topCall)
+
+	// the state of the script is reset
+	executeOp(tracer, evm.ADD, nil, nil, 1000, 3, nil)
+
+	assert.Equal(t, float64(1), getResult(t, tracer))
+}
+
+func TestJSTracerClear_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	cancelErr := errors.New("timeout")
+
+	tracer, err := NewJSTracer(`{
+		setup: function() {
+			while (true) {}
+		},
+		result: function() {
+			return {};
+		}
+	}`, nil)
+	require.NoError(t, err)
+
+	tracer.Cancel(cancelErr)
+	tracer.Clear()
+
+	// the cancellation applies to the next transaction, whose scripts are interrupted right away
+	assert.True(t, tracer.cancelled())
+
+	tracer.TxStart(1000)
+
+	state := &mockState{}
+	tracer.CaptureState(nil, nil, evm.ADD, testTo, 0, nil, state)
+
+	assert.True(t, state.halted)
+
+	res, err := tracer.GetResult()
+
+	assert.Nil(t, res)
+	assert.Equal(t, cancelErr, err)
+}
+
+func TestJSTracerSetup_Cancel(t *testing.T) {
+	t.Parallel()
+
+	cancelErr := errors.New("timeout")
+
+	tracer, err := NewJSTracer(`{
+		setup: function() {
+			for (;;) {}
+		},
+		result: function() {
+			return {};
+		}
+	}`, nil)
+	require.NoError(t, err)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		tracer.Cancel(cancelErr)
+	}()
+
+	// the setup is interrupted by the cancellation
+	assert.Equal(t, cancelErr, tracer.Setup())
+}
+
+func TestJSTracerStep_StateBeforeOpcode(t *testing.T) {
+	t.Parallel()
+
+	tracer, err := NewJSTracer(`{
+		steps: [],
+		step: function(log) {
+			this.steps.push({
+				top: log.stack.peek(0),
+				bottom: log.stack.peek(log.stack.length() - 1),
+				memory: log.memory.slice(0, log.memory.length()),
+				word: log.memory.getUint(0)
+			});
+		},
+		result: function() {
+			return this.steps;
+		}
+	}`, nil)
+	require.NoError(t, err)
+
+	stack := make([]*big.Int, 10)
+	for i := range stack {
+		stack[i] = big.NewInt(int64(i + 1))
+	}
+
+	// MSTORE of the value 9 at the offset 10
+	memory := make([]byte, 64)
+	memory[40] = 0x1
+
+	tracer.CaptureState(memory, stack, evm.MSTORE, testTo, len(stack), &mockRuntimeHost{}, &mockState{})
+
+	// the opcode modifies the stack and the memory before the step is passed to the script
+	stack[9].SetUint64(100)
+	stack[8].SetUint64(200)
+	memory[40] = 0xff
+	memory[10] = 0xff
+
+	tracer.ExecuteState(testTo, 0, evm.OpCode(evm.MSTORE).String(), 1000, 3, nil, 1, nil, &mockRuntimeHost{})
+
+	expectedMemory := make([]byte, 64)
+	expectedMemory[40] = 0x1
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"top":    "0xa",
+			"bottom": "0x1",
+			"memory": hex.EncodeToHex(expectedMemory),
+			"word":   "0x0",
+		},
+	}, getResult(t, tracer))
+}
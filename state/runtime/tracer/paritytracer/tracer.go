@@ -0,0 +1,319 @@
+package paritytracer
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// TraceTypeCall is the type of the trace for the message calls
+	TraceTypeCall = "call"
+	// TraceTypeCreate is the type of the trace for the contract creations
+	TraceTypeCreate = "create"
+	// TraceTypeSuicide is the type of the trace for the self destructs
+	TraceTypeSuicide = "suicide"
+)
+
+// Action is the action of the trace, the fields are set depending on the trace type
+type Action struct {
+	CallType      string         `json:"callType,omitempty"`
+	From          *types.Address `json:"from,omitempty"`
+	To            *types.Address `json:"to,omitempty"`
+	Gas           string         `json:"gas,omitempty"`
+	Input         string         `json:"input,omitempty"`
+	Init          string         `json:"init,omitempty"`
+	Value         string         `json:"value,omitempty"`
+	Address       *types.Address `json:"address,omitempty"`
+	RefundAddress *types.Address `json:"refundAddress,omitempty"`
+	Balance       string         `json:"balance,omitempty"`
+}
+
+// Result is the result of the trace, it's not set if the trace has failed
+type Result struct {
+	GasUsed string         `json:"gasUsed"`
+	Output  string         `json:"output,omitempty"`
+	Address *types.Address `json:"address,omitempty"`
+	Code    string         `json:"code,omitempty"`
+}
+
+// Trace is a single trace in the flat trace list, in the format of Parity/OpenEthereum
+type Trace struct {
+	Action       *Action `json:"action"`
+	Error        string  `json:"error,omitempty"`
+	Result       *Result `json:"result"`
+	Subtraces    int     `json:"subtraces"`
+	TraceAddress []int   `json:"traceAddress"`
+	Type         string  `json:"type"`
+}
+
+// From returns the address initiating the trace
+func (t *Trace) From() *types.Address {
+	if t.Type == TraceTypeSuicide {
+		return t.Action.Address
+	}
+
+	return t.Action.From
+}
+
+// To returns the address receiving the trace
+func (t *Trace) To() *types.Address {
+	switch t.Type {
+	case TraceTypeSuicide:
+		return t.Action.RefundAddress
+	case TraceTypeCreate:
+		if t.Result == nil {
+			return nil
+		}
+
+		return t.Result.Address
+	}
+
+	return t.Action.To
+}
+
+type frame struct {
+	trace    *Trace
+	gas      uint64
+	children int
+}
+
+// ParityTracer is the tracer which collects the flat list of the calls made by a transaction
+type ParityTracer struct {
+	cancelLock sync.RWMutex
+	reason     error
+	interrupt  bool
+
+	host      tracer.StateHost
+	traces    []*Trace
+	callStack []*frame
+}
+
+func NewParityTracer() *ParityTracer {
+	return &ParityTracer{
+		cancelLock: sync.RWMutex{},
+		traces:     []*Trace{},
+	}
+}
+
+func (t *ParityTracer) Cancel(err error) {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	t.reason = err
+	t.interrupt = true
+}
+
+func (t *ParityTracer) cancelled() bool {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.interrupt
+}
+
+func (t *ParityTracer) cancelReason() error {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.reason
+}
+
+func (t *ParityTracer) Clear() {
+	t.cancelLock.Lock()
+	t.reason = nil
+	t.interrupt = false
+	t.cancelLock.Unlock()
+
+	t.host = nil
+	t.traces = []*Trace{}
+	t.callStack = t.callStack[:0]
+}
+
+func (t *ParityTracer) TxStart(gasLimit uint64) {
+}
+
+func (t *ParityTracer) TxEnd(gasLeft uint64) {
+}
+
+func (t *ParityTracer) TxStateStart(
+	host tracer.StateHost,
+	from types.Address,
+	to *types.Address,
+	coinbase types.Address,
+) {
+	t.host = host
+}
+
+func (t *ParityTracer) TxStateEnd(host tracer.StateHost) {
+}
+
+func (t *ParityTracer) CallStart(
+	depth int,
+	from, to types.Address,
+	callType int,
+	gas uint64,
+	value *big.Int,
+	input []byte,
+) {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	trace := &Trace{
+		Action: &Action{
+			From:  from.Ptr(),
+			Gas:   hex.EncodeUint64(gas),
+			Value: hex.EncodeBig(value),
+		},
+	}
+
+	if callType == evm.CREATE || callType == evm.CREATE2 {
+		trace.Type = TraceTypeCreate
+		trace.Action.Init = hex.EncodeToHex(input)
+		// the address is set into the result once the contract is deployed
+		trace.Result = &Result{Address: to.Ptr()}
+	} else {
+		trace.Type = TraceTypeCall
+		trace.Action.CallType = callTypeToString(callType)
+		trace.Action.To = to.Ptr()
+		trace.Action.Input = hex.EncodeToHex(input)
+		trace.Result = &Result{}
+	}
+
+	t.addTrace(trace)
+	t.callStack = append(t.callStack, &frame{trace: trace, gas: gas})
+}
+
+func (t *ParityTracer) CallEnd(
+	depth int,
+	output []byte,
+	gasLeft uint64,
+	err error,
+) {
+	if len(t.callStack) == 0 {
+		return
+	}
+
+	current := t.callStack[len(t.callStack)-1]
+	t.callStack = t.callStack[:len(t.callStack)-1]
+
+	trace := current.trace
+
+	if err != nil {
+		trace.Error = errorToString(err)
+		trace.Result = nil
+
+		return
+	}
+
+	trace.Result.GasUsed = hex.EncodeUint64(current.gas - gasLeft)
+
+	if trace.Type == TraceTypeCreate {
+		trace.Result.Code = hex.EncodeToHex(output)
+	} else {
+		trace.Result.Output = hex.EncodeToHex(output)
+	}
+}
+
+func (t *ParityTracer) CaptureState(
+	memory []byte,
+	stack []*big.Int,
+	opCode int,
+	contractAddress types.Address,
+	sp int,
+	host tracer.RuntimeHost,
+	state tracer.VMState,
+) {
+	if t.cancelled() {
+		state.Halt()
+
+		return
+	}
+
+	if opCode != evm.SELFDESTRUCT || sp < 1 || t.host == nil {
+		return
+	}
+
+	refundAddress := types.BytesToAddress(stack[sp-1].Bytes())
+
+	t.addTrace(&Trace{
+		Type: TraceTypeSuicide,
+		Action: &Action{
+			Address:       contractAddress.Ptr(),
+			RefundAddress: &refundAddress,
+			Balance:       hex.EncodeBig(t.host.GetBalance(contractAddress)),
+		},
+	})
+}
+
+func (t *ParityTracer) ExecuteState(
+	contractAddress types.Address,
+	ip uint64,
+	opCode string,
+	availableGas uint64,
+	cost uint64,
+	lastReturnData []byte,
+	depth int,
+	err error,
+	host tracer.RuntimeHost,
+) {
+}
+
+func (t *ParityTracer) GetResult() (interface{}, error) {
+	if reason := t.cancelReason(); reason != nil {
+		return nil, reason
+	}
+
+	return t.traces, nil
+}
+
+// addTrace appends the trace as a child of the current call
+func (t *ParityTracer) addTrace(trace *Trace) {
+	trace.TraceAddress = []int{}
+
+	if len(t.callStack) > 0 {
+		parent := t.callStack[len(t.callStack)-1]
+
+		trace.TraceAddress = append(trace.TraceAddress, parent.trace.TraceAddress...)
+		trace.TraceAddress = append(trace.TraceAddress, parent.children)
+
+		parent.children++
+		parent.trace.Subtraces++
+	}
+
+	t.traces = append(t.traces, trace)
+}
+
+func callTypeToString(callType int) string {
+	switch runtime.CallType(callType) {
+	case runtime.CallCode:
+		return strings.ToLower(evm.OpCode(evm.CALLCODE).String())
+	case runtime.DelegateCall:
+		return strings.ToLower(evm.OpCode(evm.DELEGATECALL).String())
+	case runtime.StaticCall:
+		return strings.ToLower(evm.OpCode(evm.STATICCALL).String())
+	}
+
+	return strings.ToLower(evm.OpCode(evm.CALL).String())
+}
+
+// errorToString converts the execution error into the message used by Parity/OpenEthereum
+func errorToString(err error) string {
+	switch {
+	case errors.Is(err, runtime.ErrExecutionReverted):
+		return "Reverted"
+	case errors.Is(err, runtime.ErrOutOfGas):
+		return "Out of gas"
+	case errors.Is(err, runtime.ErrDepth):
+		return "Out of stack"
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,201 @@
+package paritytracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	testFrom   = types.StringToAddress("1")
+	testTo     = types.StringToAddress("2")
+	testInner  = types.StringToAddress("3")
+	testRefund = types.StringToAddress("4")
+)
+
+type mockState struct {
+	halted bool
+}
+
+func (m *mockState) Halt() {
+	m.halted = true
+}
+
+type mockStateHost struct {
+	balances map[types.Address]*big.Int
+}
+
+func (m *mockStateHost) AccountExists(addr types.Address) bool {
+	_, ok := m.balances[addr]
+
+	return ok
+}
+
+func (m *mockStateHost) GetBalance(addr types.Address) *big.Int {
+	if balance, ok := m.balances[addr]; ok {
+		return balance
+	}
+
+	return big.NewInt(0)
+}
+
+func (m *mockStateHost) GetNonce(addr types.Address) uint64 {
+	return 0
+}
+
+func (m *mockStateHost) GetCode(addr types.Address) []byte {
+	return nil
+}
+
+func (m *mockStateHost) GetStorage(addr types.Address, slot types.Hash) types.Hash {
+	return types.ZeroHash
+}
+
+func TestParityTracerCancel(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("timeout")
+
+	tracer := NewParityTracer()
+	tracer.Cancel(err)
+
+	state := &mockState{}
+	tracer.CaptureState(nil, nil, evm.ADD, testTo, 0, nil, state)
+
+	assert.True(t, state.halted)
+
+	res, resErr := tracer.GetResult()
+
+	assert.Nil(t, res)
+	assert.Equal(t, err, resErr)
+}
+
+func TestParityTracerNestedCalls(t *testing.T) {
+	t.Parallel()
+
+	host := &mockStateHost{
+		balances: map[types.Address]*big.Int{
+			testInner: big.NewInt(7),
+		},
+	}
+
+	tracer := NewParityTracer()
+
+	tracer.TxStateStart(host, testFrom, &testTo, types.ZeroAddress)
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 1000, big.NewInt(10), []byte{0x1})
+	tracer.CallStart(2, testTo, testInner, int(runtime.DelegateCall), 500, nil, nil)
+	tracer.CaptureState(nil, []*big.Int{new(big.Int).SetBytes(testRefund.Bytes())}, evm.SELFDESTRUCT,
+		testInner, 1, nil, &mockState{})
+	tracer.CallEnd(2, nil, 100, nil)
+	tracer.CallStart(2, testTo, testInner, evm.CREATE, 300, big.NewInt(0), []byte{0x2})
+	tracer.CallEnd(2, nil, 0, runtime.ErrOutOfGas)
+	tracer.CallEnd(1, []byte{0x3}, 200, nil)
+
+	res, err := tracer.GetResult()
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*Trace{
+		{
+			Type: TraceTypeCall,
+			Action: &Action{
+				CallType: "call",
+				From:     &testFrom,
+				To:       &testTo,
+				Gas:      hex.EncodeUint64(1000),
+				Input:    "0x01",
+				Value:    "0xa",
+			},
+			Result: &Result{
+				GasUsed: hex.EncodeUint64(800),
+				Output:  "0x03",
+			},
+			Subtraces:    2,
+			TraceAddress: []int{},
+		},
+		{
+			Type: TraceTypeCall,
+			Action: &Action{
+				CallType: "delegatecall",
+				From:     &testTo,
+				To:       &testInner,
+				Gas:      hex.EncodeUint64(500),
+				Input:    "0x",
+				Value:    "0x0",
+			},
+			Result: &Result{
+				GasUsed: hex.EncodeUint64(400),
+				Output:  "0x",
+			},
+			Subtraces:    1,
+			TraceAddress: []int{0},
+		},
+		{
+			Type: TraceTypeSuicide,
+			Action: &Action{
+				Address:       &testInner,
+				RefundAddress: &testRefund,
+				Balance:       "0x7",
+			},
+			TraceAddress: []int{0, 0},
+		},
+		{
+			Type: TraceTypeCreate,
+			Action: &Action{
+				From:  &testTo,
+				Gas:   hex.EncodeUint64(300),
+				Init:  "0x02",
+				Value: "0x0",
+			},
+			Error:        "Out of gas",
+			TraceAddress: []int{1},
+		},
+	}, res)
+}
+
+func TestParityTracerTraceAddresses(t *testing.T) {
+	t.Parallel()
+
+	trace := &Trace{
+		Type:   TraceTypeCreate,
+		Action: &Action{From: &testFrom},
+		Result: &Result{Address: &testTo},
+	}
+
+	assert.Equal(t, &testFrom, trace.From())
+	assert.Equal(t, &testTo, trace.To())
+
+	trace.Result = nil
+
+	assert.Nil(t, trace.To())
+
+	trace = &Trace{
+		Type:   TraceTypeSuicide,
+		Action: &Action{Address: &testInner, RefundAddress: &testRefund},
+	}
+
+	assert.Equal(t, &testInner, trace.From())
+	assert.Equal(t, &testRefund, trace.To())
+}
+
+func TestParityTracerClear(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewParityTracer()
+
+	tracer.CallStart(1, testFrom, testTo, int(runtime.Call), 1000, big.NewInt(0), nil)
+	tracer.Cancel(errors.New("timeout"))
+
+	tracer.Clear()
+
+	assert.False(t, tracer.cancelled())
+	assert.Empty(t, tracer.traces)
+	assert.Empty(t, tracer.callStack)
+	assert.Nil(t, tracer.host)
+}
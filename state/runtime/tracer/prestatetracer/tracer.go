@@ -0,0 +1,382 @@
+package prestatetracer
+
+import (
+	"bytes"
+	"math/big"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type Config struct {
+	DiffMode bool `json:"diffMode"` // return the differences between the states before and after the transaction
+}
+
+// Account is the state of an account in the format of the prestateTracer in Geth
+type Account struct {
+	Balance string                    `json:"balance,omitempty"`
+	Code    string                    `json:"code,omitempty"`
+	Nonce   uint64                    `json:"nonce,omitempty"`
+	Storage map[types.Hash]types.Hash `json:"storage,omitempty"`
+}
+
+// DiffResult is the result of the tracer in the diff mode
+type DiffResult struct {
+	Pre  map[types.Address]*Account `json:"pre"`
+	Post map[types.Address]*Account `json:"post"`
+}
+
+type account struct {
+	exists  bool
+	balance *big.Int
+	nonce   uint64
+	code    []byte
+	storage map[types.Hash]types.Hash
+}
+
+func (a *account) toResult() *Account {
+	res := &Account{
+		Nonce: a.nonce,
+	}
+
+	if a.balance != nil {
+		res.Balance = hex.EncodeBig(a.balance)
+	}
+
+	if len(a.code) > 0 {
+		res.Code = hex.EncodeToHex(a.code)
+	}
+
+	if len(a.storage) > 0 {
+		res.Storage = a.storage
+	}
+
+	return res
+}
+
+// PrestateTracer is the tracer which collects the state of the accounts touched by a transaction
+type PrestateTracer struct {
+	Config Config
+
+	cancelLock sync.RWMutex
+	reason     error
+	interrupt  bool
+
+	host    tracer.StateHost
+	pre     map[types.Address]*account
+	post    map[types.Address]*account
+	created map[types.Address]struct{}
+	deleted map[types.Address]struct{}
+}
+
+func NewPrestateTracer(config Config) *PrestateTracer {
+	return &PrestateTracer{
+		Config:     config,
+		cancelLock: sync.RWMutex{},
+		pre:        make(map[types.Address]*account),
+		post:       make(map[types.Address]*account),
+		created:    make(map[types.Address]struct{}),
+		deleted:    make(map[types.Address]struct{}),
+	}
+}
+
+func (t *PrestateTracer) Cancel(err error) {
+	t.cancelLock.Lock()
+	defer t.cancelLock.Unlock()
+
+	t.reason = err
+	t.interrupt = true
+}
+
+func (t *PrestateTracer) cancelled() bool {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.interrupt
+}
+
+func (t *PrestateTracer) cancelReason() error {
+	t.cancelLock.RLock()
+	defer t.cancelLock.RUnlock()
+
+	return t.reason
+}
+
+func (t *PrestateTracer) Clear() {
+	t.cancelLock.Lock()
+	t.reason = nil
+	t.interrupt = false
+	t.cancelLock.Unlock()
+
+	t.host = nil
+	t.pre = make(map[types.Address]*account)
+	t.post = make(map[types.Address]*account)
+	t.created = make(map[types.Address]struct{})
+	t.deleted = make(map[types.Address]struct{})
+}
+
+func (t *PrestateTracer) TxStart(gasLimit uint64) {
+}
+
+func (t *PrestateTracer) TxEnd(gasLeft uint64) {
+}
+
+func (t *PrestateTracer) TxStateStart(
+	host tracer.StateHost,
+	from types.Address,
+	to *types.Address,
+	coinbase types.Address,
+) {
+	t.host = host
+
+	t.lookupAccount(from)
+	t.lookupAccount(coinbase)
+
+	if to != nil {
+		t.lookupAccount(*to)
+
+		return
+	}
+
+	t.lookupCreatedAccount(crypto.CreateAddress(from, host.GetNonce(from)))
+}
+
+func (t *PrestateTracer) TxStateEnd(host tracer.StateHost) {
+	if !t.Config.DiffMode {
+		return
+	}
+
+	for addr, pre := range t.pre {
+		// the state of the deleted account is kept only in the pre state
+		if _, ok := t.deleted[addr]; ok {
+			continue
+		}
+
+		var (
+			modified = false
+			post     = &account{storage: make(map[types.Hash]types.Hash)}
+		)
+
+		if balance := host.GetBalance(addr); balance.Cmp(pre.balance) != 0 {
+			modified = true
+			post.balance = new(big.Int).Set(balance)
+		}
+
+		if nonce := host.GetNonce(addr); nonce != pre.nonce {
+			modified = true
+			post.nonce = nonce
+		}
+
+		if code := host.GetCode(addr); !bytes.Equal(code, pre.code) {
+			modified = true
+			post.code = code
+		}
+
+		for slot, value := range pre.storage {
+			newValue := host.GetStorage(addr, slot)
+
+			// unchanged and empty slots are omitted in the pre state
+			if value == newValue || value == types.ZeroHash {
+				delete(pre.storage, slot)
+			}
+
+			if value != newValue {
+				modified = true
+
+				if newValue != types.ZeroHash {
+					post.storage[slot] = newValue
+				}
+			}
+		}
+
+		if modified {
+			t.post[addr] = post
+		} else {
+			// the account is not included in the pre state if it has not been modified
+			delete(t.pre, addr)
+		}
+	}
+
+	// the accounts created by the transaction didn't have any pre state
+	for addr := range t.created {
+		if pre, ok := t.pre[addr]; ok && !pre.exists {
+			delete(t.pre, addr)
+		}
+	}
+}
+
+func (t *PrestateTracer) CallStart(
+	depth int,
+	from, to types.Address,
+	callType int,
+	gas uint64,
+	value *big.Int,
+	input []byte,
+) {
+}
+
+func (t *PrestateTracer) CallEnd(
+	depth int,
+	output []byte,
+	gasLeft uint64,
+	err error,
+) {
+}
+
+func (t *PrestateTracer) CaptureState(
+	memory []byte,
+	stack []*big.Int,
+	opCode int,
+	contractAddress types.Address,
+	sp int,
+	host tracer.RuntimeHost,
+	state tracer.VMState,
+) {
+	if t.cancelled() {
+		state.Halt()
+
+		return
+	}
+
+	if t.host == nil {
+		return
+	}
+
+	switch opCode {
+	case evm.SLOAD, evm.SSTORE:
+		if sp < 1 {
+			return
+		}
+
+		t.lookupStorage(contractAddress, types.BytesToHash(stack[sp-1].Bytes()))
+
+	case evm.BALANCE, evm.EXTCODESIZE, evm.EXTCODECOPY, evm.EXTCODEHASH:
+		if sp < 1 {
+			return
+		}
+
+		t.lookupAccount(types.BytesToAddress(stack[sp-1].Bytes()))
+
+	case evm.SELFDESTRUCT:
+		if sp < 1 {
+			return
+		}
+
+		t.lookupAccount(types.BytesToAddress(stack[sp-1].Bytes()))
+		t.deleted[contractAddress] = struct{}{}
+
+	case evm.CALL, evm.CALLCODE, evm.DELEGATECALL, evm.STATICCALL:
+		if sp < 2 {
+			return
+		}
+
+		t.lookupAccount(types.BytesToAddress(stack[sp-2].Bytes()))
+
+	case evm.CREATE:
+		t.lookupCreatedAccount(crypto.CreateAddress(contractAddress, t.host.GetNonce(contractAddress)))
+
+	case evm.CREATE2:
+		if sp < 4 {
+			return
+		}
+
+		offset, size := stack[sp-2], stack[sp-3]
+		if !offset.IsUint64() || !size.IsUint64() {
+			return
+		}
+
+		var (
+			initCode []byte
+			salt     [32]byte
+		)
+
+		if size.Uint64() > 0 {
+			// init code is taken only if it's already placed in the memory
+			start, end := offset.Uint64(), offset.Uint64()+size.Uint64()
+			if end < start || end > uint64(len(memory)) {
+				return
+			}
+
+			initCode = memory[start:end]
+		}
+
+		copy(salt[:], types.BytesToHash(stack[sp-4].Bytes()).Bytes())
+
+		t.lookupCreatedAccount(crypto.CreateAddress2(contractAddress, salt, initCode))
+	}
+}
+
+func (t *PrestateTracer) ExecuteState(
+	contractAddress types.Address,
+	ip uint64,
+	opCode string,
+	availableGas uint64,
+	cost uint64,
+	lastReturnData []byte,
+	depth int,
+	err error,
+	host tracer.RuntimeHost,
+) {
+}
+
+func (t *PrestateTracer) GetResult() (interface{}, error) {
+	if reason := t.cancelReason(); reason != nil {
+		return nil, reason
+	}
+
+	pre := make(map[types.Address]*Account, len(t.pre))
+	for addr, acc := range t.pre {
+		pre[addr] = acc.toResult()
+	}
+
+	if !t.Config.DiffMode {
+		return pre, nil
+	}
+
+	post := make(map[types.Address]*Account, len(t.post))
+	for addr, acc := range t.post {
+		post[addr] = acc.toResult()
+	}
+
+	return &DiffResult{
+		Pre:  pre,
+		Post: post,
+	}, nil
+}
+
+// lookupAccount fetches the account from the state if it is not tracked yet
+func (t *PrestateTracer) lookupAccount(addr types.Address) {
+	if _, ok := t.pre[addr]; ok {
+		return
+	}
+
+	t.pre[addr] = &account{
+		exists:  t.host.AccountExists(addr),
+		balance: new(big.Int).Set(t.host.GetBalance(addr)),
+		nonce:   t.host.GetNonce(addr),
+		code:    t.host.GetCode(addr),
+		storage: make(map[types.Hash]types.Hash),
+	}
+}
+
+// lookupCreatedAccount tracks the account which is about to be created
+func (t *PrestateTracer) lookupCreatedAccount(addr types.Address) {
+	t.lookupAccount(addr)
+	t.created[addr] = struct{}{}
+}
+
+// lookupStorage fetches the storage slot from the state if it is not tracked yet
+func (t *PrestateTracer) lookupStorage(addr types.Address, slot types.Hash) {
+	t.lookupAccount(addr)
+
+	storage := t.pre[addr].storage
+	if _, ok := storage[slot]; ok {
+		return
+	}
+
+	storage[slot] = t.host.GetStorage(addr, slot)
+}
@@ -0,0 +1,288 @@
+package prestatetracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	testFrom     = types.StringToAddress("1")
+	testTo       = types.StringToAddress("2")
+	testCoinbase = types.StringToAddress("3")
+	testOther    = types.StringToAddress("4")
+
+	testSlot1 = types.StringToHash("1")
+	testSlot2 = types.StringToHash("2")
+)
+
+type mockState struct {
+	halted bool
+}
+
+func (m *mockState) Halt() {
+	m.halted = true
+}
+
+type mockAccount struct {
+	balance *big.Int
+	nonce   uint64
+	code    []byte
+	storage map[types.Hash]types.Hash
+}
+
+type mockStateHost struct {
+	accounts map[types.Address]*mockAccount
+}
+
+func newMockStateHost() *mockStateHost {
+	return &mockStateHost{
+		accounts: map[types.Address]*mockAccount{
+			testFrom: {
+				balance: big.NewInt(1000),
+				nonce:   5,
+			},
+			testTo: {
+				balance: big.NewInt(10),
+				nonce:   1,
+				code:    []byte{0x1},
+				storage: map[types.Hash]types.Hash{
+					testSlot1: types.StringToHash("10"),
+				},
+			},
+			testCoinbase: {
+				balance: big.NewInt(0),
+			},
+		},
+	}
+}
+
+func (m *mockStateHost) AccountExists(addr types.Address) bool {
+	_, ok := m.accounts[addr]
+
+	return ok
+}
+
+func (m *mockStateHost) GetBalance(addr types.Address) *big.Int {
+	if acc, ok := m.accounts[addr]; ok {
+		return acc.balance
+	}
+
+	return big.NewInt(0)
+}
+
+func (m *mockStateHost) GetNonce(addr types.Address) uint64 {
+	if acc, ok := m.accounts[addr]; ok {
+		return acc.nonce
+	}
+
+	return 0
+}
+
+func (m *mockStateHost) GetCode(addr types.Address) []byte {
+	if acc, ok := m.accounts[addr]; ok {
+		return acc.code
+	}
+
+	return nil
+}
+
+func (m *mockStateHost) GetStorage(addr types.Address, slot types.Hash) types.Hash {
+	if acc, ok := m.accounts[addr]; ok {
+		return acc.storage[slot]
+	}
+
+	return types.ZeroHash
+}
+
+func toStack(values ...[]byte) []*big.Int {
+	stack := make([]*big.Int, len(values))
+	for i, v := range values {
+		stack[i] = new(big.Int).SetBytes(v)
+	}
+
+	return stack
+}
+
+func TestPrestateTracerCancel(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("timeout")
+
+	tracer := NewPrestateTracer(Config{})
+	tracer.Cancel(err)
+
+	state := &mockState{}
+	tracer.CaptureState(nil, nil, evm.SLOAD, testTo, 0, nil, state)
+
+	assert.True(t, state.halted)
+
+	res, resErr := tracer.GetResult()
+
+	assert.Nil(t, res)
+	assert.Equal(t, err, resErr)
+}
+
+func TestPrestateTracerCancelConcurrently(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("timeout")
+	tracer := NewPrestateTracer(Config{})
+
+	done := make(chan struct{})
+
+	// the timeout goroutine cancels the tracer while the result is read
+	go func() {
+		defer close(done)
+
+		tracer.Cancel(err)
+	}()
+
+	_, _ = tracer.GetResult()
+
+	<-done
+
+	_, resErr := tracer.GetResult()
+	assert.Equal(t, err, resErr)
+}
+
+func TestPrestateTracerPrestate(t *testing.T) {
+	t.Parallel()
+
+	host := newMockStateHost()
+	tracer := NewPrestateTracer(Config{})
+
+	tracer.TxStateStart(host, testFrom, &testTo, testCoinbase)
+
+	// SLOAD slot1 and BALANCE of other account
+	tracer.CaptureState(nil, toStack(testSlot1.Bytes()), evm.SLOAD, testTo, 1, nil, &mockState{})
+	tracer.CaptureState(nil, toStack(testOther.Bytes()), evm.BALANCE, testTo, 1, nil, &mockState{})
+
+	tracer.TxStateEnd(host)
+
+	res, err := tracer.GetResult()
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[types.Address]*Account{
+		testFrom: {
+			Balance: "0x3e8",
+			Nonce:   5,
+		},
+		testTo: {
+			Balance: "0xa",
+			Nonce:   1,
+			Code:    "0x01",
+			Storage: map[types.Hash]types.Hash{
+				testSlot1: types.StringToHash("10"),
+			},
+		},
+		testCoinbase: {
+			Balance: "0x0",
+		},
+		testOther: {
+			Balance: "0x0",
+		},
+	}, res)
+}
+
+func TestPrestateTracerDiffMode(t *testing.T) {
+	t.Parallel()
+
+	host := newMockStateHost()
+	tracer := NewPrestateTracer(Config{DiffMode: true})
+
+	tracer.TxStateStart(host, testFrom, &testTo, testCoinbase)
+
+	tracer.CaptureState(nil, toStack(testSlot1.Bytes()), evm.SSTORE, testTo, 1, nil, &mockState{})
+	tracer.CaptureState(nil, toStack(testSlot2.Bytes()), evm.SLOAD, testTo, 1, nil, &mockState{})
+
+	// apply the transaction
+	host.accounts[testFrom].balance = big.NewInt(900)
+	host.accounts[testFrom].nonce = 6
+	host.accounts[testTo].storage[testSlot1] = types.StringToHash("20")
+
+	tracer.TxStateEnd(host)
+
+	res, err := tracer.GetResult()
+	assert.NoError(t, err)
+
+	assert.Equal(t, &DiffResult{
+		Pre: map[types.Address]*Account{
+			testFrom: {
+				Balance: "0x3e8",
+				Nonce:   5,
+			},
+			testTo: {
+				Balance: "0xa",
+				Nonce:   1,
+				Code:    "0x01",
+				Storage: map[types.Hash]types.Hash{
+					testSlot1: types.StringToHash("10"),
+				},
+			},
+		},
+		Post: map[types.Address]*Account{
+			testFrom: {
+				Balance: "0x384",
+				Nonce:   6,
+			},
+			testTo: {
+				Storage: map[types.Hash]types.Hash{
+					testSlot1: types.StringToHash("20"),
+				},
+			},
+		},
+	}, res)
+}
+
+func TestPrestateTracerDiffModeCreation(t *testing.T) {
+	t.Parallel()
+
+	host := newMockStateHost()
+	tracer := NewPrestateTracer(Config{DiffMode: true})
+
+	created := crypto.CreateAddress(testFrom, 5)
+
+	tracer.TxStateStart(host, testFrom, nil, testCoinbase)
+
+	// apply the transaction
+	host.accounts[testFrom].nonce = 6
+	host.accounts[created] = &mockAccount{
+		balance: big.NewInt(0),
+		nonce:   1,
+		code:    []byte{0x2},
+	}
+
+	tracer.TxStateEnd(host)
+
+	res, err := tracer.GetResult()
+	assert.NoError(t, err)
+
+	diff, ok := res.(*DiffResult)
+	assert.True(t, ok)
+
+	assert.NotContains(t, diff.Pre, created)
+	assert.Equal(t, &Account{Nonce: 1, Code: "0x02"}, diff.Post[created])
+}
+
+func TestPrestateTracerClear(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewPrestateTracer(Config{})
+
+	tracer.TxStateStart(newMockStateHost(), testFrom, &testTo, testCoinbase)
+	tracer.Cancel(errors.New("timeout"))
+
+	tracer.Clear()
+
+	assert.False(t, tracer.cancelled())
+	assert.Nil(t, tracer.host)
+	assert.Empty(t, tracer.pre)
+	assert.Empty(t, tracer.post)
+}
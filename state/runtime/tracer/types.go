@@ -14,6 +14,20 @@ type RuntimeHost interface {
 	GetStorage(types.Address, types.Hash) types.Hash
 }
 
+// StateHost is the interface defining the methods for accessing the world state by tracer
+type StateHost interface {
+	// AccountExists returns true if the account exists in the state
+	AccountExists(types.Address) bool
+	// GetBalance returns the balance of the given account
+	GetBalance(types.Address) *big.Int
+	// GetNonce returns the nonce of the given account
+	GetNonce(types.Address) uint64
+	// GetCode returns the code of the given account
+	GetCode(types.Address) []byte
+	// GetStorage access the storage slot at the given address and slot hash
+	GetStorage(types.Address, types.Hash) types.Hash
+}
+
 type VMState interface {
 	// Halt tells VM to terminate its process
 	Halt()
@@ -43,6 +57,7 @@ type Tracer interface {
 	CallEnd(
 		depth int, // begins from 1
 		output []byte,
+		gasLeft uint64,
 		err error,
 	)
 
@@ -68,3 +83,17 @@ type Tracer interface {
 		host RuntimeHost,
 	)
 }
+
+// StateTracer is an optional interface of Tracer
+// which enables the tracer to inspect the world state around a transaction
+type StateTracer interface {
+	// TxStateStart is called before the transaction modifies the state
+	TxStateStart(
+		host StateHost,
+		from types.Address,
+		to *types.Address,
+		coinbase types.Address,
+	)
+	// TxStateEnd is called after the transaction has been applied
+	TxStateEnd(host StateHost)
+}
@@ -2,6 +2,7 @@ package state
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -11,6 +12,9 @@ import (
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
+// ErrStatePruned is returned if the state at the requested root was removed by the pruning
+var ErrStatePruned = errors.New("historical state is not available, it was pruned")
+
 type State interface {
 	NewSnapshotAt(types.Hash) (Snapshot, error)
 	NewSnapshot() Snapshot
@@ -20,6 +24,11 @@ type State interface {
 type Snapshot interface {
 	readSnapshot
 
+	// GetAccountProof returns the merkle proof of the account in the state trie
+	GetAccountProof(addr types.Address) ([][]byte, error)
+	// GetStorageProof returns the merkle proof of the slot in the storage trie with the given root
+	GetStorageProof(addr types.Address, root types.Hash, key types.Hash) ([][]byte, error)
+
 	Commit(objs []*Object) (Snapshot, []byte)
 }
 
@@ -28,6 +28,15 @@ var (
 
 	// refundIndex is the index of the refund
 	refundIndex = types.BytesToHash([]byte{3}).Bytes()
+
+	// accessListIndex is the prefix of the addresses and the slots in the access list (EIP-2929)
+	accessListIndex = types.BytesToHash([]byte{4}).Bytes()
+
+	// transientStorageIndex is the prefix of the transient storage (EIP-1153)
+	transientStorageIndex = types.BytesToHash([]byte{5}).Bytes()
+
+	// createdIndex is the prefix of the contracts created in the transaction (EIP-6780)
+	createdIndex = types.BytesToHash([]byte{6}).Bytes()
 )
 
 // Txn is a reference of the state
@@ -272,13 +281,19 @@ func (txn *Txn) SetStorage(
 	if original == value {
 		if original == types.ZeroHash { // reset to original nonexistent slot (2.2.2.1)
 			// Storage was used as memory (allocation and deallocation occurred within the same contract)
-			if config.Istanbul {
+			if config.Berlin {
+				// eip-2929
+				txn.AddRefund(19900)
+			} else if config.Istanbul {
 				txn.AddRefund(19200)
 			} else {
 				txn.AddRefund(19800)
 			}
 		} else { // reset to original existing slot (2.2.2.2)
-			if config.Istanbul {
+			if config.Berlin {
+				// eip-2929
+				txn.AddRefund(2800)
+			} else if config.Istanbul {
 				txn.AddRefund(4200)
 			} else {
 				txn.AddRefund(4800)
@@ -458,6 +473,84 @@ func (txn *Txn) Logs() []*types.Log {
 	return data.([]*types.Log)
 }
 
+// indexKey returns the key of the address or the slot of the address under the given index
+func indexKey(index []byte, addr types.Address, slot *types.Hash) []byte {
+	key := make([]byte, 0, len(index)+types.AddressLength+types.HashLength)
+	key = append(key, index...)
+	key = append(key, addr.Bytes()...)
+
+	if slot != nil {
+		key = append(key, slot.Bytes()...)
+	}
+
+	return key
+}
+
+// AddAddressToAccessList adds the address to the access list of the transaction.
+// The access list is kept in the trie, so that it is reverted together with the state
+func (txn *Txn) AddAddressToAccessList(addr types.Address) {
+	txn.txn.Insert(indexKey(accessListIndex, addr, nil), true)
+}
+
+// AddSlotToAccessList adds the slot and its address to the access list of the transaction
+func (txn *Txn) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	txn.AddAddressToAccessList(addr)
+	txn.txn.Insert(indexKey(accessListIndex, addr, &slot), true)
+}
+
+// AddressInAccessList returns true if the address is in the access list of the transaction
+func (txn *Txn) AddressInAccessList(addr types.Address) bool {
+	_, exists := txn.txn.Get(indexKey(accessListIndex, addr, nil))
+
+	return exists
+}
+
+// SlotInAccessList returns whether the address and the slot are in the access list of the transaction
+func (txn *Txn) SlotInAccessList(addr types.Address, slot types.Hash) (bool, bool) {
+	addrOk := txn.AddressInAccessList(addr)
+	if !addrOk {
+		return false, false
+	}
+
+	_, slotOk := txn.txn.Get(indexKey(accessListIndex, addr, &slot))
+
+	return addrOk, slotOk
+}
+
+// SetTransientState sets the transient storage of the address, which is discarded at the end of the transaction
+func (txn *Txn) SetTransientState(addr types.Address, key, value types.Hash) {
+	if value == types.ZeroHash {
+		txn.txn.Delete(indexKey(transientStorageIndex, addr, &key))
+
+		return
+	}
+
+	txn.txn.Insert(indexKey(transientStorageIndex, addr, &key), value)
+}
+
+// GetTransientState returns the transient storage of the address at a given key
+func (txn *Txn) GetTransientState(addr types.Address, key types.Hash) types.Hash {
+	val, exists := txn.txn.Get(indexKey(transientStorageIndex, addr, &key))
+	if !exists {
+		return types.Hash{}
+	}
+
+	//nolint:forcetypeassert
+	return val.(types.Hash)
+}
+
+// MarkContractCreated marks the address as created in the current transaction
+func (txn *Txn) MarkContractCreated(addr types.Address) {
+	txn.txn.Insert(indexKey(createdIndex, addr, nil), true)
+}
+
+// IsContractCreated returns true if the address has been created in the current transaction
+func (txn *Txn) IsContractCreated(addr types.Address) bool {
+	_, exists := txn.txn.Get(indexKey(createdIndex, addr, nil))
+
+	return exists
+}
+
 func (txn *Txn) GetRefund() uint64 {
 	data, exists := txn.txn.Get(refundIndex)
 	if !exists {
@@ -572,6 +665,11 @@ func (txn *Txn) CleanDeleteObjects(deleteEmptyObjects bool) error {
 	// delete refunds
 	txn.txn.Delete(refundIndex)
 
+	// delete the access list, the transient storage and the created contracts of the transaction
+	txn.txn.DeletePrefix(accessListIndex)
+	txn.txn.DeletePrefix(transientStorageIndex)
+	txn.txn.DeletePrefix(createdIndex)
+
 	return nil
 }
 
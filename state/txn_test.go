@@ -45,6 +45,14 @@ func (m *mockSnapshot) GetCode(hash types.Hash) ([]byte, bool) {
 	return nil, false
 }
 
+func (m *mockSnapshot) GetAccountProof(addr types.Address) ([][]byte, error) {
+	return nil, nil
+}
+
+func (m *mockSnapshot) GetStorageProof(addr types.Address, root types.Hash, key types.Hash) ([][]byte, error) {
+	return nil, nil
+}
+
 func (m *mockSnapshot) Commit(objs []*Object) (Snapshot, []byte) {
 	return nil, nil
 }
@@ -70,3 +78,79 @@ func TestSnapshotUpdateData(t *testing.T) {
 	assert.NoError(t, txn.RevertToSnapshot(ss))
 	assert.Equal(t, hash1, txn.GetState(addr1, hash1))
 }
+
+func TestAccessList(t *testing.T) {
+	txn := newTestTxn(defaultPreState)
+
+	assert.False(t, txn.AddressInAccessList(addr1))
+
+	txn.AddAddressToAccessList(addr1)
+	assert.True(t, txn.AddressInAccessList(addr1))
+
+	ss := txn.Snapshot()
+
+	txn.AddSlotToAccessList(addr2, hash1)
+
+	addrOk, slotOk := txn.SlotInAccessList(addr2, hash1)
+	assert.True(t, addrOk)
+	assert.True(t, slotOk)
+
+	addrOk, slotOk = txn.SlotInAccessList(addr2, hash2)
+	assert.True(t, addrOk)
+	assert.False(t, slotOk)
+
+	// the access list is reverted together with the state
+	assert.NoError(t, txn.RevertToSnapshot(ss))
+	assert.True(t, txn.AddressInAccessList(addr1))
+	assert.False(t, txn.AddressInAccessList(addr2))
+
+	// the access list is cleared after the transaction
+	assert.NoError(t, txn.CleanDeleteObjects(true))
+	assert.False(t, txn.AddressInAccessList(addr1))
+}
+
+func TestTransientState(t *testing.T) {
+	txn := newTestTxn(defaultPreState)
+
+	txn.SetTransientState(addr1, hash2, hash1)
+	assert.Equal(t, hash1, txn.GetTransientState(addr1, hash2))
+
+	// the transient storage is not the persistent storage of the account
+	assert.Equal(t, types.Hash{}, txn.GetState(addr1, hash2))
+	assert.Equal(t, types.Hash{}, txn.GetTransientState(addr1, hash1))
+	assert.Equal(t, hash1, txn.GetState(addr1, hash1))
+	assert.Equal(t, types.Hash{}, txn.GetTransientState(addr2, hash2))
+
+	ss := txn.Snapshot()
+
+	txn.SetTransientState(addr1, hash2, hash2)
+	assert.Equal(t, hash2, txn.GetTransientState(addr1, hash2))
+
+	assert.NoError(t, txn.RevertToSnapshot(ss))
+	assert.Equal(t, hash1, txn.GetTransientState(addr1, hash2))
+
+	txn.SetTransientState(addr1, hash2, types.ZeroHash)
+	assert.Equal(t, types.Hash{}, txn.GetTransientState(addr1, hash2))
+
+	// the transient storage is cleared after the transaction
+	txn.SetTransientState(addr2, hash2, hash2)
+	assert.NoError(t, txn.CleanDeleteObjects(true))
+	assert.Equal(t, types.Hash{}, txn.GetTransientState(addr2, hash2))
+}
+
+func TestContractCreated(t *testing.T) {
+	txn := newTestTxn(defaultPreState)
+
+	ss := txn.Snapshot()
+
+	txn.MarkContractCreated(addr1)
+	assert.True(t, txn.IsContractCreated(addr1))
+	assert.False(t, txn.IsContractCreated(addr2))
+
+	assert.NoError(t, txn.RevertToSnapshot(ss))
+	assert.False(t, txn.IsContractCreated(addr1))
+
+	txn.MarkContractCreated(addr1)
+	assert.NoError(t, txn.CleanDeleteObjects(true))
+	assert.False(t, txn.IsContractCreated(addr1))
+}
@@ -0,0 +1,138 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/syncer/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	stateSyncProto = "/statesync/0.1"
+
+	defaultTimeoutForStateSync = 30 * time.Second
+)
+
+type stateSyncClient struct {
+	network Network // reference to the network module
+}
+
+func NewStateSyncClient(network Network) StateSyncClient {
+	return &stateSyncClient{
+		network: network,
+	}
+}
+
+// GetTrieNodes fetches the range of the stored nodes of the trie starting from the given path
+func (m *stateSyncClient) GetTrieNodes(
+	peerID peer.ID,
+	root types.Hash,
+	from []byte,
+	limit uint64,
+) ([]itrie.SyncNode, error) {
+	var resp *proto.TrieNodes
+
+	if err := m.call(peerID, func(ctx context.Context, clt proto.StateSyncClient) (err error) {
+		resp, err = clt.GetTrieNodes(ctx, &proto.GetTrieNodesRequest{
+			Root:  root.Bytes(),
+			From:  from,
+			Limit: limit,
+		})
+
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	nodes := make([]itrie.SyncNode, len(resp.Nodes))
+	size := 0
+
+	for i, node := range resp.Nodes {
+		nodes[i] = itrie.SyncNode{
+			Path: node.Path,
+			Data: node.Data,
+		}
+		size += len(node.Data)
+	}
+
+	metrics.IncrCounter([]string{syncerMetrics, "state_ingress_bytes"}, float32(size))
+
+	return nodes, nil
+}
+
+// GetCodes fetches the contract codes with the given hashes
+func (m *stateSyncClient) GetCodes(peerID peer.ID, hashes []types.Hash) ([][]byte, error) {
+	var resp *proto.Codes
+
+	if err := m.call(peerID, func(ctx context.Context, clt proto.StateSyncClient) (err error) {
+		resp, err = clt.GetCodes(ctx, &proto.GetCodesRequest{
+			Hashes: hashesToBytes(hashes),
+		})
+
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return resp.Codes, nil
+}
+
+// GetReceipts fetches the receipts of the blocks with the given hashes
+func (m *stateSyncClient) GetReceipts(peerID peer.ID, hashes []types.Hash) ([][]*types.Receipt, error) {
+	var resp *proto.Receipts
+
+	if err := m.call(peerID, func(ctx context.Context, clt proto.StateSyncClient) (err error) {
+		resp, err = clt.GetReceipts(ctx, &proto.GetReceiptsRequest{
+			Hashes: hashesToBytes(hashes),
+		})
+
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	receipts := make([][]*types.Receipt, len(resp.Receipts))
+
+	for i, data := range resp.Receipts {
+		blockReceipts := types.Receipts{}
+		if err := blockReceipts.UnmarshalStoreRLP(data); err != nil {
+			return nil, err
+		}
+
+		receipts[i] = blockReceipts
+	}
+
+	return receipts, nil
+}
+
+// call opens a new stream to the peer for the single request
+func (m *stateSyncClient) call(
+	peerID peer.ID,
+	fn func(ctx context.Context, clt proto.StateSyncClient) error,
+) error {
+	conn, err := m.network.NewProtoConnection(stateSyncProto, peerID)
+	if err != nil {
+		return fmt.Errorf("failed to open a stream, err %w", err)
+	}
+
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeoutForStateSync)
+	defer cancel()
+
+	return fn(ctx, proto.NewStateSyncClient(conn))
+}
+
+func hashesToBytes(hashes []types.Hash) [][]byte {
+	res := make([][]byte, len(hashes))
+	for i, hash := range hashes {
+		res[i] = hash.Bytes()
+	}
+
+	return res
+}
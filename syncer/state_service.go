@@ -0,0 +1,136 @@
+package syncer
+
+import (
+	"context"
+
+	"github.com/0xPolygon/polygon-edge/network/grpc"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/syncer/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+)
+
+const (
+	// maxTrieNodesPerRequest is the max number of the trie nodes served by a single request
+	maxTrieNodesPerRequest = 4096
+	// maxCodesPerRequest is the max number of the codes served by a single request
+	maxCodesPerRequest = 256
+	// maxReceiptsPerRequest is the max number of the blocks whose receipts are served by a single request
+	maxReceiptsPerRequest = 256
+)
+
+type stateSyncService struct {
+	proto.UnimplementedStateSyncServer
+
+	blockchain Blockchain       // reference to the blockchain module
+	network    Network          // reference to the network module
+	storage    itrie.Storage    // reference to the state storage
+	stream     *grpc.GrpcStream // reference to the grpc stream
+}
+
+func NewStateSyncService(
+	network Network,
+	blockchain Blockchain,
+	storage itrie.Storage,
+) StateSyncService {
+	return &stateSyncService{
+		blockchain: blockchain,
+		network:    network,
+		storage:    storage,
+	}
+}
+
+// Start starts stateSyncService
+func (s *stateSyncService) Start() {
+	s.stream = grpc.NewGrpcStream()
+
+	proto.RegisterStateSyncServer(s.stream.GrpcServer(), s)
+	s.stream.Serve()
+	s.network.RegisterProtocol(stateSyncProto, s.stream)
+}
+
+// Close closes stateSyncService
+func (s *stateSyncService) Close() error {
+	return s.stream.Close()
+}
+
+// GetTrieNodes is a gRPC endpoint to return the range of the stored nodes of the trie
+func (s *stateSyncService) GetTrieNodes(
+	ctx context.Context,
+	req *proto.GetTrieNodesRequest,
+) (*proto.TrieNodes, error) {
+	limit := req.Limit
+	if limit == 0 || limit > maxTrieNodesPerRequest {
+		limit = maxTrieNodesPerRequest
+	}
+
+	nodes, err := itrie.GetNodeRange(s.storage, types.BytesToHash(req.Root), req.From, int(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.TrieNodes{
+		Nodes: make([]*proto.TrieNode, len(nodes)),
+	}
+
+	size := 0
+
+	for i, node := range nodes {
+		resp.Nodes[i] = &proto.TrieNode{
+			Path: node.Path,
+			Data: node.Data,
+		}
+		size += len(node.Data)
+	}
+
+	metrics.IncrCounter([]string{syncerMetrics, "state_egress_bytes"}, float32(size))
+
+	return resp, nil
+}
+
+// GetCodes is a gRPC endpoint to return the contract codes, the unknown codes are returned empty
+func (s *stateSyncService) GetCodes(
+	ctx context.Context,
+	req *proto.GetCodesRequest,
+) (*proto.Codes, error) {
+	hashes := req.Hashes
+	if len(hashes) > maxCodesPerRequest {
+		hashes = hashes[:maxCodesPerRequest]
+	}
+
+	resp := &proto.Codes{
+		Codes: make([][]byte, len(hashes)),
+	}
+
+	for i, hash := range hashes {
+		resp.Codes[i], _ = s.storage.GetCode(types.BytesToHash(hash))
+	}
+
+	return resp, nil
+}
+
+// GetReceipts is a gRPC endpoint to return the receipts of the blocks
+func (s *stateSyncService) GetReceipts(
+	ctx context.Context,
+	req *proto.GetReceiptsRequest,
+) (*proto.Receipts, error) {
+	hashes := req.Hashes
+	if len(hashes) > maxReceiptsPerRequest {
+		hashes = hashes[:maxReceiptsPerRequest]
+	}
+
+	resp := &proto.Receipts{
+		Receipts: make([][]byte, len(hashes)),
+	}
+
+	for i, hash := range hashes {
+		receipts, err := s.blockchain.GetReceiptsByHash(types.BytesToHash(hash))
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Receipts[i] = types.Receipts(receipts).MarshalStoreRLPTo(nil)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,304 @@
+package syncer
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// stateSyncMinDistance is how far the best peer has to be ahead of the genesis
+	// for the new node to download the state instead of executing the blocks
+	stateSyncMinDistance = 1024
+
+	// stateSyncPivotOffset is how far behind the head of the peer the downloaded state is,
+	// so the peer keeps the state while it's being downloaded
+	stateSyncPivotOffset = 64
+
+	trieNodesPerRequest = 1024
+	codesPerRequest     = 128
+	receiptsPerRequest  = 64
+)
+
+var (
+	errStateNotServed    = errors.New("peer doesn't serve the state")
+	errInvalidCode       = errors.New("code doesn't match its hash")
+	errReceiptsNotServed = errors.New("peer doesn't serve the receipts")
+	errPivotNotReached   = errors.New("blocks stream ended before the pivot block")
+)
+
+// shouldSyncState returns whether the state should be downloaded instead of executing the blocks,
+// which is the case for the new node far behind the peer and for the node whose state sync was interrupted
+func (s *syncer) shouldSyncState(header *types.Header, bestPeer *NoForkPeer) bool {
+	if !s.stateSync {
+		return false
+	}
+
+	if !s.hasState(header.StateRoot) {
+		return true
+	}
+
+	return header.Number == 0 && bestPeer.Number >= stateSyncMinDistance
+}
+
+// hasState returns whether the trie with the given root is in the state storage
+func (s *syncer) hasState(root types.Hash) bool {
+	if root == types.EmptyRootHash {
+		return true
+	}
+
+	_, ok := s.stateStorage.Get(root.Bytes())
+
+	return ok
+}
+
+// stateSyncWithPeer imports the blocks up to the pivot block near the head of the peer without executing them,
+// then downloads the state of the pivot block, so the next blocks are synced as usual
+func (s *syncer) stateSyncWithPeer(bestPeer *NoForkPeer, newBlockCallback func(*types.FullBlock) bool) (bool, error) {
+	header := s.blockchain.Header()
+
+	if bestPeer.Number > header.Number+stateSyncPivotOffset {
+		pivot := bestPeer.Number - stateSyncPivotOffset
+
+		s.logger.Info("importing the blocks up to the pivot block", "peer", bestPeer.ID, "pivot", pivot)
+
+		if err := s.importBlocksWithPeer(bestPeer.ID, pivot); err != nil {
+			return false, err
+		}
+
+		header = s.blockchain.Header()
+	}
+
+	s.logger.Info("downloading the state", "peer", bestPeer.ID, "number", header.Number, "root", header.StateRoot)
+
+	if err := s.syncStateWithPeer(bestPeer.ID, header.StateRoot); err != nil {
+		return false, err
+	}
+
+	s.logger.Info("state sync completed", "number", header.Number, "root", header.StateRoot)
+
+	block, ok := s.blockchain.GetBlockByNumber(header.Number, true)
+	if !ok {
+		return false, ErrBlockNotFound
+	}
+
+	receipts, err := s.blockchain.GetReceiptsByHash(header.Hash)
+	if err != nil {
+		return false, err
+	}
+
+	return newBlockCallback(&types.FullBlock{Block: block, Receipts: receipts}), nil
+}
+
+// importBlocksWithPeer writes the blocks up to the pivot along with the receipts served by the peer
+func (s *syncer) importBlocksWithPeer(peerID peer.ID, pivot uint64) error {
+	localLatest := s.blockchain.Header().Number
+
+	blockCh, err := s.syncPeerClient.GetBlocks(peerID, localLatest+1, s.blockTimeout)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err := s.syncPeerClient.CloseStream(peerID)
+		if err != nil {
+			s.logger.Error("Failed to close stream: ", err)
+		}
+	}()
+
+	blocks := make([]*types.Block, 0, receiptsPerRequest)
+
+	for {
+		select {
+		case block, ok := <-blockCh:
+			if !ok {
+				return errPivotNotReached
+			}
+
+			// safe check
+			if block.Number() == 0 {
+				continue
+			}
+
+			blocks = append(blocks, block)
+
+			if len(blocks) < receiptsPerRequest && block.Number() < pivot {
+				continue
+			}
+
+			if err := s.importBlocks(peerID, blocks); err != nil {
+				return err
+			}
+
+			if block.Number() >= pivot {
+				return nil
+			}
+
+			blocks = blocks[:0]
+		case <-time.After(s.blockTimeout):
+			return errTimeout
+		}
+	}
+}
+
+// importBlocks verifies the blocks against the receipts fetched from the peer and writes them
+func (s *syncer) importBlocks(peerID peer.ID, blocks []*types.Block) error {
+	hashes := make([]types.Hash, len(blocks))
+	for i, block := range blocks {
+		hashes[i] = block.Hash()
+	}
+
+	receipts, err := s.stateSyncClient.GetReceipts(peerID, hashes)
+	if err != nil {
+		return err
+	}
+
+	if len(receipts) != len(blocks) {
+		return errReceiptsNotServed
+	}
+
+	for i, block := range blocks {
+		fullBlock, err := s.blockchain.VerifyFinalizedBlockWithReceipts(block, receipts[i])
+		if err != nil {
+			metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+			return fmt.Errorf("unable to verify block, %w", err)
+		}
+
+		if err := s.blockchain.WriteFullBlock(fullBlock, syncerName); err != nil {
+			metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+			return fmt.Errorf("failed to write block while state syncing: %w", err)
+		}
+
+		updateMetrics(fullBlock)
+	}
+
+	return nil
+}
+
+// syncStateWithPeer downloads the account trie along with the storage tries and the codes of the accounts.
+// The root of the account trie is written last, so the state is known locally only once it's complete
+func (s *syncer) syncStateWithPeer(peerID peer.ID, root types.Hash) error {
+	if s.hasState(root) {
+		return nil
+	}
+
+	var (
+		storageRoots = map[types.Hash]struct{}{}
+		codeHashes   = map[types.Hash]struct{}{}
+		accounts     = 0
+	)
+
+	accountTrie := itrie.NewTrieSync(s.stateStorage, root, func(_, value []byte) error {
+		var account state.Account
+		if err := account.UnmarshalRlp(value); err != nil {
+			return err
+		}
+
+		accounts++
+
+		if !s.hasState(account.Root) {
+			storageRoots[account.Root] = struct{}{}
+		}
+
+		codeHash := types.BytesToHash(account.CodeHash)
+		if codeHash != types.EmptyCodeHash {
+			if _, ok := s.stateStorage.GetCode(codeHash); !ok {
+				codeHashes[codeHash] = struct{}{}
+			}
+		}
+
+		return nil
+	})
+
+	if err := s.downloadTrie(peerID, accountTrie, root); err != nil {
+		return fmt.Errorf("failed to download the account trie: %w", err)
+	}
+
+	s.logger.Info("account trie downloaded",
+		"accounts", accounts, "storage tries", len(storageRoots), "codes", len(codeHashes))
+
+	for storageRoot := range storageRoots {
+		storageTrie := itrie.NewTrieSync(s.stateStorage, storageRoot, nil)
+
+		if err := s.downloadTrie(peerID, storageTrie, storageRoot); err != nil {
+			return fmt.Errorf("failed to download the storage trie %s: %w", storageRoot, err)
+		}
+
+		if err := storageTrie.Commit(); err != nil {
+			return err
+		}
+	}
+
+	if err := s.downloadCodes(peerID, codeHashes); err != nil {
+		return fmt.Errorf("failed to download the codes: %w", err)
+	}
+
+	return accountTrie.Commit()
+}
+
+// downloadTrie downloads the nodes of the trie range by range, the trie is committed by the caller
+func (s *syncer) downloadTrie(peerID peer.ID, sync *itrie.TrieSync, root types.Hash) error {
+	for from, ok := sync.Next(); ok; from, ok = sync.Next() {
+		nodes, err := s.stateSyncClient.GetTrieNodes(peerID, root, from, trieNodesPerRequest)
+		if err != nil {
+			return err
+		}
+
+		if len(nodes) == 0 {
+			return errStateNotServed
+		}
+
+		if err := sync.Process(nodes); err != nil {
+			return err
+		}
+
+		metrics.IncrCounter([]string{syncerMetrics, "state_nodes"}, float32(len(nodes)))
+	}
+
+	return nil
+}
+
+// downloadCodes downloads the codes with the given hashes and writes them to the state storage
+func (s *syncer) downloadCodes(peerID peer.ID, codeHashes map[types.Hash]struct{}) error {
+	hashes := make([]types.Hash, 0, len(codeHashes))
+	for hash := range codeHashes {
+		hashes = append(hashes, hash)
+	}
+
+	for len(hashes) > 0 {
+		n := codesPerRequest
+		if n > len(hashes) {
+			n = len(hashes)
+		}
+
+		codes, err := s.stateSyncClient.GetCodes(peerID, hashes[:n])
+		if err != nil {
+			return err
+		}
+
+		if len(codes) == 0 || len(codes) > n {
+			return errStateNotServed
+		}
+
+		for i, code := range codes {
+			if types.BytesToHash(crypto.Keccak256(code)) != hashes[i] {
+				return fmt.Errorf("%w %s", errInvalidCode, hashes[i])
+			}
+
+			s.stateStorage.SetCode(hashes[i], code)
+		}
+
+		hashes = hashes[len(codes):]
+	}
+
+	return nil
+}
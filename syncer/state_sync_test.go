@@ -0,0 +1,316 @@
+package syncer
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/network/grpc"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type mockStateSyncClient struct {
+	getTrieNodesHandler func(peer.ID, types.Hash, []byte, uint64) ([]itrie.SyncNode, error)
+	getCodesHandler     func(peer.ID, []types.Hash) ([][]byte, error)
+	getReceiptsHandler  func(peer.ID, []types.Hash) ([][]*types.Receipt, error)
+}
+
+func (m *mockStateSyncClient) GetTrieNodes(
+	id peer.ID,
+	root types.Hash,
+	from []byte,
+	limit uint64,
+) ([]itrie.SyncNode, error) {
+	return m.getTrieNodesHandler(id, root, from, limit)
+}
+
+func (m *mockStateSyncClient) GetCodes(id peer.ID, hashes []types.Hash) ([][]byte, error) {
+	return m.getCodesHandler(id, hashes)
+}
+
+func (m *mockStateSyncClient) GetReceipts(id peer.ID, hashes []types.Hash) ([][]*types.Receipt, error) {
+	return m.getReceiptsHandler(id, hashes)
+}
+
+func createTestStateSyncService(
+	t *testing.T,
+	chain Blockchain,
+	storage itrie.Storage,
+) (*stateSyncService, *network.Server) {
+	t.Helper()
+
+	srv := newTestNetwork(t)
+
+	service := &stateSyncService{
+		blockchain: chain,
+		network:    srv,
+		storage:    storage,
+	}
+
+	service.Start()
+
+	return service, srv
+}
+
+// newTestState commits the accounts with the storage and the code,
+// the accounts with the same number of the slots share the storage trie
+func newTestState(t *testing.T, storage itrie.Storage) (state.Snapshot, types.Hash, []types.Address) {
+	t.Helper()
+
+	code := []byte{0x60, 0x01, 0x60, 0x02}
+	addrs := []types.Address{}
+	objs := []*state.Object{}
+
+	for i := 0; i < 50; i++ {
+		addr := types.StringToAddress(big.NewInt(int64(i + 1)).String())
+		addrs = append(addrs, addr)
+
+		obj := &state.Object{
+			Address:  addr,
+			Balance:  big.NewInt(int64(i)),
+			Nonce:    uint64(i),
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+		}
+
+		if i%10 == 0 {
+			obj.CodeHash = types.BytesToHash(crypto.Keccak256(code))
+			obj.DirtyCode = true
+			obj.Code = code
+		}
+
+		for j := 0; j < i%3*100; j++ {
+			obj.Storage = append(obj.Storage, &state.StorageObject{
+				Key: types.StringToHash(big.NewInt(int64(j + 1)).String()).Bytes(),
+				Val: types.StringToHash(big.NewInt(int64(j + 1)).String()).Bytes(),
+			})
+		}
+
+		objs = append(objs, obj)
+	}
+
+	snap, root := itrie.NewState(storage).NewSnapshot().Commit(objs)
+
+	return snap, types.BytesToHash(root), addrs
+}
+
+func Test_syncStateWithPeer(t *testing.T) {
+	t.Parallel()
+
+	peerStorage := itrie.NewMemoryStorage()
+	peerSnap, root, addrs := newTestState(t, peerStorage)
+
+	clientSrv := newTestNetwork(t)
+	// need to register protocol
+	clientSrv.RegisterProtocol(stateSyncProto, grpc.NewGrpcStream())
+
+	_, peerSrv := createTestStateSyncService(t, &mockBlockchain{}, peerStorage)
+
+	require.NoError(t, network.JoinAndWait(
+		clientSrv,
+		peerSrv,
+		network.DefaultBufferTimeout,
+		network.DefaultJoinTimeout,
+	))
+
+	storage := itrie.NewMemoryStorage()
+
+	syncer := NewTestSyncer(clientSrv, &mockBlockchain{}, time.Second, &mockSyncPeerClient{}, &mockProgression{})
+	syncer.stateStorage = storage
+	syncer.stateSyncClient = NewStateSyncClient(clientSrv)
+
+	require.False(t, syncer.hasState(root))
+	require.NoError(t, syncer.syncStateWithPeer(peerSrv.AddrInfo().ID, root))
+	require.True(t, syncer.hasState(root))
+
+	snap, err := itrie.NewState(storage).NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	for _, addr := range addrs {
+		expected, err := peerSnap.GetAccount(addr)
+		require.NoError(t, err)
+
+		account, err := snap.GetAccount(addr)
+		require.NoError(t, err)
+		require.Equal(t, expected, account)
+
+		for j := 1; j <= 200; j++ {
+			key := types.StringToHash(big.NewInt(int64(j)).String())
+			assert.Equal(t, peerSnap.GetStorage(addr, expected.Root, key), snap.GetStorage(addr, account.Root, key))
+		}
+
+		code, ok := snap.GetCode(types.BytesToHash(account.CodeHash))
+		if types.BytesToHash(account.CodeHash) != types.EmptyCodeHash {
+			require.True(t, ok)
+			assert.Equal(t, types.BytesToHash(account.CodeHash), types.BytesToHash(crypto.Keccak256(code)))
+		}
+	}
+}
+
+func Test_stateSyncClient_GetReceipts(t *testing.T) {
+	t.Parallel()
+
+	success := types.ReceiptSuccess
+	receipts := map[types.Hash][]*types.Receipt{
+		types.StringToHash("1"): {},
+		types.StringToHash("2"): {
+			{TxHash: types.StringToHash("3"), GasUsed: 21000, CumulativeGasUsed: 21000, Status: &success},
+		},
+	}
+
+	clientSrv := newTestNetwork(t)
+	// need to register protocol
+	clientSrv.RegisterProtocol(stateSyncProto, grpc.NewGrpcStream())
+
+	_, peerSrv := createTestStateSyncService(t, &mockBlockchain{
+		getReceiptsByHashHandler: func(hash types.Hash) ([]*types.Receipt, error) {
+			r, ok := receipts[hash]
+			if !ok {
+				return nil, ErrBlockNotFound
+			}
+
+			return r, nil
+		},
+	}, itrie.NewMemoryStorage())
+
+	require.NoError(t, network.JoinAndWait(
+		clientSrv,
+		peerSrv,
+		network.DefaultBufferTimeout,
+		network.DefaultJoinTimeout,
+	))
+
+	client := NewStateSyncClient(clientSrv)
+
+	res, err := client.GetReceipts(peerSrv.AddrInfo().ID, []types.Hash{types.StringToHash("2"), types.StringToHash("1")})
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.Empty(t, res[1])
+	require.Len(t, res[0], 1)
+	assert.Equal(t, receipts[types.StringToHash("2")][0].TxHash, res[0][0].TxHash)
+	assert.Equal(t, receipts[types.StringToHash("2")][0].GasUsed, res[0][0].GasUsed)
+
+	_, err = client.GetReceipts(peerSrv.AddrInfo().ID, []types.Hash{types.StringToHash("4")})
+	require.Error(t, err)
+}
+
+func Test_syncStateWithPeer_InvalidCode(t *testing.T) {
+	t.Parallel()
+
+	peerStorage := itrie.NewMemoryStorage()
+	_, root, _ := newTestState(t, peerStorage)
+
+	syncer := NewTestSyncer(nil, &mockBlockchain{}, time.Second, &mockSyncPeerClient{}, &mockProgression{})
+	syncer.stateStorage = itrie.NewMemoryStorage()
+	syncer.stateSyncClient = &mockStateSyncClient{
+		getTrieNodesHandler: func(_ peer.ID, root types.Hash, from []byte, limit uint64) ([]itrie.SyncNode, error) {
+			return itrie.GetNodeRange(peerStorage, root, from, int(limit))
+		},
+		getCodesHandler: func(_ peer.ID, hashes []types.Hash) ([][]byte, error) {
+			return [][]byte{{0x1}}, nil
+		},
+	}
+
+	require.ErrorIs(t, syncer.syncStateWithPeer(peer.ID("A"), root), errInvalidCode)
+	require.False(t, syncer.hasState(root))
+}
+
+func Test_stateSyncWithPeer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		peerLatest = uint64(200)
+		pivot      = peerLatest - stateSyncPivotOffset
+
+		lock          sync.Mutex
+		latest        uint64
+		requested     []int
+		insertedBlock *types.FullBlock
+	)
+
+	newBlock := func(number uint64) *types.Block {
+		header := &types.Header{Number: number, StateRoot: types.EmptyRootHash}
+		header.ComputeHash()
+
+		return &types.Block{Header: header}
+	}
+
+	chain := &mockBlockchain{
+		headerHandler: func() *types.Header {
+			lock.Lock()
+			defer lock.Unlock()
+
+			return newBlock(latest).Header
+		},
+		getBlockByNumberHandler: func(number uint64, _ bool) (*types.Block, bool) {
+			return newBlock(number), true
+		},
+		getReceiptsByHashHandler: func(types.Hash) ([]*types.Receipt, error) {
+			return []*types.Receipt{}, nil
+		},
+		verifyFinalizedBlockWithReceiptsHandler: func(b *types.Block, r []*types.Receipt) (*types.FullBlock, error) {
+			return &types.FullBlock{Block: b, Receipts: r}, nil
+		},
+		writeFullBlockHandler: func(b *types.FullBlock) error {
+			lock.Lock()
+			defer lock.Unlock()
+
+			require.Equal(t, latest+1, b.Block.Number())
+			latest = b.Block.Number()
+
+			return nil
+		},
+	}
+
+	syncer := NewTestSyncer(nil, chain, time.Second, &mockSyncPeerClient{
+		getBlocksHandler: func(_ peer.ID, from uint64, _ time.Duration) (<-chan *types.Block, error) {
+			blockCh := make(chan *types.Block, peerLatest)
+			for i := from; i <= peerLatest; i++ {
+				blockCh <- newBlock(i)
+			}
+
+			close(blockCh)
+
+			return blockCh, nil
+		},
+	}, &mockProgression{})
+	syncer.stateSync = true
+	syncer.stateStorage = itrie.NewMemoryStorage()
+	syncer.stateSyncClient = &mockStateSyncClient{
+		getReceiptsHandler: func(_ peer.ID, hashes []types.Hash) ([][]*types.Receipt, error) {
+			requested = append(requested, len(hashes))
+
+			return make([][]*types.Receipt, len(hashes)), nil
+		},
+	}
+
+	bestPeer := &NoForkPeer{ID: peer.ID("A"), Number: peerLatest}
+
+	// the peer isn't far enough ahead of the genesis
+	assert.False(t, syncer.shouldSyncState(newBlock(0).Header, bestPeer))
+
+	bestPeer.Number = stateSyncMinDistance
+	assert.True(t, syncer.shouldSyncState(newBlock(0).Header, bestPeer))
+	bestPeer.Number = peerLatest
+
+	shouldTerminate, err := syncer.stateSyncWithPeer(bestPeer, func(b *types.FullBlock) bool {
+		insertedBlock = b
+
+		return true
+	})
+	require.NoError(t, err)
+
+	assert.True(t, shouldTerminate)
+	assert.Equal(t, pivot, latest)
+	assert.Equal(t, pivot, insertedBlock.Block.Number())
+	assert.Equal(t, []int{receiptsPerRequest, receiptsPerRequest, int(pivot) % receiptsPerRequest}, requested)
+}
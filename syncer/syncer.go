@@ -7,6 +7,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/network/event"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
@@ -33,6 +34,14 @@ type syncer struct {
 	syncPeerService SyncPeerService
 	syncPeerClient  SyncPeerClient
 
+	// stateStorage is the storage of the state tries served to the peers and downloaded by the state sync
+	stateStorage     itrie.Storage
+	stateSyncService StateSyncService
+	stateSyncClient  StateSyncClient
+
+	// stateSync enables downloading the state of a recent block instead of executing the blocks from genesis
+	stateSync bool
+
 	// Timeout for syncing a block
 	blockTimeout time.Duration
 
@@ -44,9 +53,11 @@ func NewSyncer(
 	logger hclog.Logger,
 	network Network,
 	blockchain Blockchain,
+	stateStorage itrie.Storage,
+	stateSync bool,
 	blockTimeout time.Duration,
 ) Syncer {
-	return &syncer{
+	s := &syncer{
 		logger:          logger.Named(syncerName),
 		blockchain:      blockchain,
 		syncProgression: progress.NewProgressionWrapper(progress.ChainSyncBulk),
@@ -56,6 +67,15 @@ func NewSyncer(
 		newStatusCh:     make(chan struct{}),
 		peerMap:         new(PeerMap),
 	}
+
+	if stateStorage != nil {
+		s.stateStorage = stateStorage
+		s.stateSyncService = NewStateSyncService(network, blockchain, stateStorage)
+		s.stateSyncClient = NewStateSyncClient(network)
+		s.stateSync = stateSync
+	}
+
+	return s
 }
 
 // Start starts goroutine processes
@@ -66,6 +86,10 @@ func (s *syncer) Start() error {
 
 	s.syncPeerService.Start()
 
+	if s.stateSyncService != nil {
+		s.stateSyncService.Start()
+	}
+
 	s.initializePeerMap()
 
 	go s.startPeerStatusUpdateProcess()
@@ -82,6 +106,12 @@ func (s *syncer) Close() error {
 		return err
 	}
 
+	if s.stateSyncService != nil {
+		if err := s.stateSyncService.Close(); err != nil {
+			return err
+		}
+	}
+
 	s.syncPeerClient.Close()
 
 	return nil
@@ -169,7 +199,8 @@ func (s *syncer) Sync(callback func(*types.FullBlock) bool) error {
 		<-s.newStatusCh
 
 		// fetch local latest block
-		if header := s.blockchain.Header(); header != nil {
+		header := s.blockchain.Header()
+		if header != nil {
 			localLatest = header.Number
 		}
 
@@ -182,6 +213,24 @@ func (s *syncer) Sync(callback func(*types.FullBlock) bool) error {
 			continue
 		}
 
+		// download the state instead of executing the blocks
+		if header != nil && s.shouldSyncState(header, bestPeer) {
+			shouldTerminate, err := s.stateSyncWithPeer(bestPeer, callback)
+			if err != nil {
+				s.logger.Warn("failed to complete state sync with peer, try to next one", "peer ID", bestPeer.ID, "error", err)
+
+				skipList[bestPeer.ID] = true
+
+				continue
+			}
+
+			if shouldTerminate {
+				break
+			}
+
+			localLatest = s.blockchain.Header().Number
+		}
+
 		// if the bestPeer does not have a new block continue
 		if bestPeer.Number <= localLatest {
 			continue
@@ -42,6 +42,9 @@ type mockBlockchain struct {
 	verifyFinalizedBlockHandler func(*types.Block) (*types.FullBlock, error)
 	writeBlockHandler           func(*types.Block) error
 	writeFullBlockHandler       func(*types.FullBlock) error
+
+	verifyFinalizedBlockWithReceiptsHandler func(*types.Block, []*types.Receipt) (*types.FullBlock, error)
+	getReceiptsByHashHandler                func(types.Hash) ([]*types.Receipt, error)
 }
 
 func (m *mockBlockchain) SubscribeEvents() blockchain.Subscription {
@@ -68,6 +71,17 @@ func (m *mockBlockchain) WriteFullBlock(b *types.FullBlock, s string) error {
 	return m.writeFullBlockHandler(b)
 }
 
+func (m *mockBlockchain) VerifyFinalizedBlockWithReceipts(
+	b *types.Block,
+	receipts []*types.Receipt,
+) (*types.FullBlock, error) {
+	return m.verifyFinalizedBlockWithReceiptsHandler(b, receipts)
+}
+
+func (m *mockBlockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	return m.getReceiptsByHashHandler(hash)
+}
+
 func newSimpleHeaderHandler(num uint64) func() *types.Header {
 	return func() *types.Header {
 		return &types.Header{
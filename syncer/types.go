@@ -11,6 +11,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/network/event"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"google.golang.org/protobuf/proto"
@@ -31,6 +32,10 @@ type Blockchain interface {
 	WriteBlock(*types.Block, string) error
 	// WriteFullBlock writes a given block to chain and saves its receipts to cache
 	WriteFullBlock(*types.FullBlock, string) error
+	// VerifyFinalizedBlockWithReceipts verifies finalized block along with its receipts without executing it
+	VerifyFinalizedBlockWithReceipts(block *types.Block, receipts []*types.Receipt) (*types.FullBlock, error)
+	// GetReceiptsByHash returns the receipts of the block with the given hash
+	GetReceiptsByHash(types.Hash) ([]*types.Receipt, error)
 }
 
 type Network interface {
@@ -110,3 +115,19 @@ type SyncPeerClient interface {
 	// EnablePublishingPeerStatus enables publishing status in syncer topic
 	EnablePublishingPeerStatus()
 }
+
+type StateSyncService interface {
+	// Start starts server
+	Start()
+	// Close terminates running processes for StateSyncService
+	Close() error
+}
+
+type StateSyncClient interface {
+	// GetTrieNodes fetches the range of the stored nodes of the trie starting from the given path
+	GetTrieNodes(peerID peer.ID, root types.Hash, from []byte, limit uint64) ([]itrie.SyncNode, error)
+	// GetCodes fetches the contract codes with the given hashes
+	GetCodes(peerID peer.ID, hashes []types.Hash) ([][]byte, error)
+	// GetReceipts fetches the receipts of the blocks with the given hashes
+	GetReceipts(peerID peer.ID, hashes []types.Hash) ([][]*types.Receipt, error)
+}
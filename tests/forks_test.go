@@ -0,0 +1,236 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// State tests of the Shanghai and Cancun opcodes which are not covered by the spec tests suite version in use
+
+var (
+	forkTestSender      = types.StringToAddress("0x1000")
+	forkTestContract    = types.StringToAddress("0x2000")
+	forkTestBeneficiary = types.StringToAddress("0x3000")
+	forkTestCoinbase    = types.StringToAddress("0x4000")
+)
+
+// applyForkTest executes the transaction against the contract with the given code
+func applyForkTest(
+	t *testing.T,
+	fork string,
+	code []byte,
+	tx *types.Transaction,
+) (*state.Transition, *runtime.ExecutionResult, error) {
+	t.Helper()
+
+	config, ok := Forks[fork]
+	require.True(t, ok)
+
+	s, _, root, err := buildState(map[types.Address]*chain.GenesisAccount{
+		forkTestSender: {
+			Balance: big.NewInt(1_000_000_000_000),
+		},
+		forkTestContract: {
+			Balance: big.NewInt(10),
+			Nonce:   1,
+			Code:    code,
+		},
+	})
+	require.NoError(t, err)
+
+	executor := state.NewExecutor(&chain.Params{
+		Forks:   config,
+		ChainID: 1,
+		BurnContract: map[uint64]types.Address{
+			0: types.ZeroAddress,
+		},
+	}, s, hclog.NewNullLogger())
+
+	executor.GetHash = func(*types.Header) func(i uint64) types.Hash {
+		return vmTestBlockHash
+	}
+
+	transition, err := executor.BeginTxn(root, &types.Header{
+		Number:   1,
+		GasLimit: 10_000_000,
+		BaseFee:  testGenesisBaseFee,
+	}, forkTestCoinbase)
+	require.NoError(t, err)
+
+	tx.From = forkTestSender
+	tx.Gas = 1_000_000
+	tx.GasPrice = big.NewInt(testGenesisBaseFee)
+	tx.Value = big.NewInt(0)
+
+	result, err := transition.Apply(tx)
+
+	return transition, result, err
+}
+
+func callForkTest(t *testing.T, fork string, code []byte) (*state.Transition, *runtime.ExecutionResult) {
+	t.Helper()
+
+	transition, result, err := applyForkTest(t, fork, code, &types.Transaction{
+		To: &forkTestContract,
+	})
+	require.NoError(t, err)
+
+	return transition, result
+}
+
+func TestState_Shanghai_Cancun_Opcodes(t *testing.T) {
+	t.Parallel()
+
+	slot0 := types.ZeroHash
+	value := types.BytesToHash([]byte{0x2a})
+
+	tests := []struct {
+		name string
+		code []byte
+		// forks in which the code stores the value in the first slot
+		forks []string
+		// forks in which the code fails with an invalid opcode
+		failingForks []string
+	}{
+		{
+			// PUSH1 0x2a PUSH0 SSTORE
+			name:         "PUSH0",
+			code:         []byte{0x60, 0x2a, 0x5f, 0x55},
+			forks:        []string{"Shanghai", "Cancun"},
+			failingForks: []string{"ConstantinopleFix"},
+		},
+		{
+			// PUSH1 0x2a PUSH1 0x01 TSTORE PUSH1 0x01 TLOAD PUSH1 0x00 SSTORE
+			name:         "TSTORE and TLOAD",
+			code:         []byte{0x60, 0x2a, 0x60, 0x01, 0x5d, 0x60, 0x01, 0x5c, 0x60, 0x00, 0x55},
+			forks:        []string{"Cancun"},
+			failingForks: []string{"Shanghai"},
+		},
+		{
+			// PUSH1 0x2a PUSH1 0x00 MSTORE PUSH1 0x20 PUSH1 0x00 PUSH1 0x20 MCOPY PUSH1 0x20 MLOAD PUSH1 0x00 SSTORE
+			name: "MCOPY",
+			code: []byte{
+				0x60, 0x2a, 0x60, 0x00, 0x52,
+				0x60, 0x20, 0x60, 0x00, 0x60, 0x20, 0x5e,
+				0x60, 0x20, 0x51, 0x60, 0x00, 0x55,
+			},
+			forks:        []string{"Cancun"},
+			failingForks: []string{"Shanghai"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			for _, fork := range tt.forks {
+				transition, result := callForkTest(t, fork, tt.code)
+
+				require.NoError(t, result.Err, fork)
+				require.Equal(t, value, transition.Txn().GetState(forkTestContract, slot0), fork)
+			}
+
+			for _, fork := range tt.failingForks {
+				transition, result := callForkTest(t, fork, tt.code)
+
+				require.Error(t, result.Err, fork)
+				require.Equal(t, types.ZeroHash, transition.Txn().GetState(forkTestContract, slot0), fork)
+			}
+		})
+	}
+}
+
+func TestState_Cancun_TransientStorageIsDiscarded(t *testing.T) {
+	t.Parallel()
+
+	// PUSH1 0x2a PUSH1 0x01 TSTORE
+	transition, result := callForkTest(t, "Cancun", []byte{0x60, 0x2a, 0x60, 0x01, 0x5d})
+	require.NoError(t, result.Err)
+
+	slot := types.BytesToHash([]byte{0x01})
+
+	txn := transition.Txn()
+	require.Equal(t, types.BytesToHash([]byte{0x2a}), txn.GetTransientState(forkTestContract, slot))
+
+	// the transient storage is not persisted and it's discarded at the end of the transaction
+	require.Equal(t, types.ZeroHash, txn.GetState(forkTestContract, slot))
+	require.NoError(t, txn.CleanDeleteObjects(true))
+	require.Equal(t, types.ZeroHash, txn.GetTransientState(forkTestContract, slot))
+}
+
+func TestState_Cancun_Selfdestruct(t *testing.T) {
+	t.Parallel()
+
+	// PUSH20 beneficiary SELFDESTRUCT
+	code := append(append([]byte{0x73}, forkTestBeneficiary.Bytes()...), 0xff)
+
+	t.Run("Shanghai deletes the contract", func(t *testing.T) {
+		t.Parallel()
+
+		transition, result := callForkTest(t, "Shanghai", code)
+		require.NoError(t, result.Err)
+
+		txn := transition.Txn()
+		require.True(t, txn.HasSuicided(forkTestContract))
+		require.Equal(t, uint64(10), txn.GetBalance(forkTestBeneficiary).Uint64())
+	})
+
+	t.Run("Cancun keeps the contract created in a previous transaction", func(t *testing.T) {
+		t.Parallel()
+
+		transition, result := callForkTest(t, "Cancun", code)
+		require.NoError(t, result.Err)
+
+		txn := transition.Txn()
+		require.False(t, txn.HasSuicided(forkTestContract))
+		require.Equal(t, code, txn.GetCode(forkTestContract))
+		require.Equal(t, uint64(0), txn.GetBalance(forkTestContract).Uint64())
+		require.Equal(t, uint64(10), txn.GetBalance(forkTestBeneficiary).Uint64())
+	})
+
+	t.Run("Cancun deletes the contract created in the same transaction", func(t *testing.T) {
+		t.Parallel()
+
+		// the init code is the self destructing code
+		transition, result, err := applyForkTest(t, "Cancun", nil, &types.Transaction{
+			Input: code,
+		})
+		require.NoError(t, err)
+		require.NoError(t, result.Err)
+
+		require.True(t, transition.Txn().HasSuicided(crypto.CreateAddress(forkTestSender, 0)))
+	})
+}
+
+func TestState_Shanghai_InitCodeLimit(t *testing.T) {
+	t.Parallel()
+
+	// STOP as the init code, padded up to the limit
+	input := make([]byte, runtime.MaxInitCodeSize+1)
+
+	_, _, err := applyForkTest(t, "Shanghai", nil, &types.Transaction{
+		Input: input,
+	})
+
+	var appErr *state.TransitionApplicationError
+
+	require.ErrorAs(t, err, &appErr)
+	require.ErrorIs(t, appErr.Err, runtime.ErrMaxInitCodeSizeExceeded)
+
+	_, result, err := applyForkTest(t, "Shanghai", nil, &types.Transaction{
+		Input: input[:runtime.MaxInitCodeSize],
+	})
+	require.NoError(t, err)
+	require.NoError(t, result.Err)
+}
@@ -253,15 +253,16 @@ func (p *postEntry) UnmarshalJSON(input []byte) error {
 }
 
 type stTransaction struct {
-	Data                 []string       `json:"data"`
-	GasLimit             []uint64       `json:"gasLimit"`
-	Value                []*big.Int     `json:"value"`
-	GasPrice             *big.Int       `json:"gasPrice"`
-	MaxFeePerGas         *big.Int       `json:"maxFeePerGas"`
-	MaxPriorityFeePerGas *big.Int       `json:"maxPriorityFeePerGas"`
-	Nonce                uint64         `json:"nonce"`
-	From                 types.Address  `json:"secretKey"`
-	To                   *types.Address `json:"to"`
+	Data                 []string             `json:"data"`
+	GasLimit             []uint64             `json:"gasLimit"`
+	Value                []*big.Int           `json:"value"`
+	GasPrice             *big.Int             `json:"gasPrice"`
+	MaxFeePerGas         *big.Int             `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *big.Int             `json:"maxPriorityFeePerGas"`
+	Nonce                uint64               `json:"nonce"`
+	From                 types.Address        `json:"secretKey"`
+	To                   *types.Address       `json:"to"`
+	AccessLists          []types.TxAccessList `json:"accessLists"`
 }
 
 func (t *stTransaction) At(i indexes, baseFee *big.Int) (*types.Transaction, error) {
@@ -296,30 +297,38 @@ func (t *stTransaction) At(i indexes, baseFee *big.Int) (*types.Transaction, err
 		gasPrice = common.BigMin(new(big.Int).Add(t.MaxPriorityFeePerGas, baseFee), t.MaxFeePerGas)
 	}
 
+	// the access lists are defined per data index
+	var accessList types.TxAccessList
+	if i.Data < len(t.AccessLists) {
+		accessList = t.AccessLists[i.Data]
+	}
+
 	return &types.Transaction{
-		From:      t.From,
-		To:        t.To,
-		Nonce:     t.Nonce,
-		Value:     new(big.Int).Set(t.Value[i.Value]),
-		Gas:       t.GasLimit[i.Gas],
-		GasPrice:  new(big.Int).Set(gasPrice),
-		GasFeeCap: t.MaxFeePerGas,
-		GasTipCap: t.MaxPriorityFeePerGas,
-		Input:     hex.MustDecodeHex(t.Data[i.Data]),
+		From:       t.From,
+		To:         t.To,
+		Nonce:      t.Nonce,
+		Value:      new(big.Int).Set(t.Value[i.Value]),
+		Gas:        t.GasLimit[i.Gas],
+		GasPrice:   new(big.Int).Set(gasPrice),
+		GasFeeCap:  t.MaxFeePerGas,
+		GasTipCap:  t.MaxPriorityFeePerGas,
+		Input:      hex.MustDecodeHex(t.Data[i.Data]),
+		AccessList: accessList,
 	}, nil
 }
 
 func (t *stTransaction) UnmarshalJSON(input []byte) error {
 	type txUnmarshall struct {
-		Data                 []string `json:"data,omitempty"`
-		GasLimit             []string `json:"gasLimit,omitempty"`
-		Value                []string `json:"value,omitempty"`
-		GasPrice             string   `json:"gasPrice,omitempty"`
-		MaxFeePerGas         string   `json:"maxFeePerGas,omitempty"`
-		MaxPriorityFeePerGas string   `json:"maxPriorityFeePerGas,omitempty"`
-		Nonce                string   `json:"nonce,omitempty"`
-		SecretKey            string   `json:"secretKey,omitempty"`
-		To                   string   `json:"to,omitempty"`
+		Data                 []string             `json:"data,omitempty"`
+		GasLimit             []string             `json:"gasLimit,omitempty"`
+		Value                []string             `json:"value,omitempty"`
+		GasPrice             string               `json:"gasPrice,omitempty"`
+		MaxFeePerGas         string               `json:"maxFeePerGas,omitempty"`
+		MaxPriorityFeePerGas string               `json:"maxPriorityFeePerGas,omitempty"`
+		Nonce                string               `json:"nonce,omitempty"`
+		SecretKey            string               `json:"secretKey,omitempty"`
+		To                   string               `json:"to,omitempty"`
+		AccessLists          []types.TxAccessList `json:"accessLists,omitempty"`
 	}
 
 	var dec txUnmarshall
@@ -328,6 +337,7 @@ func (t *stTransaction) UnmarshalJSON(input []byte) error {
 	}
 
 	t.Data = dec.Data
+	t.AccessLists = dec.AccessLists
 
 	for _, i := range dec.GasLimit {
 		j, err := stringToUint64(i)
@@ -446,6 +456,33 @@ var Forks = map[string]*chain.Forks{
 		chain.Petersburg:     chain.NewFork(0),
 		chain.Istanbul:       chain.NewFork(0),
 	},
+	"Shanghai": {
+		chain.Homestead:      chain.NewFork(0),
+		chain.EIP150:         chain.NewFork(0),
+		chain.EIP155:         chain.NewFork(0),
+		chain.EIP158:         chain.NewFork(0),
+		chain.Byzantium:      chain.NewFork(0),
+		chain.Constantinople: chain.NewFork(0),
+		chain.Petersburg:     chain.NewFork(0),
+		chain.Istanbul:       chain.NewFork(0),
+		chain.Berlin:         chain.NewFork(0),
+		chain.London:         chain.NewFork(0),
+		chain.Shanghai:       chain.NewFork(0),
+	},
+	"Cancun": {
+		chain.Homestead:      chain.NewFork(0),
+		chain.EIP150:         chain.NewFork(0),
+		chain.EIP155:         chain.NewFork(0),
+		chain.EIP158:         chain.NewFork(0),
+		chain.Byzantium:      chain.NewFork(0),
+		chain.Constantinople: chain.NewFork(0),
+		chain.Petersburg:     chain.NewFork(0),
+		chain.Istanbul:       chain.NewFork(0),
+		chain.Berlin:         chain.NewFork(0),
+		chain.London:         chain.NewFork(0),
+		chain.Shanghai:       chain.NewFork(0),
+		chain.Cancun:         chain.NewFork(0),
+	},
 	"FrontierToHomesteadAt5": {
 		chain.Homestead: chain.NewFork(5),
 	},
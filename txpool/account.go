@@ -3,6 +3,7 @@ package txpool
 import (
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -29,7 +30,7 @@ func (m *accountsMap) initOnce(addr types.Address, nonce uint64) *account {
 
 	if !loaded {
 		// update global count if it was a store
-		atomic.AddUint64(&m.count, 1)
+		recordAccounts(atomic.AddUint64(&m.count, 1))
 	}
 
 	return newAccount
@@ -138,12 +139,15 @@ func (m *accountsMap) allTxs(includeEnqueued bool) (
 
 type nonceToTxLookup struct {
 	mapping map[uint64]*types.Transaction
-	mutex   sync.Mutex
+	// arrivals holds the time each transaction was added (or replaced) at
+	arrivals map[uint64]time.Time
+	mutex    sync.Mutex
 }
 
 func newNonceToTxLookup() *nonceToTxLookup {
 	return &nonceToTxLookup{
-		mapping: make(map[uint64]*types.Transaction),
+		mapping:  make(map[uint64]*types.Transaction),
+		arrivals: make(map[uint64]time.Time),
 	}
 }
 
@@ -161,15 +165,25 @@ func (m *nonceToTxLookup) get(nonce uint64) *types.Transaction {
 
 func (m *nonceToTxLookup) set(tx *types.Transaction) {
 	m.mapping[tx.Nonce] = tx
+	m.arrivals[tx.Nonce] = time.Now()
+}
+
+// expired returns true if the transaction with the given nonce was added before the deadline
+func (m *nonceToTxLookup) expired(nonce uint64, deadline time.Time) bool {
+	arrival, ok := m.arrivals[nonce]
+
+	return ok && arrival.Before(deadline)
 }
 
 func (m *nonceToTxLookup) reset() {
 	m.mapping = make(map[uint64]*types.Transaction)
+	m.arrivals = make(map[uint64]time.Time)
 }
 
 func (m *nonceToTxLookup) remove(txs ...*types.Transaction) {
 	for _, tx := range txs {
 		delete(m.mapping, tx.Nonce)
+		delete(m.arrivals, tx.Nonce)
 	}
 }
 
@@ -355,6 +369,34 @@ func (a *account) promote() (promoted []*types.Transaction, pruned []*types.Tran
 	return
 }
 
+// evictExpired removes the enqueued transactions added before the deadline.
+// It also returns the first promoted transaction if it was added before the deadline
+func (a *account) evictExpired(deadline time.Time) (
+	evicted []*types.Transaction,
+	expiredPromoted *types.Transaction,
+) {
+	a.promoted.lock(false)
+	a.enqueued.lock(true)
+	a.nonceToTx.lock()
+
+	defer func() {
+		a.nonceToTx.unlock()
+		a.enqueued.unlock()
+		a.promoted.unlock()
+	}()
+
+	evicted = a.enqueued.removeFunc(func(tx *types.Transaction) bool {
+		return a.nonceToTx.expired(tx.Nonce, deadline)
+	})
+	a.nonceToTx.remove(evicted...)
+
+	if first := a.promoted.peek(); first != nil && a.nonceToTx.expired(first.Nonce, deadline) {
+		expiredPromoted = first
+	}
+
+	return
+}
+
 // resetSkips sets 0 to skips
 func (a *account) resetSkips() {
 	a.skips = 0
@@ -0,0 +1,291 @@
+package txpool
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+)
+
+const (
+	// maxBundleTxs is the max number of transactions of a bundle
+	maxBundleTxs = 16
+
+	// maxBundles is the max number of bundles kept by the pool
+	maxBundles = 256
+
+	// maxAccountBundles is the max number of bundles with transactions of the same account
+	maxAccountBundles = 8
+
+	// bundleLifetime is the number of blocks a bundle without a target block number is kept for
+	bundleLifetime = 64
+)
+
+var (
+	ErrEmptyBundle        = errors.New("bundle has no transactions")
+	ErrBundleTooLarge     = errors.New("bundle has too many transactions")
+	ErrBundleExpired      = errors.New("bundle expired")
+	ErrBundleAlreadyKnown = errors.New("bundle already known")
+	ErrBundleLimitReached = errors.New("maximum number of bundles reached")
+	ErrAccountBundleLimit = errors.New("maximum number of bundles of the account reached")
+	ErrBundleNotSealing   = errors.New("bundles are accepted only by the validators, the node is not sealing")
+)
+
+// Bundle is a list of transactions which are included contiguously in a block, or not at all.
+// Bundles are submitted to the validators and are not gossiped
+type Bundle struct {
+	// Hash is the hash of the concatenated hashes of the transactions
+	Hash types.Hash
+	Txs  []*types.Transaction
+	// BlockNumber is the only block the bundle can be included in,
+	// any of the following bundleLifetime blocks if 0
+	BlockNumber uint64
+	// MaxTimestamp is the max timestamp of the block the bundle can be included in, no limit if 0
+	MaxTimestamp uint64
+
+	// lastBlock is the last block the bundle can be included in, no limit if 0
+	lastBlock uint64
+}
+
+// Gas returns the sum of the gas limits of the transactions
+func (b *Bundle) Gas() (gas uint64) {
+	for _, tx := range b.Txs {
+		gas += tx.Gas
+	}
+
+	return
+}
+
+// expired returns true if the bundle can't be included in the block or the following ones
+func (b *Bundle) expired(blockNumber, timestamp uint64) bool {
+	return (b.lastBlock != 0 && blockNumber > b.lastBlock) ||
+		(b.MaxTimestamp != 0 && timestamp > b.MaxTimestamp)
+}
+
+// executable returns true if the bundle can be included in the block
+func (b *Bundle) executable(blockNumber, timestamp uint64) bool {
+	return !b.expired(blockNumber, timestamp) && (b.BlockNumber == 0 || b.BlockNumber == blockNumber)
+}
+
+// bundleHash returns the hash of the concatenated hashes of the transactions
+func bundleHash(txs []*types.Transaction) types.Hash {
+	data := make([]byte, 0, len(txs)*types.HashLength)
+
+	for _, tx := range txs {
+		data = append(data, tx.Hash.Bytes()...)
+	}
+
+	return types.BytesToHash(keccak.Keccak256(nil, data))
+}
+
+// hasAccount returns true if the bundle has a transaction of the account
+func (b *Bundle) hasAccount(addr types.Address) bool {
+	for _, tx := range b.Txs {
+		if tx.From == addr {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bundleQueue keeps the bundles in their arrival order. [thread-safe]
+type bundleQueue struct {
+	lock    sync.Mutex
+	bundles []*Bundle
+}
+
+// add appends the bundle to the queue
+func (q *bundleQueue) add(bundle *Bundle) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for _, b := range q.bundles {
+		if b.Hash == bundle.Hash {
+			return ErrBundleAlreadyKnown
+		}
+	}
+
+	if len(q.bundles) >= maxBundles {
+		return ErrBundleLimitReached
+	}
+
+	// an account can't take the whole queue
+	for _, tx := range bundle.Txs {
+		if q.accountBundles(tx.From) >= maxAccountBundles {
+			return ErrAccountBundleLimit
+		}
+	}
+
+	q.bundles = append(q.bundles, bundle)
+
+	return nil
+}
+
+// accountBundles returns the number of bundles with transactions of the account [NOT thread-safe]
+func (q *bundleQueue) accountBundles(addr types.Address) int {
+	count := 0
+
+	for _, b := range q.bundles {
+		if b.hasAccount(addr) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// remove removes the bundle with the given hash from the queue
+func (q *bundleQueue) remove(hash types.Hash) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for i, b := range q.bundles {
+		if b.Hash == hash {
+			q.bundles = append(q.bundles[:i], q.bundles[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// executables returns the bundles which can be included in the block
+// and removes the expired ones from the queue
+func (q *bundleQueue) executables(blockNumber, timestamp uint64) []*Bundle {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.prune(blockNumber, timestamp)
+
+	var executables []*Bundle
+
+	for _, b := range q.bundles {
+		if b.executable(blockNumber, timestamp) {
+			executables = append(executables, b)
+		}
+	}
+
+	return executables
+}
+
+// removeExpired removes the bundles which can't be included in the block or the following ones
+func (q *bundleQueue) removeExpired(blockNumber, timestamp uint64) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.prune(blockNumber, timestamp)
+}
+
+// prune removes the expired bundles from the queue [NOT thread-safe]
+func (q *bundleQueue) prune(blockNumber, timestamp uint64) {
+	kept := q.bundles[:0]
+
+	for _, b := range q.bundles {
+		if !b.expired(blockNumber, timestamp) {
+			kept = append(kept, b)
+		}
+	}
+
+	// clear the tail so the dropped bundles can be collected
+	for i := len(kept); i < len(q.bundles); i++ {
+		q.bundles[i] = nil
+	}
+
+	q.bundles = kept
+}
+
+// length returns the number of bundles in the queue
+func (q *bundleQueue) length() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return len(q.bundles)
+}
+
+// AddBundle validates the transactions and adds them to the pool as a bundle
+// for the given block number and max timestamp. It returns the hash of the bundle.
+// The bundles are only accepted by the validators, as they are not gossiped
+func (p *TxPool) AddBundle(txs []*types.Transaction, blockNumber, maxTimestamp uint64) (types.Hash, error) {
+	if !p.sealing.Load() {
+		return types.ZeroHash, ErrBundleNotSealing
+	}
+
+	if len(txs) == 0 {
+		return types.ZeroHash, ErrEmptyBundle
+	}
+
+	if len(txs) > maxBundleTxs {
+		return types.ZeroHash, ErrBundleTooLarge
+	}
+
+	header := p.store.Header()
+
+	bundle := &Bundle{
+		Txs:          txs,
+		BlockNumber:  blockNumber,
+		MaxTimestamp: maxTimestamp,
+		lastBlock:    blockNumber,
+	}
+
+	if blockNumber == 0 {
+		bundle.lastBlock = header.Number + bundleLifetime
+	}
+
+	if bundle.expired(header.Number+1, uint64(time.Now().Unix())) {
+		return types.ZeroHash, ErrBundleExpired
+	}
+
+	for _, tx := range txs {
+		if err := p.validateTx(tx); err != nil {
+			return types.ZeroHash, err
+		}
+
+		// add chainID to the tx - only typed txs
+		if tx.IsTyped() {
+			tx.ChainID = p.chainID
+		}
+
+		tx.ComputeHash(header.Number)
+	}
+
+	bundle.Hash = bundleHash(txs)
+
+	if err := p.bundles.add(bundle); err != nil {
+		return types.ZeroHash, err
+	}
+
+	metrics.SetGauge([]string{txPoolMetrics, "bundles"}, float32(p.bundles.length()))
+
+	if p.logger.IsDebug() {
+		p.logger.Debug("added bundle", "hash", bundle.Hash, "txs", len(txs), "block", blockNumber)
+	}
+
+	return bundle.Hash, nil
+}
+
+// PeekBundles returns the bundles which can be included in the block
+// with the given number and timestamp, the expired bundles are dropped
+func (p *TxPool) PeekBundles(blockNumber, timestamp uint64) []*Bundle {
+	bundles := p.bundles.executables(blockNumber, timestamp)
+
+	metrics.SetGauge([]string{txPoolMetrics, "bundles"}, float32(p.bundles.length()))
+
+	return bundles
+}
+
+// pruneBundles removes the bundles which can't be included in the block following the header
+func (p *TxPool) pruneBundles(header *types.Header) {
+	p.bundles.removeExpired(header.Number+1, header.Timestamp)
+
+	metrics.SetGauge([]string{txPoolMetrics, "bundles"}, float32(p.bundles.length()))
+}
+
+// RemoveBundle removes the bundle from the pool once it's included in a block or fails
+func (p *TxPool) RemoveBundle(bundle *Bundle) {
+	p.bundles.remove(bundle.Hash)
+
+	metrics.SetGauge([]string{txPoolMetrics, "bundles"}, float32(p.bundles.length()))
+}
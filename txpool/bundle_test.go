@@ -0,0 +1,235 @@
+package txpool
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bundleTestHeader is the head of the chain in the bundle tests,
+// the bundles target the following blocks
+var bundleTestHeader = &types.Header{
+	Number:   10,
+	GasLimit: mockHeader.GasLimit,
+}
+
+// newBundleTestPool returns a new pool on top of the bundle test header
+func newBundleTestPool(t *testing.T) *TxPool {
+	t.Helper()
+
+	pool, err := newTestPool(defaultMockStore{DefaultHeader: bundleTestHeader})
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+	pool.SetSealing(true)
+
+	return pool
+}
+
+func TestAddBundle(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		txs          []*types.Transaction
+		blockNumber  uint64
+		maxTimestamp uint64
+		expectedErr  error
+	}{
+		{
+			name:        "empty bundle",
+			expectedErr: ErrEmptyBundle,
+		},
+		{
+			name: "too many transactions",
+			txs: func() []*types.Transaction {
+				txs := make([]*types.Transaction, maxBundleTxs+1)
+				for i := range txs {
+					txs[i] = newTx(addr1, uint64(i), 1)
+				}
+
+				return txs
+			}(),
+			expectedErr: ErrBundleTooLarge,
+		},
+		{
+			name:        "block number already passed",
+			txs:         []*types.Transaction{newTx(addr1, 0, 1)},
+			blockNumber: bundleTestHeader.Number,
+			expectedErr: ErrBundleExpired,
+		},
+		{
+			name:         "max timestamp already passed",
+			txs:          []*types.Transaction{newTx(addr1, 0, 1)},
+			maxTimestamp: 1,
+			expectedErr:  ErrBundleExpired,
+		},
+		{
+			name: "invalid transaction",
+			txs: []*types.Transaction{
+				newTx(addr1, 0, 1),
+				func() *types.Transaction {
+					tx := newTx(addr2, 0, 1)
+					tx.Gas = 1
+
+					return tx
+				}(),
+			},
+			expectedErr: ErrIntrinsicGas,
+		},
+		{
+			name:        "valid bundle",
+			txs:         []*types.Transaction{newTx(addr1, 0, 1), newTx(addr2, 0, 1)},
+			blockNumber: bundleTestHeader.Number + 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			pool := newBundleTestPool(t)
+
+			hash, err := pool.AddBundle(tc.txs, tc.blockNumber, tc.maxTimestamp)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Equal(t, 0, pool.bundles.length())
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, bundleHash(tc.txs), hash)
+			assert.Equal(t, 1, pool.bundles.length())
+
+			// the transactions of a bundle don't go through the pool
+			assert.Nil(t, pool.accounts.get(addr1))
+			assert.Equal(t, uint64(0), pool.gauge.read())
+
+			_, err = pool.AddBundle(tc.txs, tc.blockNumber, tc.maxTimestamp)
+			assert.ErrorIs(t, err, ErrBundleAlreadyKnown)
+		})
+	}
+}
+
+func TestAddBundle_NotSealing(t *testing.T) {
+	t.Parallel()
+
+	pool := newBundleTestPool(t)
+	pool.SetSealing(false)
+
+	_, err := pool.AddBundle([]*types.Transaction{newTx(addr1, 0, 1)}, 0, 0)
+	assert.ErrorIs(t, err, ErrBundleNotSealing)
+	assert.Equal(t, 0, pool.bundles.length())
+}
+
+func TestAddBundle_AccountLimit(t *testing.T) {
+	t.Parallel()
+
+	pool := newBundleTestPool(t)
+
+	for i := 0; i < maxAccountBundles; i++ {
+		_, err := pool.AddBundle([]*types.Transaction{newTx(addr1, uint64(i), 1)}, 0, 0)
+		require.NoError(t, err)
+	}
+
+	// the account is limited in the bundles with other accounts too
+	_, err := pool.AddBundle(
+		[]*types.Transaction{newTx(addr2, 0, 1), newTx(addr1, maxAccountBundles, 1)},
+		0,
+		0,
+	)
+	assert.ErrorIs(t, err, ErrAccountBundleLimit)
+
+	// the other accounts are not affected
+	_, err = pool.AddBundle([]*types.Transaction{newTx(addr2, 0, 1)}, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, maxAccountBundles+1, pool.bundles.length())
+}
+
+func TestPruneBundlesOnNewHead(t *testing.T) {
+	t.Parallel()
+
+	pool := newBundleTestPool(t)
+
+	next := bundleTestHeader.Number + 1
+
+	_, err := pool.AddBundle([]*types.Transaction{newTx(addr1, 0, 1)}, next, 0)
+	require.NoError(t, err)
+
+	// the bundle without block number is kept for bundleLifetime blocks
+	_, err = pool.AddBundle([]*types.Transaction{newTx(addr1, 1, 1)}, 0, 0)
+	require.NoError(t, err)
+
+	pool.ResetWithHeaders(&types.Header{Number: next - 1})
+	assert.Equal(t, 2, pool.bundles.length())
+
+	// the first bundle can't be included anymore
+	pool.ResetWithHeaders(&types.Header{Number: next})
+	assert.Equal(t, 1, pool.bundles.length())
+
+	pool.ResetWithHeaders(&types.Header{Number: bundleTestHeader.Number + bundleLifetime - 1})
+	assert.Equal(t, 1, pool.bundles.length())
+
+	pool.ResetWithHeaders(&types.Header{Number: bundleTestHeader.Number + bundleLifetime})
+	assert.Equal(t, 0, pool.bundles.length())
+}
+
+func TestPeekBundles(t *testing.T) {
+	t.Parallel()
+
+	pool := newBundleTestPool(t)
+
+	var (
+		next    = bundleTestHeader.Number + 1
+		now     = uint64(4102444800) // 2100-01-01
+		hashes  = make([]types.Hash, 0, 3)
+		targets = []struct {
+			blockNumber  uint64
+			maxTimestamp uint64
+		}{
+			{0, 0},
+			{next, 0},
+			{next + 1, now},
+		}
+	)
+
+	for i, target := range targets {
+		hash, err := pool.AddBundle(
+			[]*types.Transaction{newTx(addr1, uint64(i), 1)},
+			target.blockNumber,
+			target.maxTimestamp,
+		)
+		require.NoError(t, err)
+
+		hashes = append(hashes, hash)
+	}
+
+	bundleHashes := func(bundles []*Bundle) []types.Hash {
+		res := make([]types.Hash, len(bundles))
+		for i, b := range bundles {
+			res[i] = b.Hash
+		}
+
+		return res
+	}
+
+	// the third bundle targets the following block
+	assert.Equal(t, hashes[:2], bundleHashes(pool.PeekBundles(next, now)))
+	assert.Equal(t, 3, pool.bundles.length())
+
+	// the second bundle expired and is dropped
+	bundles := pool.PeekBundles(next+1, now)
+	assert.Equal(t, []types.Hash{hashes[0], hashes[2]}, bundleHashes(bundles))
+	assert.Equal(t, 2, pool.bundles.length())
+
+	pool.RemoveBundle(bundles[0])
+	assert.Equal(t, 1, pool.bundles.length())
+
+	// the third bundle expired by timestamp
+	assert.Empty(t, pool.PeekBundles(next+1, now+1))
+	assert.Equal(t, 0, pool.bundles.length())
+}
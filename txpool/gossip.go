@@ -0,0 +1,305 @@
+package txpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/network/grpc"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// topicNameV2 is the topic of the transaction hash announcements
+	topicNameV2 = "txpool/0.2"
+
+	// txPoolProtoV2 is the protocol for fetching the transactions announced on topicNameV2
+	txPoolProtoV2 = "/txpool/0.2"
+
+	// announceInterval is the max time a hash waits before being announced
+	announceInterval = 100 * time.Millisecond
+
+	// maxAnnounceHashes is the max number of hashes of an announcement or a fetch request
+	maxAnnounceHashes = 256
+
+	// fetchTimeout is the timeout of a fetch request
+	fetchTimeout = 5 * time.Second
+)
+
+var (
+	ErrUnrequestedTx = errors.New("peer returned an unrequested transaction")
+)
+
+// txPoolPeerService serves the announced transactions to the peers
+type txPoolPeerService struct {
+	proto.UnimplementedTxnPoolPeerServer
+
+	pool   *TxPool
+	stream *grpc.GrpcStream
+}
+
+// setupPeerService registers the protocol the peers fetch the announced transactions with
+func (p *TxPool) setupPeerService(network *network.Server) {
+	service := &txPoolPeerService{
+		pool:   p,
+		stream: grpc.NewGrpcStream(),
+	}
+
+	proto.RegisterTxnPoolPeerServer(service.stream.GrpcServer(), service)
+	service.stream.Serve()
+	network.RegisterProtocol(txPoolProtoV2, service.stream)
+
+	p.peerService = service
+}
+
+// GetTxns is a gRPC endpoint returning the requested transactions which are in the pool
+func (s *txPoolPeerService) GetTxns(_ context.Context, req *proto.GetTxnsReq) (*proto.GetTxnsResp, error) {
+	if len(req.Hashes) > maxAnnounceHashes {
+		return nil, fmt.Errorf("too many hashes requested: %d", len(req.Hashes))
+	}
+
+	resp := &proto.GetTxnsResp{
+		Txs: make([][]byte, 0, len(req.Hashes)),
+	}
+
+	for _, hash := range req.Hashes {
+		if tx, ok := s.pool.index.get(types.BytesToHash(hash)); ok {
+			resp.Txs = append(resp.Txs, tx.MarshalRLP())
+		}
+	}
+
+	return resp, nil
+}
+
+// gossip announces the hash of the transaction to the peers. The whole transaction
+// is broadcast to the old topic as long as there are peers not supporting the announcements
+func (p *TxPool) gossip(tx *types.Transaction) {
+	p.announce(tx.Hash)
+
+	if p.hasLegacyPeers() {
+		p.broadcast(tx)
+	}
+}
+
+// announce queues the hash of the transaction to be announced to the peers
+func (p *TxPool) announce(hash types.Hash) {
+	if p.announceTopic == nil {
+		return
+	}
+
+	select {
+	case p.announceCh <- hash:
+	default:
+		// the announcer fell behind, the hash is dropped instead of blocking the caller
+		metrics.IncrCounter([]string{txPoolMetrics, "dropped_announcements"}, 1)
+	}
+}
+
+// broadcast publishes the whole transaction to the old topic
+func (p *TxPool) broadcast(tx *types.Transaction) {
+	msg := &proto.Txn{
+		Raw: &any.Any{
+			Value: tx.MarshalRLP(),
+		},
+	}
+
+	if err := p.topic.Publish(msg); err != nil {
+		p.logger.Error("failed to topic tx", "err", err)
+	}
+}
+
+// hasLegacyPeers returns true if any of the peers subscribed
+// to the old topic isn't subscribed to the announcements
+func (p *TxPool) hasLegacyPeers() bool {
+	if p.topic == nil {
+		return false
+	}
+
+	if p.announceTopic == nil {
+		return true
+	}
+
+	announcePeers := make(map[peer.ID]struct{})
+	for _, id := range p.announceTopic.ListPeers() {
+		announcePeers[id] = struct{}{}
+	}
+
+	for _, id := range p.topic.ListPeers() {
+		if _, ok := announcePeers[id]; !ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// runAnnouncer publishes the queued hashes in batches,
+// once per announce interval or when the batch is full
+func (p *TxPool) runAnnouncer() {
+	ticker := time.NewTicker(announceInterval)
+	defer ticker.Stop()
+
+	hashes := make([][]byte, 0, maxAnnounceHashes)
+
+	announce := func() {
+		if len(hashes) == 0 {
+			return
+		}
+
+		if err := p.announceTopic.Publish(&proto.TxnHashes{Hashes: hashes}); err != nil {
+			p.logger.Error("failed to announce tx hashes", "err", err)
+		}
+
+		metrics.IncrCounter([]string{txPoolMetrics, "announced_tx"}, float32(len(hashes)))
+
+		hashes = hashes[:0]
+	}
+
+	for {
+		select {
+		case <-p.shutdownCh:
+			return
+		case hash := <-p.announceCh:
+			hashes = append(hashes, hash.Bytes())
+
+			if len(hashes) == maxAnnounceHashes {
+				announce()
+			}
+		case <-ticker.C:
+			announce()
+		}
+	}
+}
+
+// addGossipHashes handles receiving transaction hashes announced by the network.
+// The transactions which aren't in the pool are fetched from the peer which relayed the announcement,
+// the publisher of the announcement may not be connected
+func (p *TxPool) addGossipHashes(obj interface{}, relay peer.ID) {
+	if !p.sealing.Load() {
+		return
+	}
+
+	raw, ok := obj.(*proto.TxnHashes)
+	if !ok {
+		p.logger.Error("failed to cast gossiped message to txn hashes")
+
+		return
+	}
+
+	hashes := p.unknownHashes(raw.Hashes)
+	if len(hashes) == 0 {
+		return
+	}
+
+	txs, err := p.fetchTxs(relay, hashes)
+	if err != nil {
+		p.logger.Error("failed to fetch announced txs", "peer", relay, "err", err)
+
+		return
+	}
+
+	metrics.IncrCounter([]string{txPoolMetrics, "fetched_tx"}, float32(len(txs)))
+
+	legacyPeers := p.hasLegacyPeers()
+
+	for _, tx := range txs {
+		if !p.addGossipTxn(tx) {
+			continue
+		}
+
+		// announce the transaction as the peers can fetch it from this node now
+		p.announce(tx.Hash)
+
+		if legacyPeers {
+			// forward the transaction to the peers not supporting the announcements
+			p.broadcast(tx)
+		}
+	}
+}
+
+// unknownHashes returns the announced hashes of the transactions which aren't in the pool
+func (p *TxPool) unknownHashes(announced [][]byte) []types.Hash {
+	if len(announced) > maxAnnounceHashes {
+		announced = announced[:maxAnnounceHashes]
+	}
+
+	hashes := make([]types.Hash, 0, len(announced))
+
+	for _, raw := range announced {
+		if len(raw) != types.HashLength {
+			continue
+		}
+
+		hash := types.BytesToHash(raw)
+		if _, ok := p.index.get(hash); !ok {
+			hashes = append(hashes, hash)
+		}
+	}
+
+	return hashes
+}
+
+// fetchTxs requests the transactions with the given hashes from the peer
+func (p *TxPool) fetchTxs(peerID peer.ID, hashes []types.Hash) ([]*types.Transaction, error) {
+	conn, err := p.network.NewProtoConnection(txPoolProtoV2, peerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a stream, err %w", err)
+	}
+
+	defer conn.Close()
+
+	req := &proto.GetTxnsReq{
+		Hashes: make([][]byte, len(hashes)),
+	}
+
+	for i, hash := range hashes {
+		req.Hashes[i] = hash.Bytes()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+
+	resp, err := proto.NewTxnPoolPeerClient(conn).GetTxns(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.decodeFetchedTxs(hashes, resp.Txs)
+}
+
+// decodeFetchedTxs decodes the fetched transactions
+// and checks they are the requested ones
+func (p *TxPool) decodeFetchedTxs(requested []types.Hash, raw [][]byte) ([]*types.Transaction, error) {
+	pending := make(map[types.Hash]struct{}, len(requested))
+	for _, hash := range requested {
+		pending[hash] = struct{}{}
+	}
+
+	blockNumber := p.store.Header().Number
+	txs := make([]*types.Transaction, 0, len(raw))
+
+	for _, data := range raw {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalRLP(data); err != nil {
+			return nil, err
+		}
+
+		tx.ComputeHash(blockNumber)
+
+		if _, ok := pending[tx.Hash]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnrequestedTx, tx.Hash)
+		}
+
+		delete(pending, tx.Hash)
+
+		txs = append(txs, tx)
+	}
+
+	return txs, nil
+}
@@ -0,0 +1,153 @@
+package txpool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTxns(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	tx := newTx(addr1, 0, 1)
+	require.NoError(t, pool.addTx(local, tx))
+
+	service := &txPoolPeerService{pool: pool}
+
+	t.Run("known and unknown hashes", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := service.GetTxns(context.Background(), &proto.GetTxnsReq{
+			Hashes: [][]byte{tx.Hash.Bytes(), types.StringToHash("0x1").Bytes()},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Txs, 1)
+		assert.Equal(t, tx.MarshalRLP(), resp.Txs[0])
+	})
+
+	t.Run("too many hashes", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := service.GetTxns(context.Background(), &proto.GetTxnsReq{
+			Hashes: make([][]byte, maxAnnounceHashes+1),
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestGossip_AnnouncerFellBehind(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+
+	pool.announceTopic = &network.Topic{}
+	pool.announceCh = make(chan types.Hash, 1)
+
+	tx1, tx2 := newTx(addr1, 0, 1), newTx(addr1, 1, 1)
+	tx1.ComputeHash(mockHeader.Number)
+	tx2.ComputeHash(mockHeader.Number)
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		pool.gossip(tx1)
+		// the queue is full, the hash is dropped
+		pool.gossip(tx2)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("gossip blocked on the announcement queue")
+	}
+
+	assert.Equal(t, tx1.Hash, <-pool.announceCh)
+	assert.Empty(t, pool.announceCh)
+}
+
+func TestUnknownHashes(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	tx := newTx(addr1, 0, 1)
+	require.NoError(t, pool.addTx(local, tx))
+
+	unknown := types.StringToHash("0x1")
+
+	hashes := pool.unknownHashes([][]byte{
+		tx.Hash.Bytes(),
+		unknown.Bytes(),
+		{0x1, 0x2}, // malformed
+	})
+
+	assert.Equal(t, []types.Hash{unknown}, hashes)
+
+	// the announcement is capped
+	announced := make([][]byte, maxAnnounceHashes+1)
+	for i := range announced {
+		announced[i] = types.BytesToHash([]byte{byte(i), byte(i >> 8)}).Bytes()
+	}
+
+	assert.Len(t, pool.unknownHashes(announced), maxAnnounceHashes)
+}
+
+func TestDecodeFetchedTxs(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+
+	txs := []*types.Transaction{newTx(addr1, 0, 1), newTx(addr2, 0, 1)}
+	for _, tx := range txs {
+		tx.ComputeHash(mockHeader.Number)
+	}
+
+	requested := []types.Hash{txs[0].Hash, txs[1].Hash}
+
+	t.Run("requested transactions", func(t *testing.T) {
+		t.Parallel()
+
+		// the peer returns the transactions it knows only
+		fetched, err := pool.decodeFetchedTxs(requested, [][]byte{txs[1].MarshalRLP()})
+		require.NoError(t, err)
+		require.Len(t, fetched, 1)
+		assert.Equal(t, txs[1].Hash, fetched[0].Hash)
+	})
+
+	t.Run("unrequested transaction", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := pool.decodeFetchedTxs(requested[:1], [][]byte{txs[1].MarshalRLP()})
+		assert.ErrorIs(t, err, ErrUnrequestedTx)
+	})
+
+	t.Run("duplicated transaction", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := pool.decodeFetchedTxs(requested, [][]byte{txs[0].MarshalRLP(), txs[0].MarshalRLP()})
+		assert.ErrorIs(t, err, ErrUnrequestedTx)
+	})
+
+	t.Run("malformed transaction", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := pool.decodeFetchedTxs(requested, [][]byte{{0xff}})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,227 @@
+package txpool
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// defaultJournalRotation is the interval between the journal rotations if not configured
+	defaultJournalRotation = time.Hour
+
+	// journalEntryPrefixSize is the size of the length prefix of each journal entry
+	journalEntryPrefixSize = 4
+)
+
+var (
+	errJournalCorrupted = errors.New("txpool journal corrupted")
+)
+
+// journal is an append-only file of the locally submitted transactions,
+// which are replayed when the pool starts. Each entry is the RLP encoded transaction
+// prefixed with its length. The journal is rotated to drop the transactions which left the pool
+type journal struct {
+	lock sync.Mutex
+
+	path   string
+	writer *os.File // nil until the journal is loaded and rotated
+	closed bool     // the journal is no longer written once closed
+
+	// entries are the journaled transactions by hash
+	entries map[types.Hash]*types.Transaction
+}
+
+func newJournal(path string) *journal {
+	return &journal{
+		path:    path,
+		entries: make(map[types.Hash]*types.Transaction),
+	}
+}
+
+// load reads the transactions from the journal file and passes them to the add function,
+// which is expected to insert them back through the journal.
+// It returns the number of the transactions read and the number of the rejected ones
+func (j *journal) load(add func(*types.Transaction) error) (int, int, error) {
+	file, err := os.Open(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, 0, nil
+	} else if err != nil {
+		return 0, 0, err
+	}
+
+	defer file.Close()
+
+	var (
+		reader          = bufio.NewReader(file)
+		prefix          = make([]byte, journalEntryPrefixSize)
+		total, rejected int
+	)
+
+	for {
+		if _, err := io.ReadFull(reader, prefix); err != nil {
+			if errors.Is(err, io.EOF) {
+				return total, rejected, nil
+			}
+
+			// the node may have been stopped while writing the last entry
+			return total, rejected, fmt.Errorf("%w: %v", errJournalCorrupted, err)
+		}
+
+		size := binary.BigEndian.Uint32(prefix)
+		if size > txMaxSize {
+			return total, rejected, fmt.Errorf("%w: entry of %d bytes", errJournalCorrupted, size)
+		}
+
+		data := make([]byte, size)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return total, rejected, fmt.Errorf("%w: %v", errJournalCorrupted, err)
+		}
+
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalRLP(data); err != nil {
+			return total, rejected, fmt.Errorf("%w: %v", errJournalCorrupted, err)
+		}
+
+		total++
+
+		if err := add(tx); err != nil {
+			rejected++
+		}
+	}
+}
+
+// insert adds the transaction to the journal,
+// it's only written to the file once the journal has been rotated the first time
+func (j *journal) insert(tx *types.Transaction) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if _, ok := j.entries[tx.Hash]; ok {
+		return nil
+	}
+
+	j.entries[tx.Hash] = tx
+
+	if j.writer == nil {
+		return nil
+	}
+
+	return writeJournalEntry(j.writer, tx)
+}
+
+// rotate rewrites the journal with the transactions for which keep returns true
+// and drops the rest. It returns the number of the transactions kept
+func (j *journal) rotate(keep func(*types.Transaction) bool) (int, error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	// the rotation may race with the shutdown of the pool
+	if j.closed {
+		return len(j.entries), nil
+	}
+
+	if j.writer != nil {
+		if err := j.writer.Close(); err != nil {
+			return 0, err
+		}
+
+		j.writer = nil
+	}
+
+	tmpPath := j.path + ".new"
+
+	tmp, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return 0, err
+	}
+
+	kept := make([]*types.Transaction, 0, len(j.entries))
+
+	for hash, tx := range j.entries {
+		if !keep(tx) {
+			delete(j.entries, hash)
+
+			continue
+		}
+
+		kept = append(kept, tx)
+	}
+
+	// the transactions of each account are replayed in nonce order
+	sort.Slice(kept, func(i, k int) bool {
+		if kept[i].From != kept[k].From {
+			return bytes.Compare(kept[i].From.Bytes(), kept[k].From.Bytes()) < 0
+		}
+
+		return kept[i].Nonce < kept[k].Nonce
+	})
+
+	writer := bufio.NewWriter(tmp)
+
+	for _, tx := range kept {
+		if err := writeJournalEntry(writer, tx); err != nil {
+			tmp.Close()
+
+			return 0, err
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+
+		return 0, err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return 0, err
+	}
+
+	if err := os.Rename(tmpPath, j.path); err != nil {
+		return 0, err
+	}
+
+	if j.writer, err = os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0600); err != nil {
+		return 0, err
+	}
+
+	return len(j.entries), nil
+}
+
+// close closes the journal file
+func (j *journal) close() error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	j.closed = true
+
+	if j.writer == nil {
+		return nil
+	}
+
+	err := j.writer.Close()
+	j.writer = nil
+
+	return err
+}
+
+// writeJournalEntry writes the length prefixed RLP encoding of the transaction
+func writeJournalEntry(w io.Writer, tx *types.Transaction) error {
+	data := tx.MarshalRLP()
+	entry := make([]byte, journalEntryPrefixSize, journalEntryPrefixSize+len(data))
+
+	binary.BigEndian.PutUint32(entry, uint32(len(data)))
+
+	_, err := w.Write(append(entry, data...))
+
+	return err
+}
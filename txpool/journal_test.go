@@ -0,0 +1,157 @@
+package txpool
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newJournalTestTx(nonce uint64) *types.Transaction {
+	tx := &types.Transaction{
+		Nonce:    nonce,
+		To:       &addr2,
+		Value:    big.NewInt(1),
+		GasPrice: big.NewInt(1),
+		Gas:      validGasLimit,
+		V:        big.NewInt(27),
+		R:        big.NewInt(1),
+		S:        big.NewInt(1),
+	}
+
+	return tx.ComputeHash(1)
+}
+
+// loadJournalTxs loads the journal and returns the transactions read
+func loadJournalTxs(t *testing.T, j *journal) ([]*types.Transaction, error) {
+	t.Helper()
+
+	txs := make([]*types.Transaction, 0)
+
+	_, _, err := j.load(func(tx *types.Transaction) error {
+		txs = append(txs, tx.ComputeHash(1))
+
+		return j.insert(tx)
+	})
+
+	return txs, err
+}
+
+func TestJournal_InsertAndLoad(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "transactions.rlp")
+
+	j := newJournal(path)
+
+	// the missing journal is empty
+	txs, err := loadJournalTxs(t, j)
+	require.NoError(t, err)
+	require.Empty(t, txs)
+
+	_, err = j.rotate(func(*types.Transaction) bool { return true })
+	require.NoError(t, err)
+
+	tx0, tx1 := newJournalTestTx(0), newJournalTestTx(1)
+
+	require.NoError(t, j.insert(tx0))
+	require.NoError(t, j.insert(tx1))
+	// the known transactions are not written again
+	require.NoError(t, j.insert(tx1))
+	require.NoError(t, j.close())
+
+	j = newJournal(path)
+
+	txs, err = loadJournalTxs(t, j)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	assert.Equal(t, tx0.Hash, txs[0].Hash)
+	assert.Equal(t, tx1.Hash, txs[1].Hash)
+}
+
+func TestJournal_Rotate(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "transactions.rlp")
+
+	j := newJournal(path)
+
+	_, err := j.rotate(func(*types.Transaction) bool { return true })
+	require.NoError(t, err)
+
+	txs := []*types.Transaction{newJournalTestTx(2), newJournalTestTx(0), newJournalTestTx(1)}
+	for _, tx := range txs {
+		require.NoError(t, j.insert(tx))
+	}
+
+	// drop the transaction with nonce 1
+	kept, err := j.rotate(func(tx *types.Transaction) bool {
+		return tx.Nonce != 1
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, kept)
+	require.NoError(t, j.close())
+
+	_, err = os.Stat(path + ".new")
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	loaded, err := loadJournalTxs(t, newJournal(path))
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+
+	// the transactions are rewritten in nonce order
+	assert.Equal(t, uint64(0), loaded[0].Nonce)
+	assert.Equal(t, uint64(2), loaded[1].Nonce)
+}
+
+func TestJournal_RotateAfterClose(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "transactions.rlp")
+
+	j := newJournal(path)
+
+	_, err := j.rotate(func(*types.Transaction) bool { return true })
+	require.NoError(t, err)
+	require.NoError(t, j.insert(newJournalTestTx(0)))
+	require.NoError(t, j.close())
+
+	// the closed journal is not reopened
+	kept, err := j.rotate(func(*types.Transaction) bool { return false })
+	require.NoError(t, err)
+	assert.Equal(t, 1, kept)
+	assert.Nil(t, j.writer)
+
+	loaded, err := loadJournalTxs(t, newJournal(path))
+	require.NoError(t, err)
+	assert.Len(t, loaded, 1)
+}
+
+func TestJournal_CorruptedEntry(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "transactions.rlp")
+
+	j := newJournal(path)
+
+	_, err := j.rotate(func(*types.Transaction) bool { return true })
+	require.NoError(t, err)
+	require.NoError(t, j.insert(newJournalTestTx(0)))
+	require.NoError(t, j.close())
+
+	// the node has been stopped while writing an entry
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+
+	_, err = file.Write([]byte{0, 0, 1, 0, 0xc0})
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	txs, err := loadJournalTxs(t, newJournal(path))
+	require.ErrorIs(t, err, errJournalCorrupted)
+	require.Len(t, txs, 1)
+}
@@ -2,6 +2,7 @@ package txpool
 
 import (
 	"sync"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -10,6 +11,8 @@ import (
 type lookupMap struct {
 	sync.RWMutex
 	all map[types.Hash]*types.Transaction
+	// arrivals holds the time each transaction was added to the pool at
+	arrivals map[types.Hash]time.Time
 }
 
 // add inserts the given transaction into the map. Returns false
@@ -23,6 +26,7 @@ func (m *lookupMap) add(tx *types.Transaction) bool {
 	}
 
 	m.all[tx.Hash] = tx
+	m.arrivals[tx.Hash] = time.Now()
 
 	return true
 }
@@ -34,6 +38,7 @@ func (m *lookupMap) remove(txs ...*types.Transaction) {
 
 	for _, tx := range txs {
 		delete(m.all, tx.Hash)
+		delete(m.arrivals, tx.Hash)
 	}
 }
 
@@ -49,3 +54,12 @@ func (m *lookupMap) get(hash types.Hash) (*types.Transaction, bool) {
 
 	return tx, true
 }
+
+// arrival returns the time the transaction with the given hash was added to the pool at,
+// or the zero time if the transaction is not present. [thread-safe]
+func (m *lookupMap) arrival(hash types.Hash) time.Time {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.arrivals[hash]
+}
@@ -0,0 +1,87 @@
+package txpool
+
+import (
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+)
+
+// rejectionReasons are the labels of the rejected transactions counter, the wrapping
+// errors precede the ones they wrap as the first matching error is used
+var rejectionReasons = []struct {
+	err    error
+	reason string
+}{
+	{ErrIntrinsicGas, "intrinsic_gas"},
+	{ErrBlockLimitExceeded, "block_limit_exceeded"},
+	{ErrNegativeValue, "negative_value"},
+	{ErrExtractSignature, "extract_signature"},
+	{ErrInvalidSender, "invalid_sender"},
+	{ErrTxPoolOverflow, "overflow"},
+	{ErrReplacementUnderpriced, "replacement_underpriced"},
+	{ErrUnderpriced, "underpriced"},
+	{ErrNonceTooLow, "nonce_too_low"},
+	{ErrInsufficientFunds, "insufficient_funds"},
+	{ErrInvalidAccountState, "invalid_account_state"},
+	{ErrAlreadyKnown, "already_known"},
+	{ErrOversizedData, "oversized_data"},
+	{ErrMaxEnqueuedLimitReached, "max_enqueued_limit_reached"},
+	{ErrRejectFutureTx, "future_tx"},
+	{ErrInvalidTxType, "invalid_tx_type"},
+	{ErrTipAboveFeeCap, "tip_above_fee_cap"},
+	{ErrTipVeryHigh, "tip_very_high"},
+	{ErrFeeCapVeryHigh, "fee_cap_very_high"},
+	{ErrDynamicTxNotAllowed, "dynamic_tx_not_allowed"},
+	{ErrInvalidSponsor, "invalid_sponsor"},
+	{ErrSponsorUnderfunded, "sponsor_underfunded"},
+}
+
+// rejectionReason returns the label of the error the transaction was rejected with
+func rejectionReason(err error) string {
+	for _, r := range rejectionReasons {
+		if errors.Is(err, r.err) {
+			return r.reason
+		}
+	}
+
+	return "other"
+}
+
+// recordAdmission counts the transaction admitted to the pool or rejected with the given error,
+// the counters are labeled by the origin so the admission rate of each one can be tracked
+func recordAdmission(origin txOrigin, err error) {
+	originLabel := metrics.Label{Name: "origin", Value: origin.String()}
+
+	if err == nil {
+		metrics.IncrCounterWithLabels([]string{txPoolMetrics, "admitted_tx"}, 1, []metrics.Label{originLabel})
+
+		return
+	}
+
+	metrics.IncrCounterWithLabels(
+		[]string{txPoolMetrics, "rejected_tx"},
+		1,
+		[]metrics.Label{originLabel, {Name: "reason", Value: rejectionReason(err)}},
+	)
+}
+
+// recordPromotions samples the time the promoted transactions spent in the pool before the promotion
+func (p *TxPool) recordPromotions(promoted []*types.Transaction) {
+	for _, tx := range promoted {
+		if arrival := p.index.arrival(tx.Hash); !arrival.IsZero() {
+			metrics.MeasureSince([]string{txPoolMetrics, "promotion_latency"}, arrival)
+		}
+	}
+}
+
+// recordPruning counts the transactions and the accounts pruned due to the nonce gaps
+func recordPruning(accounts, txs int) {
+	metrics.IncrCounter([]string{txPoolMetrics, "pruned_accounts"}, float32(accounts))
+	metrics.IncrCounter([]string{txPoolMetrics, "pruned_tx"}, float32(txs))
+}
+
+// recordAccounts sets the number of the accounts known to the pool
+func recordAccounts(count uint64) {
+	metrics.SetGauge([]string{txPoolMetrics, "accounts"}, float32(count))
+}
@@ -0,0 +1,34 @@
+package txpool
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRejectionReason(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"plain error", ErrNonceTooLow, "nonce_too_low"},
+		{"wrapped error", fmt.Errorf("%w: 1 < 2", ErrUnderpriced), "underpriced"},
+		{"replacement", ErrReplacementUnderpriced, "replacement_underpriced"},
+		{"unknown error", errors.New("random"), "other"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, rejectionReason(tc.err))
+		})
+	}
+}
@@ -88,3 +88,7 @@ type mockSigner struct {
 func (s *mockSigner) Sender(tx *types.Transaction) (types.Address, error) {
 	return tx.From, nil
 }
+
+func (s *mockSigner) Sponsor(tx *types.Transaction) (types.Address, error) {
+	return tx.Sponsor, nil
+}
@@ -0,0 +1,87 @@
+package txpool
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// OrderingType is the name of a policy ordering the executable transactions
+type OrderingType string
+
+const (
+	// PriceOrdering executes the transactions with the highest effective tip first
+	PriceOrdering OrderingType = "price"
+	// FIFOOrdering executes the transactions in the order they arrived to the pool
+	FIFOOrdering OrderingType = "fifo"
+)
+
+var (
+	ErrUnknownOrdering = errors.New("unknown transaction ordering policy")
+)
+
+// orderingPolicy decides which of two executable transactions, heads of
+// the promoted queues of different accounts, is handed to the block builder first
+type orderingPolicy interface {
+	// less returns true if the transaction a is executed before b
+	less(a, b *types.Transaction) bool
+}
+
+// orderingFactory creates the ordering policy used while building a block,
+// the base fee is the one of the block and arrival returns the time the
+// transaction was added to the pool at
+type orderingFactory func(baseFee *big.Int, arrival func(hash types.Hash) time.Time) orderingPolicy
+
+// orderingPolicies are the supported ordering policies
+var orderingPolicies = map[OrderingType]orderingFactory{
+	PriceOrdering: func(baseFee *big.Int, _ func(types.Hash) time.Time) orderingPolicy {
+		return &priceOrdering{baseFee: baseFee}
+	},
+	FIFOOrdering: func(_ *big.Int, arrival func(types.Hash) time.Time) orderingPolicy {
+		return &fifoOrdering{arrival: arrival}
+	},
+}
+
+// ValidateOrdering returns an error if the given ordering policy is not supported
+func ValidateOrdering(ordering OrderingType) error {
+	if _, ok := orderingPolicies[ordering]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownOrdering, ordering)
+	}
+
+	return nil
+}
+
+// priceOrdering sorts the transactions by the effective tip (descending)
+type priceOrdering struct {
+	baseFee *big.Int
+}
+
+// @see https://github.com/etclabscore/core-geth/blob/4e2b0e37f89515a4e7b6bafaa40910a296cb38c0/core/txpool/list.go#L458
+// for details why is something implemented like it is
+func (o *priceOrdering) less(a, b *types.Transaction) bool {
+	switch cmp(a, b, o.baseFee) {
+	case -1:
+		return false
+	case 1:
+		return true
+	default:
+		return a.Nonce < b.Nonce
+	}
+}
+
+// fifoOrdering sorts the transactions by the arrival time (ascending),
+// the price is only used to break the ties
+type fifoOrdering struct {
+	arrival func(hash types.Hash) time.Time
+}
+
+func (o *fifoOrdering) less(a, b *types.Transaction) bool {
+	if aArrival, bArrival := o.arrival(a.Hash), o.arrival(b.Hash); !aArrival.Equal(bArrival) {
+		return aArrival.Before(bArrival)
+	}
+
+	return cmp(a, b, big.NewInt(0)) > 0
+}
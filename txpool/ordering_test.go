@@ -0,0 +1,122 @@
+package txpool
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateOrdering(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, ValidateOrdering(PriceOrdering))
+	assert.NoError(t, ValidateOrdering(FIFOOrdering))
+	assert.ErrorIs(t, ValidateOrdering("random"), ErrUnknownOrdering)
+}
+
+func TestOrderedQueue_FIFO(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now      = time.Now()
+		arrivals = make(map[types.Hash]time.Time)
+		txs      = make([]*types.Transaction, 0, 3)
+	)
+
+	// the later transactions pay more
+	for i, addr := range []types.Address{addr1, addr2, addr3} {
+		tx := newTx(addr, 0, 1)
+		tx.GasPrice = big.NewInt(int64(i + 1))
+		tx.ComputeHash(1)
+
+		arrivals[tx.Hash] = now.Add(time.Duration(i) * time.Second)
+		txs = append(txs, tx)
+	}
+
+	policy := orderingPolicies[FIFOOrdering](big.NewInt(0), func(hash types.Hash) time.Time {
+		return arrivals[hash]
+	})
+
+	// shuffle the initial transactions
+	queue := newOrderedQueue(policy, []*types.Transaction{txs[2], txs[0], txs[1]}, nil)
+
+	for _, expected := range txs {
+		assert.Equal(t, expected, queue.pop())
+	}
+
+	assert.Nil(t, queue.pop())
+}
+
+func TestMaxSenderTxs(t *testing.T) {
+	t.Parallel()
+
+	pool, err := NewTxPool(
+		hclog.NewNullLogger(),
+		forks.At(0),
+		defaultMockStore{DefaultHeader: mockHeader},
+		nil,
+		nil,
+		&Config{
+			PriceLimit:         defaultPriceLimit,
+			MaxSlots:           defaultMaxSlots,
+			MaxAccountEnqueued: defaultMaxAccountEnqueued,
+			Ordering:           FIFOOrdering,
+			MaxSenderTxs:       2,
+		},
+	)
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	// the hash doesn't cover the sender, the transactions with the same nonce differ in the value
+	otherSenderTx := newTx(addr2, 0, 1)
+	otherSenderTx.Value = big.NewInt(2)
+
+	for _, tx := range []*types.Transaction{
+		newTx(addr1, 0, 1),
+		newTx(addr1, 1, 1),
+		newTx(addr1, 2, 1),
+		otherSenderTx,
+	} {
+		require.NoError(t, pool.addTx(local, tx))
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+	}
+
+	// popAll returns the senders of the transactions handed to the block builder
+	popAll := func() []types.Address {
+		senders := []types.Address{}
+
+		pool.Prepare()
+
+		for tx := pool.Peek(); tx != nil; tx = pool.Peek() {
+			pool.Pop(tx)
+
+			senders = append(senders, tx.From)
+		}
+
+		return senders
+	}
+
+	// the third transaction of the first account waits for the following block
+	assert.Equal(t, []types.Address{addr1, addr1, addr2}, popAll())
+	assert.Equal(t, []types.Address{addr1}, popAll())
+	assert.Empty(t, popAll())
+}
+
+func TestNewTxPool_UnknownOrdering(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewTxPool(
+		hclog.NewNullLogger(),
+		forks.At(0),
+		defaultMockStore{DefaultHeader: mockHeader},
+		nil,
+		nil,
+		&Config{Ordering: "random"},
+	)
+	assert.ErrorIs(t, err, ErrUnknownOrdering)
+}
@@ -0,0 +1,274 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.21.7
+// source: txpool/proto/v2.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// TxnHashes announces the hashes of the transactions added to the pool
+type TxnHashes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hashes [][]byte `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+}
+
+func (x *TxnHashes) Reset() {
+	*x = TxnHashes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_proto_v2_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TxnHashes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxnHashes) ProtoMessage() {}
+
+func (x *TxnHashes) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_proto_v2_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxnHashes.ProtoReflect.Descriptor instead.
+func (*TxnHashes) Descriptor() ([]byte, []int) {
+	return file_txpool_proto_v2_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TxnHashes) GetHashes() [][]byte {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+type GetTxnsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hashes [][]byte `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+}
+
+func (x *GetTxnsReq) Reset() {
+	*x = GetTxnsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_proto_v2_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTxnsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTxnsReq) ProtoMessage() {}
+
+func (x *GetTxnsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_proto_v2_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTxnsReq.ProtoReflect.Descriptor instead.
+func (*GetTxnsReq) Descriptor() ([]byte, []int) {
+	return file_txpool_proto_v2_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetTxnsReq) GetHashes() [][]byte {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+type GetTxnsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// RLP encoded transactions
+	Txs [][]byte `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+}
+
+func (x *GetTxnsResp) Reset() {
+	*x = GetTxnsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_proto_v2_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTxnsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTxnsResp) ProtoMessage() {}
+
+func (x *GetTxnsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_proto_v2_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTxnsResp.ProtoReflect.Descriptor instead.
+func (*GetTxnsResp) Descriptor() ([]byte, []int) {
+	return file_txpool_proto_v2_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetTxnsResp) GetTxs() [][]byte {
+	if x != nil {
+		return x.Txs
+	}
+	return nil
+}
+
+var File_txpool_proto_v2_proto protoreflect.FileDescriptor
+
+var file_txpool_proto_v2_proto_rawDesc = []byte{
+	0x0a, 0x15, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76,
+	0x32, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x76, 0x32, 0x22, 0x23, 0x0a, 0x09, 0x54,
+	0x78, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73,
+	0x22, 0x24, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x54, 0x78, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x12, 0x16,
+	0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06,
+	0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x22, 0x1f, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x54, 0x78, 0x6e,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x78, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x03, 0x74, 0x78, 0x73, 0x32, 0x39, 0x0a, 0x0b, 0x54, 0x78, 0x6e, 0x50, 0x6f,
+	0x6f, 0x6c, 0x50, 0x65, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x54, 0x78, 0x6e,
+	0x73, 0x12, 0x0e, 0x2e, 0x76, 0x32, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x78, 0x6e, 0x73, 0x52, 0x65,
+	0x71, 0x1a, 0x0f, 0x2e, 0x76, 0x32, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x78, 0x6e, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x42, 0x0f, 0x5a, 0x0d, 0x2f, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_txpool_proto_v2_proto_rawDescOnce sync.Once
+	file_txpool_proto_v2_proto_rawDescData = file_txpool_proto_v2_proto_rawDesc
+)
+
+func file_txpool_proto_v2_proto_rawDescGZIP() []byte {
+	file_txpool_proto_v2_proto_rawDescOnce.Do(func() {
+		file_txpool_proto_v2_proto_rawDescData = protoimpl.X.CompressGZIP(file_txpool_proto_v2_proto_rawDescData)
+	})
+	return file_txpool_proto_v2_proto_rawDescData
+}
+
+var file_txpool_proto_v2_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_txpool_proto_v2_proto_goTypes = []interface{}{
+	(*TxnHashes)(nil),   // 0: v2.TxnHashes
+	(*GetTxnsReq)(nil),  // 1: v2.GetTxnsReq
+	(*GetTxnsResp)(nil), // 2: v2.GetTxnsResp
+}
+var file_txpool_proto_v2_proto_depIdxs = []int32{
+	1, // 0: v2.TxnPoolPeer.GetTxns:input_type -> v2.GetTxnsReq
+	2, // 1: v2.TxnPoolPeer.GetTxns:output_type -> v2.GetTxnsResp
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_txpool_proto_v2_proto_init() }
+func file_txpool_proto_v2_proto_init() {
+	if File_txpool_proto_v2_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_txpool_proto_v2_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TxnHashes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_txpool_proto_v2_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTxnsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_txpool_proto_v2_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTxnsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_txpool_proto_v2_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_txpool_proto_v2_proto_goTypes,
+		DependencyIndexes: file_txpool_proto_v2_proto_depIdxs,
+		MessageInfos:      file_txpool_proto_v2_proto_msgTypes,
+	}.Build()
+	File_txpool_proto_v2_proto = out.File
+	file_txpool_proto_v2_proto_rawDesc = nil
+	file_txpool_proto_v2_proto_goTypes = nil
+	file_txpool_proto_v2_proto_depIdxs = nil
+}
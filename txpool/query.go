@@ -1,6 +1,7 @@
 package txpool
 
 import (
+	"math/big"
 	"sync/atomic"
 
 	"github.com/0xPolygon/polygon-edge/types"
@@ -49,6 +50,74 @@ func (p *TxPool) GetTxs(inclQueued bool) (
 	return p.accounts.allTxs(inclQueued)
 }
 
+// GetAccountTxs gets pending and queued transactions of the account, sorted by nonce
+func (p *TxPool) GetAccountTxs(addr types.Address) (promoted, enqueued []*types.Transaction) {
+	account := p.accounts.get(addr)
+	if account == nil {
+		return nil, nil
+	}
+
+	account.promoted.lock(false)
+	defer account.promoted.unlock()
+
+	account.enqueued.lock(false)
+	defer account.enqueued.unlock()
+
+	return account.promoted.sorted(), account.enqueued.sorted()
+}
+
+// GetTxStatus returns the status of the transaction with the given hash,
+// the dropped transactions are known until they're pushed out of the history
+func (p *TxPool) GetTxStatus(txHash types.Hash) (*TxStatus, bool) {
+	record, known := p.history.get(txHash)
+
+	tx, ok := p.index.get(txHash)
+	if !ok {
+		if !known || record.dropReason == "" {
+			return nil, false
+		}
+
+		return &TxStatus{
+			State:      TxStateDropped,
+			Demotions:  record.demotions,
+			DropReason: record.dropReason,
+		}, true
+	}
+
+	status := &TxStatus{
+		State:     TxStateEnqueued,
+		Demotions: record.demotions,
+	}
+
+	// the transactions with the nonce below the next one of the account are promoted
+	if account := p.accounts.get(tx.From); account != nil && tx.Nonce < account.getNonce() {
+		status.State = TxStatePromoted
+	}
+
+	return status, true
+}
+
+// GetReplacementFees returns the min fee cap and tip cap of a transaction replacing
+// the one of the account with the given nonce, false if there's no such transaction in the pool
+func (p *TxPool) GetReplacementFees(addr types.Address, nonce uint64) (gasFeeCap, gasTipCap *big.Int, ok bool) {
+	account := p.accounts.get(addr)
+	if account == nil {
+		return nil, nil, false
+	}
+
+	account.nonceToTx.lock()
+	tx := account.nonceToTx.get(nonce)
+	account.nonceToTx.unlock()
+
+	if tx == nil {
+		return nil, nil, false
+	}
+
+	gasFeeCap, gasTipCap = replacementFees(tx)
+
+	return gasFeeCap, gasTipCap, true
+}
+
 // GetBaseFee returns current base fee
 func (p *TxPool) GetBaseFee() uint64 {
 	return atomic.LoadUint64(&p.baseFee)
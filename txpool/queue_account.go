@@ -2,6 +2,7 @@ package txpool
 
 import (
 	"container/heap"
+	"sort"
 	"sync"
 	"sync/atomic"
 
@@ -71,6 +72,26 @@ func (q *accountQueue) clear() (removed []*types.Transaction) {
 	return
 }
 
+// removeFunc removes all transactions from the queue for which remove returns true.
+func (q *accountQueue) removeFunc(remove func(*types.Transaction) bool) (removed []*types.Transaction) {
+	kept := make(minNonceQueue, 0, q.length())
+
+	for _, tx := range q.queue {
+		if remove(tx) {
+			removed = append(removed, tx)
+		} else {
+			kept = append(kept, tx)
+		}
+	}
+
+	if len(removed) > 0 {
+		q.queue = kept
+		heap.Init(&q.queue)
+	}
+
+	return
+}
+
 // push pushes the given transactions onto the queue.
 func (q *accountQueue) push(tx *types.Transaction) {
 	heap.Push(&q.queue, tx)
@@ -99,6 +120,16 @@ func (q *accountQueue) pop() *types.Transaction {
 	return transaction
 }
 
+// sorted returns a copy of the queue transactions sorted by nonce.
+func (q *accountQueue) sorted() []*types.Transaction {
+	txs := make(minNonceQueue, len(q.queue))
+	copy(txs, q.queue)
+
+	sort.Sort(&txs)
+
+	return txs
+}
+
 // length returns the number of transactions in the queue.
 func (q *accountQueue) length() uint64 {
 	return uint64(q.queue.Len())
@@ -11,12 +11,28 @@ type pricedQueue struct {
 	queue *maxPriceQueue
 }
 
-// newPricesQueue creates the priced queue with initial transactions and base fee
-func newPricesQueue(baseFee uint64, initialTxs []*types.Transaction) *pricedQueue {
+// newPricesQueue creates the priced queue with initial transactions and base fee.
+// The transactions of the priority accounts are sorted before the rest
+func newPricesQueue(
+	baseFee uint64,
+	initialTxs []*types.Transaction,
+	priorityAccounts map[types.Address]struct{},
+) *pricedQueue {
+	return newOrderedQueue(&priceOrdering{baseFee: new(big.Int).SetUint64(baseFee)}, initialTxs, priorityAccounts)
+}
+
+// newOrderedQueue creates the queue with initial transactions sorted by the given policy.
+// The transactions of the priority accounts are sorted before the rest
+func newOrderedQueue(
+	policy orderingPolicy,
+	initialTxs []*types.Transaction,
+	priorityAccounts map[types.Address]struct{},
+) *pricedQueue {
 	q := &pricedQueue{
 		queue: &maxPriceQueue{
-			baseFee: new(big.Int).SetUint64(baseFee),
-			txs:     initialTxs,
+			policy:   policy,
+			txs:      initialTxs,
+			priority: priorityAccounts,
 		},
 	}
 
@@ -50,10 +66,11 @@ func (q *pricedQueue) length() int {
 	return q.queue.Len()
 }
 
-// transactions sorted by gas price (descending)
+// transactions sorted by priority and the ordering policy (gas price by default)
 type maxPriceQueue struct {
-	baseFee *big.Int
-	txs     []*types.Transaction
+	policy   orderingPolicy
+	txs      []*types.Transaction
+	priority map[types.Address]struct{}
 }
 
 /* Queue methods required by the heap interface */
@@ -92,17 +109,19 @@ func (q *maxPriceQueue) Pop() interface{} {
 	return x
 }
 
-// @see https://github.com/etclabscore/core-geth/blob/4e2b0e37f89515a4e7b6bafaa40910a296cb38c0/core/txpool/list.go#L458
-// for details why is something implemented like it is
 func (q *maxPriceQueue) Less(i, j int) bool {
-	switch cmp(q.txs[i], q.txs[j], q.baseFee) {
-	case -1:
-		return false
-	case 1:
-		return true
-	default:
-		return q.txs[i].Nonce < q.txs[j].Nonce
+	if iPriority, jPriority := q.isPriority(q.txs[i]), q.isPriority(q.txs[j]); iPriority != jPriority {
+		return iPriority
 	}
+
+	return q.policy.less(q.txs[i], q.txs[j])
+}
+
+// isPriority returns true if the transaction is sent by a priority account
+func (q *maxPriceQueue) isPriority(tx *types.Transaction) bool {
+	_, ok := q.priority[tx.From]
+
+	return ok
 }
 
 func cmp(a, b *types.Transaction, baseFee *big.Int) int {
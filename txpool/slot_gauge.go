@@ -42,7 +42,12 @@ func (g *slotGauge) highPressure() bool {
 
 // free slots returns how many slots are currently available
 func (g *slotGauge) freeSlots() uint64 {
-	return g.max - g.read()
+	// the priority accounts may fill the pool beyond its limit
+	if height := g.read(); height < g.max {
+		return g.max - height
+	}
+
+	return 0
 }
 
 // slotsRequired calculates the number of slots required for given transaction(s).
@@ -0,0 +1,123 @@
+package txpool
+
+import (
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// maxTxHistory is the max number of the transactions whose demotions and drop reasons are kept
+const maxTxHistory = 4096
+
+// DropReason is the reason a transaction was removed from the pool without being included in a block
+type DropReason string
+
+const (
+	// DropReasonFailed is set for the transaction dropped by the block builder due to an unrecoverable error
+	DropReasonFailed DropReason = "unrecoverable execution error"
+	// DropReasonDemotions is set for the transaction dropped after too many demotions of its account
+	DropReasonDemotions DropReason = "account demoted too many times"
+	// DropReasonSkips is set for the transaction dropped after its account was skipped too many times
+	DropReasonSkips DropReason = "account skipped too many times"
+	// DropReasonAccountDropped is set for the transactions dropped along with a lower nonce one of the account
+	DropReasonAccountDropped DropReason = "lower nonce transaction of the account dropped"
+	// DropReasonExpired is set for the transaction which stayed in the pool longer than its lifetime
+	DropReasonExpired DropReason = "expired"
+	// DropReasonNonceGap is set for the enqueued transaction pruned due to a nonce gap
+	DropReasonNonceGap DropReason = "pruned due to a nonce gap"
+	// DropReasonReplaced is set for the transaction replaced by a pricier one with the same nonce
+	DropReasonReplaced DropReason = "replaced"
+)
+
+// TxState is the state of a transaction in the pool
+type TxState string
+
+const (
+	// TxStatePromoted is the state of the transaction ready for execution
+	TxStatePromoted TxState = "promoted"
+	// TxStateEnqueued is the state of the transaction waiting for a lower nonce one
+	TxStateEnqueued TxState = "enqueued"
+	// TxStateDropped is the state of the transaction removed from the pool
+	TxStateDropped TxState = "dropped"
+)
+
+// TxStatus is the status of a transaction known to the pool
+type TxStatus struct {
+	State TxState
+	// Demotions is the number of times the transaction was demoted by the block builders
+	Demotions uint64
+	// DropReason is the reason the transaction was dropped, empty if it's still in the pool
+	DropReason DropReason
+}
+
+// txRecord is what the history keeps about a transaction
+type txRecord struct {
+	demotions  uint64
+	dropReason DropReason
+}
+
+// txHistory is the bounded lookup of the demotions and the drop reasons of the transactions,
+// the oldest records are forgotten once it's full [thread-safe]
+type txHistory struct {
+	sync.Mutex
+
+	records map[types.Hash]*txRecord
+	// order of the records, from the oldest
+	order []types.Hash
+}
+
+func newTxHistory() *txHistory {
+	return &txHistory{
+		records: make(map[types.Hash]*txRecord),
+	}
+}
+
+// demoted increments the demotions of the transaction
+func (h *txHistory) demoted(hash types.Hash) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.record(hash).demotions++
+}
+
+// dropped sets the drop reason of the transactions
+func (h *txHistory) dropped(reason DropReason, hashes ...types.Hash) {
+	h.Lock()
+	defer h.Unlock()
+
+	for _, hash := range hashes {
+		h.record(hash).dropReason = reason
+	}
+}
+
+// get returns the record of the transaction with the given hash
+func (h *txHistory) get(hash types.Hash) (txRecord, bool) {
+	h.Lock()
+	defer h.Unlock()
+
+	record, ok := h.records[hash]
+	if !ok {
+		return txRecord{}, false
+	}
+
+	return *record, true
+}
+
+// record returns the record of the transaction, a new one is added
+// in place of the oldest one if the history is full
+func (h *txHistory) record(hash types.Hash) *txRecord {
+	if record, ok := h.records[hash]; ok {
+		return record
+	}
+
+	if len(h.order) >= maxTxHistory {
+		delete(h.records, h.order[0])
+		h.order = h.order[1:]
+	}
+
+	record := &txRecord{}
+	h.records[hash] = record
+	h.order = append(h.order, hash)
+
+	return record
+}
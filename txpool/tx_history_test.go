@@ -0,0 +1,103 @@
+package txpool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxHistory_Bounded(t *testing.T) {
+	t.Parallel()
+
+	history := newTxHistory()
+
+	for i := 0; i <= maxTxHistory; i++ {
+		history.dropped(DropReasonExpired, types.BytesToHash(big.NewInt(int64(i)).Bytes()))
+	}
+
+	assert.Len(t, history.records, maxTxHistory)
+	assert.Len(t, history.order, maxTxHistory)
+
+	// the oldest record is forgotten
+	_, ok := history.get(types.BytesToHash(big.NewInt(0).Bytes()))
+	assert.False(t, ok)
+
+	record, ok := history.get(types.BytesToHash(big.NewInt(maxTxHistory).Bytes()))
+	assert.True(t, ok)
+	assert.Equal(t, DropReasonExpired, record.dropReason)
+}
+
+func TestGetTxStatus(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	promoted, enqueued := newTx(addr1, 0, 1), newTx(addr1, 2, 1)
+
+	require.NoError(t, pool.addTx(local, promoted))
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+	require.NoError(t, pool.addTx(local, enqueued))
+
+	status, ok := pool.GetTxStatus(promoted.Hash)
+	require.True(t, ok)
+	assert.Equal(t, &TxStatus{State: TxStatePromoted}, status)
+
+	status, ok = pool.GetTxStatus(enqueued.Hash)
+	require.True(t, ok)
+	assert.Equal(t, &TxStatus{State: TxStateEnqueued}, status)
+
+	pool.Demote(promoted)
+
+	status, ok = pool.GetTxStatus(promoted.Hash)
+	require.True(t, ok)
+	assert.Equal(t, &TxStatus{State: TxStatePromoted, Demotions: 1}, status)
+
+	pool.Drop(promoted)
+
+	status, ok = pool.GetTxStatus(promoted.Hash)
+	require.True(t, ok)
+	assert.Equal(t, &TxStatus{State: TxStateDropped, Demotions: 1, DropReason: DropReasonFailed}, status)
+
+	status, ok = pool.GetTxStatus(enqueued.Hash)
+	require.True(t, ok)
+	assert.Equal(t, &TxStatus{State: TxStateDropped, DropReason: DropReasonAccountDropped}, status)
+
+	_, ok = pool.GetTxStatus(types.StringToHash("1"))
+	assert.False(t, ok)
+}
+
+func TestGetReplacementFees(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	tx := newTx(addr1, 0, 1)
+	require.NoError(t, pool.addTx(local, tx))
+
+	_, _, ok := pool.GetReplacementFees(addr1, 1)
+	assert.False(t, ok)
+
+	gasFeeCap, gasTipCap, ok := pool.GetReplacementFees(addr1, 0)
+	require.True(t, ok)
+	assert.Equal(t, new(big.Int).SetUint64(defaultPriceLimit+1), gasFeeCap)
+	assert.Equal(t, new(big.Int).SetUint64(defaultPriceLimit+1), gasTipCap)
+
+	// the same price doesn't replace the transaction
+	underpriced := newTx(addr1, 0, 1)
+	assert.ErrorIs(t, pool.addTx(local, underpriced), ErrReplacementUnderpriced)
+
+	replacement := newTx(addr1, 0, 1)
+	replacement.GasPrice = gasFeeCap
+	require.NoError(t, pool.addTx(local, replacement))
+
+	status, ok := pool.GetTxStatus(tx.Hash)
+	require.True(t, ok)
+	assert.Equal(t, &TxStatus{State: TxStateDropped, DropReason: DropReasonReplaced}, status)
+}
@@ -16,7 +16,6 @@ import (
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/armon/go-metrics"
-	"github.com/golang/protobuf/ptypes/any"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"google.golang.org/grpc"
@@ -36,6 +35,9 @@ const (
 
 	pruningCooldown = 5000 * time.Millisecond
 
+	// maximum interval between the evictions of the expired transactions
+	maxEvictionInterval = time.Minute
+
 	// txPoolMetrics is a prefix used for txpool-related metrics
 	txPoolMetrics = "txpool"
 )
@@ -61,8 +63,10 @@ var (
 	ErrTipVeryHigh             = errors.New("max priority fee per gas higher than 2^256-1")
 	ErrFeeCapVeryHigh          = errors.New("max fee per gas higher than 2^256-1")
 	ErrNonceExistsInPool       = errors.New("tx with the same nonce is already present")
-	ErrReplacementUnderpriced  = errors.New("replacement tx underpriced")
+	ErrReplacementUnderpriced  = fmt.Errorf("replacement %w", ErrUnderpriced)
 	ErrDynamicTxNotAllowed     = errors.New("dynamic tx not allowed currently")
+	ErrInvalidSponsor          = errors.New("invalid sponsor")
+	ErrSponsorUnderfunded      = errors.New("insufficient sponsor funds for gas * price")
 )
 
 // indicates origin of a transaction
@@ -95,6 +99,7 @@ type store interface {
 
 type signer interface {
 	Sender(tx *types.Transaction) (types.Address, error)
+	Sponsor(tx *types.Transaction) (types.Address, error)
 }
 
 type Config struct {
@@ -102,6 +107,27 @@ type Config struct {
 	MaxSlots           uint64
 	MaxAccountEnqueued uint64
 	ChainID            *big.Int
+
+	// JournalPath is the file of the local transactions journal, the journal is disabled if it's empty
+	JournalPath string
+	// JournalRotation is the interval between the journal rotations
+	JournalRotation time.Duration
+
+	// Lifetime is the max time an enqueued transaction stays in the pool, the eviction is disabled if it's 0
+	Lifetime time.Duration
+	// EvictPromoted makes the promoted transactions be evicted after the lifetime as well
+	EvictPromoted bool
+
+	// PriorityAccounts are the accounts whose transactions bypass the price limit,
+	// are never pruned or evicted and are executed first
+	PriorityAccounts []types.Address
+
+	// Ordering is the policy the executable transactions are handed to the block builders by,
+	// the transactions are ordered by price if it's empty
+	Ordering OrderingType
+	// MaxSenderTxs is the max number of transactions of a single (non priority) account
+	// included in a block, there's no limit if it's 0
+	MaxSenderTxs uint64
 }
 
 /* All requests are passed to the main loop
@@ -150,15 +176,29 @@ type TxPool struct {
 	// map of all accounts registered by the pool
 	accounts accountsMap
 
-	// all the primaries sorted by max gas price
+	// all the primaries sorted by the ordering policy
 	executables *pricedQueue
 
+	// ordering policy of the executables
+	ordering orderingFactory
+
+	// max number of transactions of an account popped since the last Prepare,
+	// and the number of the transactions popped for each account
+	maxSenderTxs uint64
+	senderTxs    map[types.Address]uint64
+
 	// lookup map keeping track of all
 	// transactions present in the pool
 	index lookupMap
 
 	// networking stack
-	topic *network.Topic
+	topic         *network.Topic
+	announceTopic *network.Topic
+	network       *network.Server
+	peerService   *txPoolPeerService
+
+	// hashes of the local transactions waiting to be announced
+	announceCh chan types.Hash
 
 	// gauge for measuring pool capacity
 	gauge slotGauge
@@ -194,6 +234,23 @@ type TxPool struct {
 
 	// chain id
 	chainID *big.Int
+
+	// journal of the local transactions, nil if disabled
+	journal         *journal
+	journalRotation time.Duration
+
+	// lifetime of the transactions, the expired ones are evicted periodically
+	lifetime      time.Duration
+	evictPromoted bool
+
+	// set of the accounts whose transactions are prioritized
+	priorityAccounts map[types.Address]struct{}
+
+	// bundles of transactions applied as a unit by the block builders
+	bundles *bundleQueue
+
+	// demotions and drop reasons of the recent transactions
+	history *txHistory
 }
 
 // NewTxPool returns a new pool for processing incoming transactions.
@@ -209,12 +266,20 @@ func NewTxPool(
 		logger:      logger.Named("txpool"),
 		forks:       forks,
 		store:       store,
-		executables: newPricesQueue(0, nil),
+		executables: newPricesQueue(0, nil, nil),
 		accounts:    accountsMap{maxEnqueuedLimit: config.MaxAccountEnqueued},
-		index:       lookupMap{all: make(map[types.Hash]*types.Transaction)},
+		index:       lookupMap{all: make(map[types.Hash]*types.Transaction), arrivals: make(map[types.Hash]time.Time)},
 		gauge:       slotGauge{height: 0, max: config.MaxSlots},
 		priceLimit:  config.PriceLimit,
 		chainID:     config.ChainID,
+		bundles:     &bundleQueue{},
+		history:     newTxHistory(),
+
+		maxSenderTxs: config.MaxSenderTxs,
+		senderTxs:    make(map[types.Address]uint64),
+
+		lifetime:      config.Lifetime,
+		evictPromoted: config.EvictPromoted,
 
 		//	main loop channels
 		promoteReqCh: make(chan promoteRequest),
@@ -222,11 +287,40 @@ func NewTxPool(
 		shutdownCh:   make(chan struct{}),
 	}
 
+	ordering := config.Ordering
+	if ordering == "" {
+		ordering = PriceOrdering
+	}
+
+	if err := ValidateOrdering(ordering); err != nil {
+		return nil, err
+	}
+
+	pool.ordering = orderingPolicies[ordering]
+
+	if len(config.PriorityAccounts) > 0 {
+		pool.priorityAccounts = make(map[types.Address]struct{}, len(config.PriorityAccounts))
+
+		for _, addr := range config.PriorityAccounts {
+			pool.priorityAccounts[addr] = struct{}{}
+		}
+	}
+
 	// Attach the event manager
 	pool.eventManager = newEventManager(pool.logger)
 
+	if config.JournalPath != "" {
+		pool.journal = newJournal(config.JournalPath)
+
+		pool.journalRotation = config.JournalRotation
+		if pool.journalRotation == 0 {
+			pool.journalRotation = defaultJournalRotation
+		}
+	}
+
 	if network != nil {
-		// subscribe to the gossip protocol
+		// subscribe to the gossip protocol, the old topic is kept
+		// for the peers which don't support the announcements
 		topic, err := network.NewTopic(topicNameV1, &proto.Txn{})
 		if err != nil {
 			return nil, err
@@ -236,7 +330,21 @@ func NewTxPool(
 			return nil, fmt.Errorf("unable to subscribe to gossip topic, %w", subscribeErr)
 		}
 
+		announceTopic, err := network.NewTopic(topicNameV2, &proto.TxnHashes{})
+		if err != nil {
+			return nil, err
+		}
+
+		if subscribeErr := announceTopic.SubscribeFromRelay(pool.addGossipHashes); subscribeErr != nil {
+			return nil, fmt.Errorf("unable to subscribe to announcement topic, %w", subscribeErr)
+		}
+
 		pool.topic = topic
+		pool.announceTopic = announceTopic
+		pool.network = network
+		pool.announceCh = make(chan types.Hash, maxAnnounceHashes)
+
+		pool.setupPeerService(network)
 	}
 
 	if grpcServer != nil {
@@ -285,12 +393,100 @@ func (p *TxPool) Start() {
 			}
 		}
 	}()
+
+	//	run the handler for the announcements of the local txs
+	if p.announceTopic != nil {
+		go p.runAnnouncer()
+	}
+
+	//	run the handler for the eviction of the expired txs
+	if p.lifetime > 0 {
+		go func() {
+			interval := p.lifetime
+			if interval > maxEvictionInterval {
+				interval = maxEvictionInterval
+			}
+
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-p.shutdownCh:
+					return
+				case now := <-ticker.C:
+					p.evictExpired(now)
+				}
+			}
+		}()
+	}
+
+	// the journal is replayed once the pool is able to handle the promotions
+	if p.journal != nil {
+		p.loadJournal()
+	}
 }
 
 // Close shuts down the pool's main loop.
 func (p *TxPool) Close() {
 	p.eventManager.Close()
 	close(p.shutdownCh)
+
+	if p.journal != nil {
+		if err := p.journal.close(); err != nil {
+			p.logger.Error("failed to close the journal", "err", err)
+		}
+	}
+
+	if p.peerService != nil {
+		if err := p.peerService.stream.Close(); err != nil {
+			p.logger.Error("failed to close the peer service stream", "err", err)
+		}
+	}
+}
+
+// loadJournal replays the local transactions of the journal
+// and runs the handler for the periodic journal rotation
+func (p *TxPool) loadJournal() {
+	total, rejected, err := p.journal.load(p.AddTx)
+	if err != nil {
+		p.logger.Warn("failed to load the journal", "err", err)
+	}
+
+	p.logger.Info("loaded the journal", "transactions", total, "rejected", rejected)
+
+	// drop the rejected transactions and start writing the new ones
+	p.rotateJournal()
+
+	go func() {
+		ticker := time.NewTicker(p.journalRotation)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-p.shutdownCh:
+				return
+			case <-ticker.C:
+				p.rotateJournal()
+			}
+		}
+	}()
+}
+
+// rotateJournal rewrites the journal with the local transactions which are still in the pool
+func (p *TxPool) rotateJournal() {
+	kept, err := p.journal.rotate(func(tx *types.Transaction) bool {
+		_, ok := p.index.get(tx.Hash)
+
+		return ok
+	})
+	if err != nil {
+		p.logger.Error("failed to rotate the journal", "err", err)
+
+		return
+	}
+
+	p.logger.Debug("rotated the journal", "transactions", kept)
 }
 
 // SetSigner sets the signer the pool will use
@@ -313,18 +509,16 @@ func (p *TxPool) AddTx(tx *types.Transaction) error {
 		return err
 	}
 
-	// broadcast the transaction only if a topic
-	// subscription is present
-	if p.topic != nil {
-		tx := &proto.Txn{
-			Raw: &any.Any{
-				Value: tx.MarshalRLP(),
-			},
+	if p.journal != nil {
+		if err := p.journal.insert(tx); err != nil {
+			p.logger.Error("failed to journal tx", "err", err, "hash", tx.Hash.String())
 		}
+	}
 
-		if err := p.topic.Publish(tx); err != nil {
-			p.logger.Error("failed to topic tx", "err", err)
-		}
+	// gossip the transaction only if a topic
+	// subscription is present
+	if p.topic != nil {
+		p.gossip(tx)
 	}
 
 	return nil
@@ -336,8 +530,12 @@ func (p *TxPool) Prepare() {
 	// fetch primary from each account
 	primaries := p.accounts.getPrimaries()
 
-	// create new executables queue with base fee and initial transactions (primaries)
-	p.executables = newPricesQueue(p.GetBaseFee(), primaries)
+	// create new executables queue with the ordering policy and initial transactions (primaries)
+	policy := p.ordering(new(big.Int).SetUint64(p.GetBaseFee()), p.index.arrival)
+	p.executables = newOrderedQueue(policy, primaries, p.priorityAccounts)
+
+	// start counting the transactions included for each account from scratch
+	p.senderTxs = make(map[types.Address]uint64)
 }
 
 // Peek returns the best-price selected
@@ -383,6 +581,16 @@ func (p *TxPool) Pop(tx *types.Transaction) {
 	// update metrics
 	p.updatePending(-1)
 
+	// the next transaction of an account which reached the cap
+	// waits for the following block
+	if p.maxSenderTxs > 0 && !p.isPriorityAccount(tx.From) {
+		p.senderTxs[tx.From]++
+
+		if p.senderTxs[tx.From] >= p.maxSenderTxs {
+			return
+		}
+	}
+
 	// update executables
 	if tx := account.promoted.peek(); tx != nil {
 		p.executables.push(tx)
@@ -392,6 +600,12 @@ func (p *TxPool) Pop(tx *types.Transaction) {
 // Drop clears the entire account associated with the given transaction
 // and reverts its next (expected) nonce.
 func (p *TxPool) Drop(tx *types.Transaction) {
+	p.dropWithReason(tx, DropReasonFailed)
+}
+
+// dropWithReason drops the account of the transaction, the reason is recorded
+// for the transaction while the rest of the account's ones are dropped along with it
+func (p *TxPool) dropWithReason(tx *types.Transaction, reason DropReason) {
 	// fetch associated account
 	account := p.accounts.get(tx.From)
 
@@ -413,6 +627,8 @@ func (p *TxPool) Drop(tx *types.Transaction) {
 		p.index.remove(txs...)
 		p.gauge.decrease(slotsRequired(txs...))
 
+		p.history.dropped(DropReasonAccountDropped, toHash(txs...)...)
+
 		// increase counter
 		droppedCount += len(txs)
 	}
@@ -435,6 +651,8 @@ func (p *TxPool) Drop(tx *types.Transaction) {
 	dropped = account.enqueued.clear()
 	clearAccountQueue(dropped)
 
+	p.history.dropped(reason, tx.Hash)
+
 	p.eventManager.signalEvent(proto.EventType_DROPPED, tx.Hash)
 
 	if p.logger.IsDebug() {
@@ -459,7 +677,7 @@ func (p *TxPool) Demote(tx *types.Transaction) {
 			)
 		}
 
-		p.Drop(tx)
+		p.dropWithReason(tx, DropReasonDemotions)
 
 		// reset the demotions counter
 		account.resetDemotions()
@@ -468,6 +686,7 @@ func (p *TxPool) Demote(tx *types.Transaction) {
 	}
 
 	account.incrementDemotions()
+	p.history.demoted(tx.Hash)
 
 	p.eventManager.signalEvent(proto.EventType_DEMOTED, tx.Hash)
 }
@@ -530,9 +749,10 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 		}
 	}
 
-	// update base fee
+	// update base fee and drop the bundles expired with the new head
 	if ln := len(event.NewChain); ln > 0 {
 		p.SetBaseFee(event.NewChain[ln-1])
+		p.pruneBundles(event.NewChain[ln-1])
 	}
 
 	// reset accounts with the new state
@@ -554,6 +774,14 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		return ErrInvalidTxType
 	}
 
+	// Reject sponsored tx if the sponsored transactions fork is not enabled for the next block
+	if tx.Type == types.SponsoredTx &&
+		!forkmanager.GetInstance().IsForkEnabled(chain.SponsoredTx, p.store.Header().Number+1) {
+		metrics.IncrCounter([]string{txPoolMetrics, "invalid_tx_type"}, 1)
+
+		return ErrInvalidTxType
+	}
+
 	// Check the transaction size to overcome DOS Attacks
 	if uint64(len(tx.MarshalRLP())) > txMaxSize {
 		metrics.IncrCounter([]string{txPoolMetrics, "oversized_data_txs"}, 1)
@@ -592,6 +820,15 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		tx.From = from
 	}
 
+	// Check if the sponsored transaction is signed by its sponsor
+	if tx.IsSponsored() {
+		if sponsor, err := p.signer.Sponsor(tx); err != nil || sponsor != tx.Sponsor {
+			metrics.IncrCounter([]string{txPoolMetrics, "invalid_sponsor_txs"}, 1)
+
+			return ErrInvalidSponsor
+		}
+	}
+
 	// Check if transaction can deploy smart contract
 	if tx.IsContractCreation() && p.forks.EIP158 && len(tx.Input) > state.TxPoolMaxInitCodeSize {
 		metrics.IncrCounter([]string{txPoolMetrics, "contract_deploy_too_large_txs"}, 1)
@@ -654,8 +891,16 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 			return ErrUnderpriced
 		}
 	} else {
+		// Reject access list tx if berlin hardfork is not enabled
+		if tx.Type == types.AccessListTx && !p.forks.Berlin {
+			metrics.IncrCounter([]string{txPoolMetrics, "invalid_tx_type"}, 1)
+
+			return ErrInvalidTxType
+		}
+
 		// Legacy approach to check if the given tx is not underpriced
-		if tx.GetGasPrice(baseFee).Cmp(big.NewInt(0).SetUint64(p.priceLimit)) < 0 {
+		if !p.isPriorityAccount(tx.From) &&
+			tx.GetGasPrice(baseFee).Cmp(big.NewInt(0).SetUint64(p.priceLimit)) < 0 {
 			metrics.IncrCounter([]string{txPoolMetrics, "underpriced_tx"}, 1)
 
 			return ErrUnderpriced
@@ -676,15 +921,37 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		return ErrInvalidAccountState
 	}
 
-	// Check if the sender has enough funds to execute the transaction
-	if accountBalance.Cmp(tx.Cost()) < 0 {
+	// Check if the sender has enough funds to execute the transaction,
+	// the sender of the sponsored transaction pays only the value
+	cost := tx.Cost()
+	if tx.IsSponsored() {
+		cost = tx.Value
+	}
+
+	if accountBalance.Cmp(cost) < 0 {
 		metrics.IncrCounter([]string{txPoolMetrics, "insufficient_funds_tx"}, 1)
 
 		return ErrInsufficientFunds
 	}
 
+	// Check if the sponsor has enough funds to pay the gas
+	if tx.IsSponsored() {
+		sponsorBalance, err := p.store.GetBalance(stateRoot, tx.Sponsor)
+		if err != nil {
+			metrics.IncrCounter([]string{txPoolMetrics, "invalid_account_state_tx"}, 1)
+
+			return ErrInvalidAccountState
+		}
+
+		if sponsorBalance.Cmp(tx.GasCost()) < 0 {
+			metrics.IncrCounter([]string{txPoolMetrics, "insufficient_sponsor_funds_tx"}, 1)
+
+			return ErrSponsorUnderfunded
+		}
+	}
+
 	// Make sure the transaction has more gas than the basic transaction fee
-	intrinsicGas, err := state.TransactionGasCost(tx, p.forks.Homestead, p.forks.Istanbul)
+	intrinsicGas, err := state.TransactionGasCost(tx, p.forks.Homestead, p.forks.Istanbul, p.forks.Shanghai)
 	if err != nil {
 		metrics.IncrCounter([]string{txPoolMetrics, "invalid_intrinsic_gas_tx"}, 1)
 
@@ -714,10 +981,17 @@ func (p *TxPool) signalPruning() {
 }
 
 func (p *TxPool) pruneAccountsWithNonceHoles() {
+	var prunedAccounts, prunedTxs int
+
 	p.accounts.Range(
-		func(_, value interface{}) bool {
+		func(key, value interface{}) bool {
+			address, _ := key.(types.Address)
 			account, _ := value.(*account)
 
+			if p.isPriorityAccount(address) {
+				return true
+			}
+
 			account.enqueued.lock(true)
 			defer account.enqueued.unlock()
 
@@ -740,6 +1014,50 @@ func (p *TxPool) pruneAccountsWithNonceHoles() {
 			p.index.remove(removed...)
 			p.gauge.decrease(slotsRequired(removed...))
 
+			p.history.dropped(DropReasonNonceGap, toHash(removed...)...)
+
+			prunedAccounts++
+			prunedTxs += len(removed)
+
+			return true
+		},
+	)
+
+	recordPruning(prunedAccounts, prunedTxs)
+}
+
+// evictExpired drops the enqueued transactions which have been in the pool longer than the lifetime.
+// If evictPromoted is set, the accounts whose first promoted transaction expired are dropped as well
+func (p *TxPool) evictExpired(now time.Time) {
+	deadline := now.Add(-p.lifetime)
+
+	p.accounts.Range(
+		func(key, value interface{}) bool {
+			address, _ := key.(types.Address)
+			account, _ := value.(*account)
+
+			if p.isPriorityAccount(address) {
+				return true
+			}
+
+			evicted, expiredPromoted := account.evictExpired(deadline)
+
+			if len(evicted) > 0 {
+				p.index.remove(evicted...)
+				p.gauge.decrease(slotsRequired(evicted...))
+
+				p.history.dropped(DropReasonExpired, toHash(evicted...)...)
+				p.eventManager.signalEvent(proto.EventType_DROPPED, toHash(evicted...)...)
+
+				metrics.IncrCounter([]string{txPoolMetrics, "expired_enqueued_tx"}, float32(len(evicted)))
+			}
+
+			if p.evictPromoted && expiredPromoted != nil {
+				p.dropWithReason(expiredPromoted, DropReasonExpired)
+
+				metrics.IncrCounter([]string{txPoolMetrics, "expired_promoted_tx"}, 1)
+			}
+
 			return true
 		},
 	)
@@ -750,6 +1068,14 @@ func (p *TxPool) pruneAccountsWithNonceHoles() {
 // successful, an account is created for this address
 // (only once) and an enqueueRequest is signaled.
 func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
+	err := p.insertTx(origin, tx)
+	recordAdmission(origin, err)
+
+	return err
+}
+
+// insertTx validates the transaction and adds it to the account's enqueued queue
+func (p *TxPool) insertTx(origin txOrigin, tx *types.Transaction) error {
 	if p.logger.IsDebug() {
 		p.logger.Debug("add tx", "origin", origin.String(), "hash", tx.Hash.String())
 	}
@@ -759,8 +1085,8 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 		return err
 	}
 
-	// add chainID to the tx - only dynamic fee tx
-	if tx.Type == types.DynamicFeeTx {
+	// add chainID to the tx - only typed txs
+	if tx.IsTyped() {
 		tx.ChainID = p.chainID
 	}
 
@@ -783,12 +1109,13 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 	}()
 
 	accountNonce := account.getNonce()
+	isPriority := p.isPriorityAccount(tx.From)
 
 	//	only accept transactions with expected nonce
 	if p.gauge.highPressure() {
 		p.signalPruning()
 
-		if tx.Nonce > accountNonce {
+		if tx.Nonce > accountNonce && !isPriority {
 			metrics.IncrCounter([]string{txPoolMetrics, "rejected_future_tx"}, 1)
 
 			return ErrRejectFutureTx
@@ -802,9 +1129,9 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 			metrics.IncrCounter([]string{txPoolMetrics, "already_known_tx"}, 1)
 
 			return ErrAlreadyKnown
-		} else if oldTxWithSameNonce.GasPrice.Cmp(tx.GasPrice) >= 0 {
-			// if tx with same nonce does exist and has same or better gas price -> return error
-			return ErrUnderpriced
+		} else if !paysReplacementFees(oldTxWithSameNonce, tx) {
+			// if tx with same nonce does exist and the new one doesn't pay more -> return error
+			return ErrReplacementUnderpriced
 		}
 
 		slotsFree += slotsRequired(oldTxWithSameNonce) // add old tx slots
@@ -819,8 +1146,8 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 		}
 	}
 
-	// check for overflow
-	if slotsRequired(tx) > slotsFree {
+	// check for overflow, the priority accounts are never rejected
+	if slotsRequired(tx) > slotsFree && !isPriority {
 		return ErrTxPoolOverflow
 	}
 
@@ -834,6 +1161,7 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 	if oldTxWithSameNonce != nil {
 		p.index.remove(oldTxWithSameNonce)
 		p.gauge.decrease(slotsRequired(oldTxWithSameNonce))
+		p.history.dropped(DropReasonReplaced, oldTxWithSameNonce.Hash)
 	} else {
 		metrics.SetGauge([]string{txPoolMetrics, "added_tx"}, 1)
 	}
@@ -846,6 +1174,21 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 	return nil
 }
 
+// replacementFees returns the min fee cap and tip cap of a transaction replacing the given one,
+// both have to be higher than the ones of the replaced transaction
+func replacementFees(old *types.Transaction) (gasFeeCap, gasTipCap *big.Int) {
+	one := big.NewInt(1)
+
+	return new(big.Int).Add(old.GetGasFeeCap(), one), new(big.Int).Add(old.GetGasTipCap(), one)
+}
+
+// paysReplacementFees returns true if the transaction pays enough to replace the old one
+func paysReplacementFees(old, tx *types.Transaction) bool {
+	gasFeeCap, gasTipCap := replacementFees(old)
+
+	return tx.GetGasFeeCap().Cmp(gasFeeCap) >= 0 && tx.GetGasTipCap().Cmp(gasTipCap) >= 0
+}
+
 func (p *TxPool) invokePromotion(tx *types.Transaction, callPromote bool) {
 	p.eventManager.signalEvent(proto.EventType_ADDED, tx.Hash)
 
@@ -881,6 +1224,7 @@ func (p *TxPool) handlePromoteRequest(req promoteRequest) {
 
 	// update metrics
 	p.updatePending(int64(len(promoted)))
+	p.recordPromotions(promoted)
 
 	p.eventManager.signalEvent(proto.EventType_PROMOTED, toHash(promoted...)...)
 }
@@ -915,18 +1259,27 @@ func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
 		return
 	}
 
-	// add tx
+	p.addGossipTxn(tx)
+}
+
+// addGossipTxn adds the transaction received from the network
+// to the pool and returns true if it wasn't known
+func (p *TxPool) addGossipTxn(tx *types.Transaction) bool {
 	if err := p.addTx(gossip, tx); err != nil {
 		if errors.Is(err, ErrAlreadyKnown) {
 			if p.logger.IsDebug() {
 				p.logger.Debug("rejecting known tx (gossip)", "hash", tx.Hash.String())
 			}
 
-			return
+			return false
 		}
 
 		p.logger.Error("failed to add broadcast tx", "err", err, "hash", tx.Hash.String())
+
+		return false
 	}
+
+	return true
 }
 
 // resetAccounts updates existing accounts with the new nonce and prunes stale transactions.
@@ -1015,7 +1368,7 @@ func (p *TxPool) updateAccountSkipsCounts(latestActiveAccounts map[types.Address
 			}
 
 			// account has been skipped too many times
-			p.Drop(firstTx)
+			p.dropWithReason(firstTx, DropReasonSkips)
 
 			account.resetSkips()
 
@@ -1039,11 +1392,28 @@ func (p *TxPool) getOrCreateAccount(newAddr types.Address) *account {
 	return p.accounts.initOnce(newAddr, stateNonce)
 }
 
+// isPriorityAccount returns true if the transactions of the account are prioritized
+func (p *TxPool) isPriorityAccount(addr types.Address) bool {
+	_, ok := p.priorityAccounts[addr]
+
+	return ok
+}
+
 // Length returns the total number of all promoted transactions.
 func (p *TxPool) Length() uint64 {
 	return p.accounts.promoted()
 }
 
+// SubscribeTxEvents registers a listener for the events of the given types.
+// The returned function cancels the subscription, which closes the events channel
+func (p *TxPool) SubscribeTxEvents(eventTypes ...proto.EventType) (<-chan *proto.TxPoolEvent, func()) {
+	subscription := p.eventManager.subscribe(eventTypes)
+
+	return subscription.subscriptionChannel, func() {
+		p.eventManager.cancelSubscription(subscription.subscriptionID)
+	}
+}
+
 // toHash returns the hash(es) of given transaction(s)
 func toHash(txs ...*types.Transaction) (hashes []types.Hash) {
 	for _, tx := range txs {
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/forkmanager"
 	"github.com/0xPolygon/polygon-edge/helper/tests"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
@@ -36,6 +38,7 @@ var (
 	forks = (&chain.Forks{
 		chain.Homestead: chain.NewFork(0),
 		chain.Istanbul:  chain.NewFork(0),
+		chain.Berlin:    chain.NewFork(0),
 		chain.London:    chain.NewFork(0),
 	})
 )
@@ -2207,6 +2210,60 @@ func Test_TxPool_validateTx(t *testing.T) {
 			ErrInvalidTxType,
 		)
 	})
+
+	t.Run("access list tx can pass", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool()
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx.Type = types.AccessListTx
+		tx.GasPrice = big.NewInt(1100)
+		tx.AccessList = types.TxAccessList{
+			{
+				Address:     addr1,
+				StorageKeys: []types.Hash{types.StringToHash("1")},
+			},
+		}
+
+		assert.NoError(t, pool.validateTx(signTx(tx)))
+	})
+
+	t.Run("access list tx (intrinsic gas too low)", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool()
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx.Type = types.AccessListTx
+		tx.GasPrice = big.NewInt(1100)
+		tx.Gas = state.TxGas
+		tx.AccessList = types.TxAccessList{
+			{
+				Address: addr1,
+			},
+		}
+
+		assert.ErrorIs(t,
+			pool.validateTx(signTx(tx)),
+			ErrIntrinsicGas,
+		)
+	})
+
+	t.Run("access list tx placed without berlin fork enabled", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool()
+		pool.forks.Berlin = false
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx.Type = types.AccessListTx
+		tx.GasPrice = big.NewInt(1100)
+
+		assert.ErrorIs(t,
+			pool.validateTx(signTx(tx)),
+			ErrInvalidTxType,
+		)
+	})
 }
 
 /* "Integrated" tests */
@@ -3565,3 +3622,373 @@ func BenchmarkAddTxTime(b *testing.B) {
 		}
 	})
 }
+
+func TestSubscribeTxEvents(t *testing.T) {
+	t.Parallel()
+
+	eoa1 := new(eoa).create(t)
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	pool.SetSigner(signerEIP155)
+
+	pool.Start()
+	defer pool.Close()
+
+	eventCh, cancel := pool.SubscribeTxEvents(proto.EventType_PROMOTED)
+
+	tx := eoa1.signTx(t, newTx(eoa1.Address, 0, 1), signerEIP155)
+	require.NoError(t, pool.addTx(local, tx))
+
+	select {
+	case event := <-eventCh:
+		assert.Equal(t, proto.EventType_PROMOTED, event.Type)
+		assert.Equal(t, tx.Hash.String(), event.TxHash)
+	case <-time.After(5 * time.Second):
+		t.Fatal("promoted event not received")
+	}
+
+	cancel()
+
+	_, more := <-eventCh
+	assert.False(t, more)
+}
+
+func TestJournal_ReplayOnStart(t *testing.T) {
+	t.Parallel()
+
+	eoa1 := new(eoa).create(t)
+
+	newJournaledPool := func(path string) *TxPool {
+		t.Helper()
+
+		pool, err := NewTxPool(
+			hclog.NewNullLogger(),
+			forks.At(0),
+			defaultMockStore{DefaultHeader: mockHeader},
+			nil,
+			nil,
+			&Config{
+				PriceLimit:         defaultPriceLimit,
+				MaxSlots:           defaultMaxSlots,
+				MaxAccountEnqueued: defaultMaxAccountEnqueued,
+				JournalPath:        path,
+			},
+		)
+		require.NoError(t, err)
+
+		pool.SetSigner(signerEIP155)
+
+		return pool
+	}
+
+	path := filepath.Join(t.TempDir(), "transactions.rlp")
+
+	pool := newJournaledPool(path)
+	pool.Start()
+
+	txs := []*types.Transaction{
+		eoa1.signTx(t, newTx(eoa1.Address, 0, 1), signerEIP155),
+		eoa1.signTx(t, newTx(eoa1.Address, 1, 1), signerEIP155),
+	}
+
+	for _, tx := range txs {
+		require.NoError(t, pool.AddTx(tx))
+	}
+
+	// the gossiped transactions are not journaled
+	eoa2 := new(eoa).create(t)
+	gossipTx := eoa2.signTx(t, newTx(eoa2.Address, 0, 1), signerEIP155)
+	require.NoError(t, pool.addTx(gossip, gossipTx))
+
+	pool.Close()
+
+	// the local transactions are replayed after the restart
+	pool = newJournaledPool(path)
+	pool.Start()
+
+	defer pool.Close()
+
+	for _, tx := range txs {
+		_, ok := pool.index.get(tx.Hash)
+		assert.True(t, ok)
+	}
+
+	_, ok := pool.index.get(gossipTx.Hash)
+	assert.False(t, ok)
+}
+
+func TestEvictExpired(t *testing.T) {
+	t.Parallel()
+
+	const lifetime = time.Hour
+
+	cases := []struct {
+		name           string
+		evictPromoted  bool
+		expectedEvents int
+		expectedSlots  uint64
+		expectedNonce  uint64
+	}{
+		{"enqueued only", false, 2, 1, 1},
+		{"promoted as well", true, 3, 0, 0},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			pool, err := newTestPool()
+			require.NoError(t, err)
+			pool.SetSigner(&mockSigner{})
+
+			pool.lifetime = lifetime
+			pool.evictPromoted = c.evictPromoted
+
+			// promote the first tx and enqueue the ones with a nonce gap
+			require.NoError(t, pool.addTx(local, newTx(addr1, 0, 1)))
+			pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+			require.NoError(t, pool.addTx(local, newTx(addr1, 5, 1)))
+			require.NoError(t, pool.addTx(local, newTx(addr1, 6, 1)))
+
+			eventCh, cancel := pool.SubscribeTxEvents(proto.EventType_DROPPED)
+			defer cancel()
+
+			// nothing is expired yet
+			pool.evictExpired(time.Now())
+			assert.Equal(t, uint64(3), pool.gauge.read())
+
+			pool.evictExpired(time.Now().Add(2 * lifetime))
+
+			acc := pool.accounts.get(addr1)
+
+			assert.Equal(t, c.expectedSlots, pool.gauge.read())
+			assert.Equal(t, c.expectedNonce, acc.getNonce())
+			assert.Equal(t, uint64(0), acc.enqueued.length())
+			assert.Equal(t, c.expectedSlots, acc.promoted.length())
+			assert.Len(t, acc.nonceToTx.mapping, int(c.expectedSlots))
+			assert.Len(t, pool.index.all, int(c.expectedSlots))
+
+			// an event is signaled for each evicted enqueued tx and one for the dropped account
+			for i := 0; i < c.expectedEvents; i++ {
+				select {
+				case event := <-eventCh:
+					assert.Equal(t, proto.EventType_DROPPED, event.Type)
+				case <-time.After(5 * time.Second):
+					t.Fatal("dropped event not received")
+				}
+			}
+		})
+	}
+}
+
+func TestPriorityAccounts(t *testing.T) {
+	t.Parallel()
+
+	setupPool := func(t *testing.T) *TxPool {
+		t.Helper()
+
+		pool, err := newTestPool()
+		require.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		pool.priorityAccounts = map[types.Address]struct{}{addr1: {}}
+
+		return pool
+	}
+
+	t.Run("bypass the price limit", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t)
+		pool.priceLimit = 1000000
+
+		assert.NoError(t, pool.addTx(local, newTx(addr1, 0, 1)))
+		assert.ErrorIs(t, pool.addTx(local, newTx(addr2, 0, 1)), ErrUnderpriced)
+	})
+
+	t.Run("accepted when the pool is full", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t)
+
+		// fill the pool
+		pool.gauge.increase(defaultMaxSlots)
+
+		assert.ErrorIs(t, pool.addTx(local, newTx(addr2, 0, 1)), ErrTxPoolOverflow)
+		assert.ErrorIs(t, pool.addTx(local, newTx(addr2, 5, 1)), ErrRejectFutureTx)
+
+		assert.NoError(t, pool.addTx(local, newTx(addr1, 5, 1)))
+		assert.Equal(t, defaultMaxSlots+1, pool.gauge.read())
+		assert.Equal(t, uint64(0), pool.gauge.freeSlots())
+
+		// the enqueued txs with nonce holes are kept
+		pool.pruneAccountsWithNonceHoles()
+
+		assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+	})
+
+	t.Run("executed first", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t)
+
+		expensiveTx := newTx(addr2, 0, 1)
+		expensiveTx.GasPrice = new(big.Int).Mul(expensiveTx.GasPrice, big.NewInt(10))
+
+		for _, tx := range []*types.Transaction{expensiveTx, newTx(addr1, 0, 1)} {
+			require.NoError(t, pool.addTx(local, tx))
+			pool.handlePromoteRequest(<-pool.promoteReqCh)
+		}
+
+		pool.Prepare()
+
+		assert.Equal(t, addr1, pool.Peek().From)
+		assert.Equal(t, addr2, pool.Peek().From)
+	})
+}
+
+// balancesMockStore is the mock store with the balance of each account
+type balancesMockStore struct {
+	defaultMockStore
+
+	balances map[types.Address]*big.Int
+}
+
+func (m balancesMockStore) GetBalance(_ types.Hash, addr types.Address) (*big.Int, error) {
+	if balance, ok := m.balances[addr]; ok {
+		return balance, nil
+	}
+
+	return big.NewInt(0), nil
+}
+
+// sponsoredTxForkBlock is the block the sponsored transactions are enabled from in the tests
+const sponsoredTxForkBlock = 2
+
+var activateSponsoredTxForkOnce sync.Once
+
+// activateSponsoredTxFork enables the sponsored transactions from sponsoredTxForkBlock
+func activateSponsoredTxFork(t *testing.T) {
+	t.Helper()
+
+	activateSponsoredTxForkOnce.Do(func() {
+		fm := forkmanager.GetInstance()
+		fm.RegisterFork(chain.SponsoredTx, nil)
+		require.NoError(t, fm.ActivateFork(chain.SponsoredTx, sponsoredTxForkBlock))
+	})
+}
+
+func TestSponsoredTx(t *testing.T) {
+	t.Parallel()
+
+	activateSponsoredTxFork(t)
+
+	var (
+		sender  = new(eoa).create(t)
+		sponsor = new(eoa).create(t)
+		signer  = crypto.NewLondonSigner(100, true, signerEIP155)
+	)
+
+	// the sponsored transaction costs 1 (value) + validGasLimit * defaultPriceLimit (gas)
+	gasCost := new(big.Int).SetUint64(validGasLimit * defaultPriceLimit)
+
+	newSponsoredTx := func(t *testing.T, sponsorAddr types.Address) *types.Transaction {
+		t.Helper()
+
+		tx := newTx(types.ZeroAddress, 0, 1)
+		tx.Type = types.SponsoredTx
+		tx.ChainID = big.NewInt(100)
+		tx.Sponsor = sponsorAddr
+
+		tx = sender.signTx(t, tx, signer)
+
+		tx, err := signer.SignSponsorTx(tx, sponsor.PrivateKey)
+		require.NoError(t, err)
+
+		return tx
+	}
+
+	testCases := []struct {
+		name           string
+		senderBalance  *big.Int
+		sponsorBalance *big.Int
+		sponsor        types.Address
+		beforeFork     bool
+		expectedErr    error
+	}{
+		{
+			name:           "sponsor pays the gas",
+			senderBalance:  big.NewInt(1),
+			sponsorBalance: gasCost,
+			sponsor:        sponsor.Address,
+		},
+		{
+			name:           "not signed by the sponsor",
+			senderBalance:  big.NewInt(1),
+			sponsorBalance: gasCost,
+			sponsor:        addr1,
+			expectedErr:    ErrInvalidSponsor,
+		},
+		{
+			name:           "sponsor can't pay the gas",
+			senderBalance:  gasCost,
+			sponsorBalance: big.NewInt(1),
+			sponsor:        sponsor.Address,
+			expectedErr:    ErrSponsorUnderfunded,
+		},
+		{
+			name:           "sender can't pay the value",
+			senderBalance:  big.NewInt(0),
+			sponsorBalance: gasCost,
+			sponsor:        sponsor.Address,
+			expectedErr:    ErrInsufficientFunds,
+		},
+		{
+			name:           "fork is not enabled for the next block",
+			senderBalance:  big.NewInt(1),
+			sponsorBalance: gasCost,
+			sponsor:        sponsor.Address,
+			beforeFork:     true,
+			expectedErr:    ErrInvalidTxType,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// the next block is the fork block
+			header := mockHeader.Copy()
+			header.Number = sponsoredTxForkBlock - 1
+
+			if tc.beforeFork {
+				header.Number--
+			}
+
+			pool, err := newTestPool(balancesMockStore{
+				defaultMockStore: defaultMockStore{DefaultHeader: header},
+				balances: map[types.Address]*big.Int{
+					sender.Address:  tc.senderBalance,
+					sponsor.Address: tc.sponsorBalance,
+					addr1:           tc.sponsorBalance,
+				},
+			})
+			require.NoError(t, err)
+
+			pool.SetSigner(signer)
+
+			err = pool.validateTx(newSponsoredTx(t, tc.sponsor))
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -150,12 +150,28 @@ func TestRLPMarshall_And_Unmarshall_TypedTransaction(t *testing.T) {
 		V:         big.NewInt(25),
 		S:         big.NewInt(26),
 		R:         big.NewInt(27),
+		ChainID:   big.NewInt(100),
+		AccessList: TxAccessList{
+			{
+				Address:     addrTo,
+				StorageKeys: []Hash{StringToHash("1"), StringToHash("2")},
+			},
+			{
+				Address: addrFrom,
+			},
+		},
+		Sponsor:  StringToAddress("33"),
+		SponsorV: big.NewInt(1),
+		SponsorR: big.NewInt(28),
+		SponsorS: big.NewInt(29),
 	}
 
 	txTypes := []TxType{
 		StateTx,
 		LegacyTx,
+		AccessListTx,
 		DynamicFeeTx,
+		SponsoredTx,
 	}
 
 	for _, v := range txTypes {
@@ -171,6 +187,26 @@ func TestRLPMarshall_And_Unmarshall_TypedTransaction(t *testing.T) {
 			unmarshalledTx.ComputeHash(1)
 			assert.Equal(t, originalTx.Type, unmarshalledTx.Type)
 			assert.Equal(t, originalTx.Hash, unmarshalledTx.Hash)
+
+			if originalTx.IsTyped() {
+				assert.Equal(t, originalTx.ChainID, unmarshalledTx.ChainID)
+				assert.Len(t, unmarshalledTx.AccessList, 2)
+				assert.Equal(t, originalTx.AccessList[0], unmarshalledTx.AccessList[0])
+				assert.Equal(t, addrFrom, unmarshalledTx.AccessList[1].Address)
+				assert.Empty(t, unmarshalledTx.AccessList[1].StorageKeys)
+			} else {
+				assert.Nil(t, unmarshalledTx.AccessList)
+			}
+
+			if originalTx.IsSponsored() {
+				assert.Equal(t, originalTx.Sponsor, unmarshalledTx.Sponsor)
+				assert.Equal(t, originalTx.SponsorV, unmarshalledTx.SponsorV)
+				assert.Equal(t, originalTx.SponsorR, unmarshalledTx.SponsorR)
+				assert.Equal(t, originalTx.SponsorS, unmarshalledTx.SponsorS)
+			} else {
+				assert.Equal(t, ZeroAddress, unmarshalledTx.Sponsor)
+				assert.Nil(t, unmarshalledTx.SponsorV)
+			}
 		})
 	}
 }
@@ -181,11 +217,13 @@ func TestRLPMarshall_Unmarshall_Missing_Data(t *testing.T) {
 	txTypes := []TxType{
 		StateTx,
 		LegacyTx,
+		AccessListTx,
 		DynamicFeeTx,
 	}
 
 	for _, txType := range txTypes {
 		txType := txType
+		isTyped := txType == AccessListTx || txType == DynamicFeeTx
 		testTable := []struct {
 			name          string
 			expectedErr   bool
@@ -204,11 +242,11 @@ func TestRLPMarshall_Unmarshall_Missing_Data(t *testing.T) {
 				name:        fmt.Sprintf("[%s] Missing From", txType),
 				expectedErr: false,
 				omittedValues: map[string]bool{
-					"ChainID":    txType != DynamicFeeTx,
+					"ChainID":    !isTyped,
 					"GasTipCap":  txType != DynamicFeeTx,
 					"GasFeeCap":  txType != DynamicFeeTx,
 					"GasPrice":   txType == DynamicFeeTx,
-					"AccessList": txType != DynamicFeeTx,
+					"AccessList": !isTyped,
 					"From":       txType != StateTx,
 				},
 				fromAddrSet: txType == StateTx,
@@ -217,11 +255,11 @@ func TestRLPMarshall_Unmarshall_Missing_Data(t *testing.T) {
 				name:        fmt.Sprintf("[%s] Address set for state tx only", txType),
 				expectedErr: false,
 				omittedValues: map[string]bool{
-					"ChainID":    txType != DynamicFeeTx,
+					"ChainID":    !isTyped,
 					"GasTipCap":  txType != DynamicFeeTx,
 					"GasFeeCap":  txType != DynamicFeeTx,
 					"GasPrice":   txType == DynamicFeeTx,
-					"AccessList": txType != DynamicFeeTx,
+					"AccessList": !isTyped,
 					"From":       txType != StateTx,
 				},
 				fromAddrSet: txType == StateTx,
@@ -270,10 +308,18 @@ func TestRLPMarshall_And_Unmarshall_TxType(t *testing.T) {
 			name:   "LegacyTx",
 			txType: LegacyTx,
 		},
+		{
+			name:   "AccessListTx",
+			txType: AccessListTx,
+		},
 		{
 			name:   "DynamicFeeTx",
 			txType: DynamicFeeTx,
 		},
+		{
+			name:   "SponsoredTx",
+			txType: SponsoredTx,
+		},
 		{
 			name:        "undefined type",
 			txType:      TxType(0x09),
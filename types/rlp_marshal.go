@@ -174,6 +174,26 @@ func (l *Log) MarshalRLPWith(a *fastrlp.Arena) *fastrlp.Value {
 	return v
 }
 
+// MarshalRLPWith marshals the access list to RLP as the list of [address, [storageKeys...]] tuples
+func (al TxAccessList) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	for _, tuple := range al {
+		tupleVal := arena.NewArray()
+		tupleVal.Set(arena.NewCopyBytes(tuple.Address.Bytes()))
+
+		keys := arena.NewArray()
+		for _, key := range tuple.StorageKeys {
+			keys.Set(arena.NewCopyBytes(key.Bytes()))
+		}
+
+		tupleVal.Set(keys)
+		vv.Set(tupleVal)
+	}
+
+	return vv
+}
+
 func (t *Transaction) MarshalRLP() []byte {
 	return t.MarshalRLPTo(nil)
 }
@@ -193,7 +213,8 @@ func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 	vv := arena.NewArray()
 
 	// Check Transaction1559Payload there https://eips.ethereum.org/EIPS/eip-1559#specification
-	if t.Type == DynamicFeeTx {
+	// and AccessListTx payload there https://eips.ethereum.org/EIPS/eip-2930#specification
+	if t.IsTyped() {
 		vv.Set(arena.NewBigInt(t.ChainID))
 	}
 
@@ -220,12 +241,12 @@ func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 	vv.Set(arena.NewBigInt(t.Value))
 	vv.Set(arena.NewCopyBytes(t.Input))
 
-	// Specify access list as per spec.
-	// This is needed to have the same format as other EVM chains do.
-	// There is no access list feature here, so it is always empty just to be compatible.
-	// Check Transaction1559Payload there https://eips.ethereum.org/EIPS/eip-1559#specification
-	if t.Type == DynamicFeeTx {
-		vv.Set(arena.NewArray())
+	if t.IsTyped() {
+		vv.Set(t.AccessList.MarshalRLPWith(arena))
+	}
+
+	if t.IsSponsored() {
+		vv.Set(arena.NewCopyBytes(t.Sponsor.Bytes()))
 	}
 
 	// signature values
@@ -233,6 +254,13 @@ func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 	vv.Set(arena.NewBigInt(t.R))
 	vv.Set(arena.NewBigInt(t.S))
 
+	// the sponsor signs the transaction signed by the sender
+	if t.IsSponsored() {
+		vv.Set(arena.NewBigInt(t.SponsorV))
+		vv.Set(arena.NewBigInt(t.SponsorR))
+		vv.Set(arena.NewBigInt(t.SponsorS))
+	}
+
 	if t.Type == StateTx {
 		vv.Set(arena.NewCopyBytes(t.From.Bytes()))
 	}
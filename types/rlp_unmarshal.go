@@ -407,8 +407,12 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 		num = 9
 	case StateTx:
 		num = 10
+	case AccessListTx:
+		num = 11
 	case DynamicFeeTx:
 		num = 12
+	case SponsoredTx:
+		num = 15
 	default:
 		return fmt.Errorf("transaction type %d not found", t.Type)
 	}
@@ -417,8 +421,8 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 		return fmt.Errorf("incorrect number of transaction elements, expected %d but found %d", num, numElems)
 	}
 
-	// Load Chain ID for dynamic transactions
-	if t.Type == DynamicFeeTx {
+	// Load Chain ID for typed transactions
+	if t.IsTyped() {
 		t.ChainID = new(big.Int)
 		if err = getElem().GetBigInt(t.ChainID); err != nil {
 			return err
@@ -476,11 +480,22 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 		return err
 	}
 
-	// Skipping Access List field since we don't support it.
-	// This is needed to be compatible with other EVM chains and have the same format.
-	// Since we don't have access list, just skip it here.
-	if t.Type == DynamicFeeTx {
-		_ = getElem()
+	// access list
+	if t.IsTyped() {
+		if err = t.AccessList.unmarshalRLPFrom(p, getElem()); err != nil {
+			return err
+		}
+	} else {
+		t.AccessList = nil
+	}
+
+	// sponsor
+	if t.IsSponsored() {
+		if err = getElem().GetAddr(t.Sponsor[:]); err != nil {
+			return err
+		}
+	} else {
+		t.Sponsor = ZeroAddress
 	}
 
 	// V
@@ -501,6 +516,17 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 		return err
 	}
 
+	// sponsor signature
+	if t.IsSponsored() {
+		t.SponsorV, t.SponsorR, t.SponsorS = new(big.Int), new(big.Int), new(big.Int)
+
+		for _, sig := range []*big.Int{t.SponsorV, t.SponsorR, t.SponsorS} {
+			if err = getElem().GetBigInt(sig); err != nil {
+				return err
+			}
+		}
+	}
+
 	if t.Type == StateTx {
 		t.From = ZeroAddress
 
@@ -514,3 +540,52 @@ func (t *Transaction) unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 
 	return nil
 }
+
+// unmarshalRLPFrom unmarshals the access list from the list of [address, [storageKeys...]] tuples
+func (al *TxAccessList) unmarshalRLPFrom(_ *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	// keep the empty access list nil, the same as the transaction without one
+	if len(elems) == 0 {
+		*al = nil
+
+		return nil
+	}
+
+	list := make(TxAccessList, len(elems))
+
+	for i, elem := range elems {
+		tuple, err := elem.GetElems()
+		if err != nil {
+			return err
+		}
+
+		if len(tuple) != 2 {
+			return fmt.Errorf("incorrect number of access tuple elements, expected 2 but found %d", len(tuple))
+		}
+
+		if err = tuple[0].GetAddr(list[i].Address[:]); err != nil {
+			return err
+		}
+
+		keys, err := tuple[1].GetElems()
+		if err != nil {
+			return err
+		}
+
+		list[i].StorageKeys = make([]Hash, len(keys))
+
+		for j, key := range keys {
+			if err = key.GetHash(list[i].StorageKeys[j][:]); err != nil {
+				return err
+			}
+		}
+	}
+
+	*al = list
+
+	return nil
+}
@@ -20,14 +20,16 @@ type TxType byte
 const (
 	LegacyTx     TxType = 0x0
 	StateTx      TxType = 0x7f
+	AccessListTx TxType = 0x01
 	DynamicFeeTx TxType = 0x02
+	SponsoredTx  TxType = 0x7e
 )
 
 func txTypeFromByte(b byte) (TxType, error) {
 	tt := TxType(b)
 
 	switch tt {
-	case LegacyTx, StateTx, DynamicFeeTx:
+	case LegacyTx, StateTx, AccessListTx, DynamicFeeTx, SponsoredTx:
 		return tt, nil
 	default:
 		return tt, fmt.Errorf("unknown transaction type: %d", b)
@@ -41,8 +43,12 @@ func (t TxType) String() (s string) {
 		return "LegacyTx"
 	case StateTx:
 		return "StateTx"
+	case AccessListTx:
+		return "AccessListTx"
 	case DynamicFeeTx:
 		return "DynamicFeeTx"
+	case SponsoredTx:
+		return "SponsoredTx"
 	}
 
 	return
@@ -65,6 +71,14 @@ type Transaction struct {
 
 	ChainID *big.Int
 
+	// AccessList is the list of addresses and storage keys pre-warmed by the transaction (EIP-2930)
+	AccessList TxAccessList
+
+	// Sponsor is the account paying the gas of the sponsored transaction,
+	// it signs the transaction after the sender does
+	Sponsor                      Address
+	SponsorV, SponsorR, SponsorS *big.Int
+
 	// Cache
 	size atomic.Pointer[uint64]
 }
@@ -118,11 +132,45 @@ func (t *Transaction) Copy() *Transaction {
 	tt.Input = make([]byte, len(t.Input))
 	copy(tt.Input[:], t.Input[:])
 
+	tt.AccessList = t.AccessList.Copy()
+
+	if t.SponsorV != nil {
+		tt.SponsorV = new(big.Int).Set(t.SponsorV)
+	}
+
+	if t.SponsorR != nil {
+		tt.SponsorR = new(big.Int).Set(t.SponsorR)
+	}
+
+	if t.SponsorS != nil {
+		tt.SponsorS = new(big.Int).Set(t.SponsorS)
+	}
+
 	return tt
 }
 
-// Cost returns gas * gasPrice + value
-func (t *Transaction) Cost() *big.Int {
+// IsTyped returns true if the transaction is an EIP-2718 typed transaction
+// which is signed with the chain ID and carries the access list
+func (t *Transaction) IsTyped() bool {
+	return t.Type == AccessListTx || t.Type == DynamicFeeTx || t.Type == SponsoredTx
+}
+
+// IsSponsored returns true if the gas of the transaction is paid by the sponsor
+func (t *Transaction) IsSponsored() bool {
+	return t.Type == SponsoredTx
+}
+
+// GasPayer returns the account the gas of the transaction is charged to
+func (t *Transaction) GasPayer() Address {
+	if t.IsSponsored() {
+		return t.Sponsor
+	}
+
+	return t.From
+}
+
+// GasCost returns gas * gasPrice, or gas * gasFeeCap for the dynamic fee transactions
+func (t *Transaction) GasCost() *big.Int {
 	var factor *big.Int
 
 	if t.GasFeeCap != nil && t.GasFeeCap.BitLen() > 0 {
@@ -131,10 +179,12 @@ func (t *Transaction) Cost() *big.Int {
 		factor = new(big.Int).Set(t.GasPrice)
 	}
 
-	total := new(big.Int).Mul(factor, new(big.Int).SetUint64(t.Gas))
-	total = total.Add(total, t.Value)
+	return factor.Mul(factor, new(big.Int).SetUint64(t.Gas))
+}
 
-	return total
+// Cost returns gas * gasPrice + value
+func (t *Transaction) Cost() *big.Int {
+	return new(big.Int).Add(t.GasCost(), t.Value)
 }
 
 // GetGasPrice returns gas price if not empty, or calculates one based on
@@ -220,6 +270,43 @@ func (t *Transaction) GetGasFeeCap() *big.Int {
 	}
 }
 
+// AccessTuple is the element of the access list,
+// the address and the storage keys accessed by the transaction
+type AccessTuple struct {
+	Address     Address `json:"address"`
+	StorageKeys []Hash  `json:"storageKeys"`
+}
+
+// TxAccessList is the EIP-2930 access list of the transaction
+type TxAccessList []AccessTuple
+
+// StorageKeys returns the total number of storage keys in the access list
+func (al TxAccessList) StorageKeys() int {
+	count := 0
+	for _, tuple := range al {
+		count += len(tuple.StorageKeys)
+	}
+
+	return count
+}
+
+// Copy returns a deep copy of the access list
+func (al TxAccessList) Copy() TxAccessList {
+	if al == nil {
+		return nil
+	}
+
+	cpy := make(TxAccessList, len(al))
+	for i, tuple := range al {
+		cpy[i] = AccessTuple{
+			Address:     tuple.Address,
+			StorageKeys: append([]Hash{}, tuple.StorageKeys...),
+		}
+	}
+
+	return cpy
+}
+
 // FindTxByHash returns transaction and its index from a slice of transactions
 func FindTxByHash(txs []*Transaction, hash Hash) (*Transaction, int) {
 	for idx, txn := range txs {